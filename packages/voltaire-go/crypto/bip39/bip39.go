@@ -0,0 +1,209 @@
+// Package bip39 implements BIP-39 mnemonic generation, validation and seed
+// derivation.
+//
+// Mnemonics and passphrases are used as given. Callers handling non-ASCII
+// input must apply Unicode NFKD normalization first, as BIP-39 requires.
+package bip39
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Sizes
+const (
+	WordlistSize = 2048
+	SeedSize     = 64
+	MinEntropy   = 16 // 128 bits, 12 words
+	MaxEntropy   = 32 // 256 bits, 24 words
+)
+
+// seedIterations is the PBKDF2-HMAC-SHA512 iteration count.
+const seedIterations = 2048
+
+// Errors
+var (
+	ErrInvalidEntropy   = errors.New("bip39: entropy must be 16 to 32 bytes in steps of 4")
+	ErrInvalidWordCount = errors.New("bip39: mnemonic must have 12, 15, 18, 21 or 24 words")
+	ErrUnknownWord      = errors.New("bip39: word not in wordlist")
+	ErrInvalidChecksum  = errors.New("bip39: invalid checksum")
+	ErrInvalidWordlist  = errors.New("bip39: wordlist must have 2048 unique words")
+)
+
+// Wordlist maps 11-bit indices to words and back.
+type Wordlist struct {
+	words [WordlistSize]string
+	index map[string]int
+}
+
+// English is the BIP-39 English wordlist.
+var English = MustNewWordlist(englishWords[:])
+
+// NewWordlist creates a Wordlist from 2048 unique words in index order.
+func NewWordlist(words []string) (*Wordlist, error) {
+	if len(words) != WordlistSize {
+		return nil, ErrInvalidWordlist
+	}
+	wl := &Wordlist{index: make(map[string]int, WordlistSize)}
+	for i, w := range words {
+		if w == "" {
+			return nil, ErrInvalidWordlist
+		}
+		if _, dup := wl.index[w]; dup {
+			return nil, ErrInvalidWordlist
+		}
+		wl.words[i] = w
+		wl.index[w] = i
+	}
+	return wl, nil
+}
+
+// MustNewWordlist is like NewWordlist but panics on error.
+func MustNewWordlist(words []string) *Wordlist {
+	wl, err := NewWordlist(words)
+	if err != nil {
+		panic(err)
+	}
+	return wl
+}
+
+// Word returns the word at index i, which must be below WordlistSize.
+func (wl *Wordlist) Word(i int) string {
+	return wl.words[i]
+}
+
+// Index returns the index of word, or false if it is not in the list.
+func (wl *Wordlist) Index(word string) (int, bool) {
+	i, ok := wl.index[word]
+	return i, ok
+}
+
+// NewEntropy returns size bytes of random entropy. size must be between
+// MinEntropy and MaxEntropy and a multiple of 4.
+func NewEntropy(size int) ([]byte, error) {
+	if !validEntropySize(size) {
+		return nil, ErrInvalidEntropy
+	}
+	entropy := make([]byte, size)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, err
+	}
+	return entropy, nil
+}
+
+// Generate returns a new English mnemonic with size bytes of entropy.
+func Generate(size int) (string, error) {
+	entropy, err := NewEntropy(size)
+	if err != nil {
+		return "", err
+	}
+	return English.FromEntropy(entropy)
+}
+
+// FromEntropy returns the English mnemonic encoding entropy.
+func FromEntropy(entropy []byte) (string, error) {
+	return English.FromEntropy(entropy)
+}
+
+// ToEntropy returns the entropy encoded by an English mnemonic.
+func ToEntropy(mnemonic string) ([]byte, error) {
+	return English.ToEntropy(mnemonic)
+}
+
+// Validate checks the words and checksum of an English mnemonic.
+func Validate(mnemonic string) error {
+	return English.Validate(mnemonic)
+}
+
+// FromEntropy returns the mnemonic encoding entropy: the entropy followed
+// by the first len(entropy)/4 bits of its SHA-256 hash, split into 11-bit
+// word indices.
+func (wl *Wordlist) FromEntropy(entropy []byte) (string, error) {
+	if !validEntropySize(len(entropy)) {
+		return "", ErrInvalidEntropy
+	}
+
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), checksum[0])
+	count := len(entropy) * 8 * 3 / 32
+
+	words := make([]string, count)
+	for i := range words {
+		words[i] = wl.words[readBits(data, i*11)]
+	}
+	return strings.Join(words, " "), nil
+}
+
+// ToEntropy returns the entropy encoded by mnemonic, checking every word
+// and the checksum.
+func (wl *Wordlist) ToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, ErrInvalidWordCount
+	}
+
+	// Entropy plus checksum always fits in one extra byte
+	size := len(words) * 11 / 33 * 4
+	data := make([]byte, size+1)
+	for i, w := range words {
+		idx, ok := wl.index[w]
+		if !ok {
+			return nil, ErrUnknownWord
+		}
+		writeBits(data, i*11, idx)
+	}
+
+	entropy := data[:size]
+	checksumBits := size / 4
+	want := sha256.Sum256(entropy)
+	if data[size]>>(8-checksumBits) != want[0]>>(8-checksumBits) {
+		return nil, ErrInvalidChecksum
+	}
+	return entropy, nil
+}
+
+// Validate checks the words and checksum of mnemonic.
+func (wl *Wordlist) Validate(mnemonic string) error {
+	_, err := wl.ToEntropy(mnemonic)
+	return err
+}
+
+// Seed derives the 64-byte BIP-32 seed from a mnemonic and an optional
+// passphrase with PBKDF2-HMAC-SHA512. It does not validate the mnemonic;
+// call Validate first when the input comes from a user.
+func Seed(mnemonic, passphrase string) [SeedSize]byte {
+	normalized := strings.Join(strings.Fields(mnemonic), " ")
+	key := pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase), seedIterations, SeedSize, sha512.New)
+	return [SeedSize]byte(key)
+}
+
+func validEntropySize(n int) bool {
+	return n >= MinEntropy && n <= MaxEntropy && n%4 == 0
+}
+
+// readBits reads the 11-bit big-endian value starting at bit offset.
+func readBits(data []byte, offset int) int {
+	v := 0
+	for i := 0; i < 11; i++ {
+		bit := offset + i
+		v = v<<1 | int(data[bit/8]>>(7-bit%8)&1)
+	}
+	return v
+}
+
+// writeBits writes the 11-bit value v big-endian at bit offset.
+func writeBits(data []byte, offset int, v int) {
+	for i := 0; i < 11; i++ {
+		if v>>(10-i)&1 == 1 {
+			bit := offset + i
+			data[bit/8] |= 1 << (7 - bit%8)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package bip39
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Vectors from the Trezor reference implementation, passphrase "TREZOR".
+var vectors = []struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}{
+	{
+		"00000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		"80808080808080808080808080808080",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+		"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+	},
+	{
+		"ffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+	},
+	{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		"bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+	},
+	{
+		"9e885d952ad362caeb4efe34a8e91bd2",
+		"ozone drill grab fiber curtain grace pudding thank cruise elder eight picnic",
+		"274ddc525802f7c828d8ef7ddbcdc5304e87ac3535913611fbbfa986d0c9e5476c91689f9c8a54fd55bd38606aa6a8595ad213d4c9c9f9aca3fb217069a41028",
+	},
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range vectors {
+		entropy, _ := hex.DecodeString(v.entropy)
+
+		mnemonic, err := FromEntropy(entropy)
+		if err != nil || mnemonic != v.mnemonic {
+			t.Errorf("FromEntropy(%s) = %q, %v, want %q", v.entropy, mnemonic, err, v.mnemonic)
+		}
+
+		decoded, err := ToEntropy(v.mnemonic)
+		if err != nil || !bytes.Equal(decoded, entropy) {
+			t.Errorf("ToEntropy(%q) = %x, %v, want %s", v.mnemonic, decoded, err, v.entropy)
+		}
+
+		seed := Seed(v.mnemonic, "TREZOR")
+		if got := hex.EncodeToString(seed[:]); got != v.seed {
+			t.Errorf("Seed(%q) = %s, want %s", v.mnemonic, got, v.seed)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := vectors[1].mnemonic
+	tests := []struct {
+		name     string
+		mnemonic string
+		wantErr  error
+	}{
+		{"valid", valid, nil},
+		{"extra whitespace", "  " + strings.ReplaceAll(valid, " ", "\t ") + "\n", nil},
+		{"bad checksum", strings.Replace(valid, "yellow", "year", 1), ErrInvalidChecksum},
+		{"unknown word", strings.Replace(valid, "legal", "legally", 1), ErrUnknownWord},
+		{"word count", valid + " zoo", ErrInvalidWordCount},
+		{"empty", "", ErrInvalidWordCount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.mnemonic); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	for _, size := range []int{16, 20, 24, 28, 32} {
+		mnemonic, err := Generate(size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(strings.Fields(mnemonic)), size*3/4; got != want {
+			t.Errorf("Generate(%d) has %d words, want %d", size, got, want)
+		}
+		if err := Validate(mnemonic); err != nil {
+			t.Errorf("Validate(Generate(%d)) = %v", size, err)
+		}
+	}
+
+	for _, size := range []int{0, 12, 17, 36} {
+		if _, err := Generate(size); !errors.Is(err, ErrInvalidEntropy) {
+			t.Errorf("Generate(%d) error = %v, want %v", size, err, ErrInvalidEntropy)
+		}
+	}
+}
+
+func TestWordlist(t *testing.T) {
+	if English.Word(0) != "abandon" || English.Word(WordlistSize-1) != "zoo" {
+		t.Errorf("English wordlist bounds = %q, %q", English.Word(0), English.Word(WordlistSize-1))
+	}
+	if i, ok := English.Index("zoo"); !ok || i != WordlistSize-1 {
+		t.Errorf("Index(zoo) = %d, %v", i, ok)
+	}
+
+	// A custom wordlist encodes the same indices with its own words
+	words := make([]string, WordlistSize)
+	for i := range words {
+		words[i] = strings.ToUpper(englishWords[i])
+	}
+	upper, err := NewWordlist(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entropy, _ := hex.DecodeString(vectors[2].entropy)
+	mnemonic, err := upper.FromEntropy(entropy)
+	if err != nil || mnemonic != strings.ToUpper(vectors[2].mnemonic) {
+		t.Errorf("FromEntropy() = %q, %v", mnemonic, err)
+	}
+	if err := English.Validate(mnemonic); !errors.Is(err, ErrUnknownWord) {
+		t.Errorf("English.Validate(upper) = %v, want %v", err, ErrUnknownWord)
+	}
+
+	words[1] = words[0]
+	if _, err := NewWordlist(words); !errors.Is(err, ErrInvalidWordlist) {
+		t.Errorf("NewWordlist(duplicate) = %v, want %v", err, ErrInvalidWordlist)
+	}
+	if _, err := NewWordlist(words[:10]); !errors.Is(err, ErrInvalidWordlist) {
+		t.Errorf("NewWordlist(short) = %v, want %v", err, ErrInvalidWordlist)
+	}
+}
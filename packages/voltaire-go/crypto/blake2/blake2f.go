@@ -0,0 +1,161 @@
+package blake2
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// Blake2FInputSize is the size of the BLAKE2 F precompile (0x09) input.
+const Blake2FInputSize = 213
+
+// Errors
+var (
+	ErrInvalidBlake2FInputLength = errors.New("blake2: F input must be 213 bytes")
+	ErrInvalidBlake2FFinalFlag   = errors.New("blake2: F final flag must be 0 or 1")
+)
+
+// iv is the BLAKE2b initialization vector.
+var iv = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+// sigma is the BLAKE2b message schedule. Rounds past 10 wrap around.
+var sigma = [10][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+// Blake2FInput is the input of the EIP-152 BLAKE2 F compression
+// precompile.
+type Blake2FInput struct {
+	Rounds uint32
+	H      [8]uint64  // State vector
+	M      [16]uint64 // Message block
+	T      [2]uint64  // Offset counters
+	Final  bool       // Final block indicator
+}
+
+// Encode returns the 213-byte precompile input: rounds as a big-endian
+// uint32, then h, m and t as little-endian uint64 words, then the final
+// flag byte.
+func (in Blake2FInput) Encode() []byte {
+	b := make([]byte, Blake2FInputSize)
+	binary.BigEndian.PutUint32(b[0:4], in.Rounds)
+	for i, w := range in.H {
+		binary.LittleEndian.PutUint64(b[4+8*i:], w)
+	}
+	for i, w := range in.M {
+		binary.LittleEndian.PutUint64(b[68+8*i:], w)
+	}
+	binary.LittleEndian.PutUint64(b[196:], in.T[0])
+	binary.LittleEndian.PutUint64(b[204:], in.T[1])
+	if in.Final {
+		b[212] = 1
+	}
+	return b
+}
+
+// ParseBlake2FInput decodes a precompile input, rejecting the same inputs
+// the precompile does.
+func ParseBlake2FInput(b []byte) (Blake2FInput, error) {
+	if len(b) != Blake2FInputSize {
+		return Blake2FInput{}, ErrInvalidBlake2FInputLength
+	}
+	if b[212] > 1 {
+		return Blake2FInput{}, ErrInvalidBlake2FFinalFlag
+	}
+
+	in := Blake2FInput{
+		Rounds: binary.BigEndian.Uint32(b[0:4]),
+		Final:  b[212] == 1,
+	}
+	for i := range in.H {
+		in.H[i] = binary.LittleEndian.Uint64(b[4+8*i:])
+	}
+	for i := range in.M {
+		in.M[i] = binary.LittleEndian.Uint64(b[68+8*i:])
+	}
+	in.T[0] = binary.LittleEndian.Uint64(b[196:])
+	in.T[1] = binary.LittleEndian.Uint64(b[204:])
+	return in, nil
+}
+
+// Compress runs the compression function on in and returns the precompile
+// output: the new state vector as little-endian uint64 words.
+func (in Blake2FInput) Compress() [64]byte {
+	h := in.H
+	F(&h, in.M, in.T, in.Final, in.Rounds)
+
+	var out [64]byte
+	for i, w := range h {
+		binary.LittleEndian.PutUint64(out[8*i:], w)
+	}
+	return out
+}
+
+// Gas returns the precompile gas cost: one per round.
+func (in Blake2FInput) Gas() uint64 {
+	return uint64(in.Rounds)
+}
+
+// Blake2F runs the BLAKE2 F precompile on a raw 213-byte input and
+// returns its 64-byte output.
+func Blake2F(input []byte) ([64]byte, error) {
+	in, err := ParseBlake2FInput(input)
+	if err != nil {
+		return [64]byte{}, err
+	}
+	return in.Compress(), nil
+}
+
+// F is the BLAKE2b compression function F from RFC 7693 with a
+// configurable number of rounds, as exposed by EIP-152. It updates h in
+// place.
+func F(h *[8]uint64, m [16]uint64, t [2]uint64, final bool, rounds uint32) {
+	var v [16]uint64
+	copy(v[:8], h[:])
+	copy(v[8:], iv[:])
+	v[12] ^= t[0]
+	v[13] ^= t[1]
+	if final {
+		v[14] = ^v[14]
+	}
+
+	for r := uint32(0); r < rounds; r++ {
+		s := &sigma[r%10]
+		g(&v, 0, 4, 8, 12, m[s[0]], m[s[1]])
+		g(&v, 1, 5, 9, 13, m[s[2]], m[s[3]])
+		g(&v, 2, 6, 10, 14, m[s[4]], m[s[5]])
+		g(&v, 3, 7, 11, 15, m[s[6]], m[s[7]])
+		g(&v, 0, 5, 10, 15, m[s[8]], m[s[9]])
+		g(&v, 1, 6, 11, 12, m[s[10]], m[s[11]])
+		g(&v, 2, 7, 8, 13, m[s[12]], m[s[13]])
+		g(&v, 3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range h {
+		h[i] ^= v[i] ^ v[i+8]
+	}
+}
+
+// g is the BLAKE2b mixing function.
+func g(v *[16]uint64, a, b, c, d int, x, y uint64) {
+	v[a] += v[b] + x
+	v[d] = bits.RotateLeft64(v[d]^v[a], -32)
+	v[c] += v[d]
+	v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+	v[a] += v[b] + y
+	v[d] = bits.RotateLeft64(v[d]^v[a], -16)
+	v[c] += v[d]
+	v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+}
@@ -0,0 +1,91 @@
+package blake2
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// blake2fABC is the EIP-152 input for compressing the single block "abc"
+// with the BLAKE2b-512 parameter block, minus the rounds and final flag.
+const blake2fABC = "48c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b" +
+	"6162630000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"03000000000000000000000000000000"
+
+func TestBlake2FVectors(t *testing.T) {
+	// EIP-152 test vectors 4 to 7
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"zero rounds", "00000000" + blake2fABC + "01", "08c9bcf367e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d282e6ad7f520e511f6c3e2b8c68059b9442be0454267ce079217e1319cde05b"},
+		{"12 rounds", "0000000c" + blake2fABC + "01", "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+		{"not final", "0000000c" + blake2fABC + "00", "75ab69d3190a562c51aef8d88f1c2775876944407270c42c9844252c26d2875298743e7f6d5ea2f2d3e8d226039cd31b4e426ac4f2d3d666a610c2116fde4735"},
+		{"one round", "00000001" + blake2fABC + "01", "b63a380cb2897d521994a85234ee2c181b5f844d2c624c002677e9703449d2fba551b3a8333bcdf5f2f7e08993d53923de3d64fcc68c034e717b9293fed7a421"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, _ := hex.DecodeString(tt.input)
+			in, err := ParseBlake2FInput(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(in.Encode(), raw) {
+				t.Errorf("Encode() does not round trip")
+			}
+			out := in.Compress()
+			if got := hex.EncodeToString(out[:]); got != tt.want {
+				t.Errorf("Compress() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlake2FMatchesBlake2b(t *testing.T) {
+	raw, _ := hex.DecodeString("0000000c" + blake2fABC + "01")
+	in, _ := ParseBlake2FInput(raw)
+	if in.Compress() != Hash512([]byte("abc")) {
+		t.Error("12-round F of the final block differs from BLAKE2b-512")
+	}
+}
+
+func TestParseBlake2FInputInvalid(t *testing.T) {
+	// EIP-152 test vectors 0 to 3
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"empty", "", ErrInvalidBlake2FInputLength},
+		{"short", "00000c" + blake2fABC + "01", ErrInvalidBlake2FInputLength},
+		{"long", "000000000c" + blake2fABC + "01", ErrInvalidBlake2FInputLength},
+		{"bad flag", "0000000c" + blake2fABC + "02", ErrInvalidBlake2FFinalFlag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, _ := hex.DecodeString(tt.input)
+			if _, err := Blake2F(raw); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Blake2F() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBlake2F(t *testing.T) {
+	raw, _ := hex.DecodeString("0000000c" + blake2fABC + "01")
+	out, err := Blake2F(raw)
+	if err != nil || out != Hash512([]byte("abc")) {
+		t.Errorf("Blake2F() = %x, %v", out, err)
+	}
+	in, _ := ParseBlake2FInput(raw)
+	if in.Gas() != 12 {
+		t.Errorf("Gas() = %d, want 12", in.Gas())
+	}
+}
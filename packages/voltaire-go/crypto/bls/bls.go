@@ -0,0 +1,158 @@
+// Package bls provides BLS12-381 signatures as used by the Ethereum
+// consensus layer.
+//
+// Public keys are compressed G1 points and signatures are compressed G2
+// points. Messages are hashed to G2 with the proof-of-possession
+// ciphersuite tag DST. Consensus objects are signed over their signing
+// root; see Domain and SigningRoot.
+package bls
+
+import (
+	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+	"github.com/voltaire-labs/voltaire-go/primitives/hex"
+)
+
+// Sizes
+const (
+	SecretKeySize = ffi.BLSSecretKeySize
+	PublicKeySize = ffi.BLSPublicKeySize
+	SignatureSize = ffi.BLSSignatureSize
+)
+
+// DST is the domain separation tag of the Ethereum consensus signature
+// ciphersuite.
+const DST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+// Errors
+var (
+	ErrInvalidSecretKey = errors.New("bls: secret key must be nonzero and below the group order")
+	ErrInvalidPublicKey = errors.New("bls: invalid public key")
+	ErrInvalidSignature = errors.New("bls: invalid signature")
+	ErrEmpty            = errors.New("bls: nothing to aggregate")
+	ErrInvalidLength    = errors.New("bls: invalid length")
+)
+
+// SecretKey is a big-endian BLS secret key.
+type SecretKey [SecretKeySize]byte
+
+// PublicKey is a compressed G1 public key.
+type PublicKey [PublicKeySize]byte
+
+// Signature is a compressed G2 signature.
+type Signature [SignatureSize]byte
+
+// PublicKey derives the public key of sk.
+func (sk SecretKey) PublicKey() (PublicKey, error) {
+	pk, err := ffi.BLSSecretToPublic(sk)
+	if err != nil {
+		return PublicKey{}, ErrInvalidSecretKey
+	}
+	return PublicKey(pk), nil
+}
+
+// Sign signs msg with sk.
+func (sk SecretKey) Sign(msg []byte) (Signature, error) {
+	sig, err := ffi.BLSSign(sk, msg)
+	if err != nil {
+		return Signature{}, ErrInvalidSecretKey
+	}
+	return Signature(sig), nil
+}
+
+// Verify reports whether sig is a valid signature of msg by pk. Keys and
+// signatures that do not decode to points in the right subgroup, and the
+// infinity public key, are reported as invalid.
+func Verify(pk PublicKey, msg []byte, sig Signature) bool {
+	return ffi.BLSVerify(pk, msg, sig)
+}
+
+// AggregateSignatures sums sigs into a single signature.
+func AggregateSignatures(sigs []Signature) (Signature, error) {
+	if len(sigs) == 0 {
+		return Signature{}, ErrEmpty
+	}
+	in := make([][SignatureSize]byte, len(sigs))
+	for i, s := range sigs {
+		in[i] = s
+	}
+	agg, err := ffi.BLSAggregateSignatures(in)
+	if err != nil {
+		return Signature{}, ErrInvalidSignature
+	}
+	return Signature(agg), nil
+}
+
+// AggregatePublicKeys sums pks into a single public key. It rejects keys
+// that do not decode and the infinity key.
+func AggregatePublicKeys(pks []PublicKey) (PublicKey, error) {
+	if len(pks) == 0 {
+		return PublicKey{}, ErrEmpty
+	}
+	agg, err := ffi.BLSAggregatePublicKeys(publicKeys(pks))
+	if err != nil {
+		return PublicKey{}, ErrInvalidPublicKey
+	}
+	return PublicKey(agg), nil
+}
+
+// FastAggregateVerify reports whether sig is a valid aggregate signature
+// of msg by every key in pks, as used for attestations and sync committee
+// messages. It returns false for an empty key list.
+func FastAggregateVerify(pks []PublicKey, msg []byte, sig Signature) bool {
+	if len(pks) == 0 {
+		return false
+	}
+	return ffi.BLSFastAggregateVerify(publicKeys(pks), msg, sig)
+}
+
+func publicKeys(pks []PublicKey) [][PublicKeySize]byte {
+	out := make([][PublicKeySize]byte, len(pks))
+	for i, pk := range pks {
+		out[i] = pk
+	}
+	return out
+}
+
+// Hex returns the 0x-prefixed hex encoding of pk.
+func (pk PublicKey) Hex() string {
+	return hex.EncodeData(pk[:])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (pk PublicKey) MarshalText() ([]byte, error) {
+	return []byte(pk.Hex()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (pk *PublicKey) UnmarshalText(text []byte) error {
+	return unmarshalFixed(pk[:], text)
+}
+
+// Hex returns the 0x-prefixed hex encoding of sig.
+func (sig Signature) Hex() string {
+	return hex.EncodeData(sig[:])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (sig Signature) MarshalText() ([]byte, error) {
+	return []byte(sig.Hex()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (sig *Signature) UnmarshalText(text []byte) error {
+	return unmarshalFixed(sig[:], text)
+}
+
+func unmarshalFixed(dst []byte, text []byte) error {
+	b, err := hex.DecodeData(string(text))
+	if err != nil {
+		return err
+	}
+	if len(b) != len(dst) {
+		return ErrInvalidLength
+	}
+	copy(dst, b)
+	return nil
+}
@@ -0,0 +1,118 @@
+package bls
+
+import (
+	"errors"
+	"testing"
+)
+
+func secretKey(b byte) SecretKey {
+	var sk SecretKey
+	sk[SecretKeySize-1] = b
+	return sk
+}
+
+func TestPublicKey(t *testing.T) {
+	// The public key of secret key 1 is the G1 generator
+	const generator = "0x97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+	pk, err := secretKey(1).PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pk.Hex(); got != generator {
+		t.Errorf("PublicKey() = %s, want %s", got, generator)
+	}
+
+	if _, err := (SecretKey{}).PublicKey(); !errors.Is(err, ErrInvalidSecretKey) {
+		t.Errorf("zero key error = %v, want %v", err, ErrInvalidSecretKey)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	sk := secretKey(42)
+	pk, _ := sk.PublicKey()
+	msg := []byte("attestation data root")
+
+	sig, err := sk.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(pk, msg, sig) {
+		t.Error("Verify() = false for a valid signature")
+	}
+	if Verify(pk, []byte("other message"), sig) {
+		t.Error("Verify() = true for a different message")
+	}
+	other, _ := secretKey(43).PublicKey()
+	if Verify(other, msg, sig) {
+		t.Error("Verify() = true for a different key")
+	}
+	if Verify(PublicKey{}, msg, sig) || Verify(pk, msg, Signature{}) {
+		t.Error("Verify() = true for malformed inputs")
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	msg := []byte("sync committee block root")
+
+	var pks []PublicKey
+	var sigs []Signature
+	for i := byte(1); i <= 4; i++ {
+		sk := secretKey(i)
+		pk, _ := sk.PublicKey()
+		sig, err := sk.Sign(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pks = append(pks, pk)
+		sigs = append(sigs, sig)
+	}
+
+	agg, err := AggregateSignatures(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !FastAggregateVerify(pks, msg, agg) {
+		t.Error("FastAggregateVerify() = false for a valid aggregate")
+	}
+	if FastAggregateVerify(pks[:3], msg, agg) {
+		t.Error("FastAggregateVerify() = true with a missing signer")
+	}
+	if FastAggregateVerify(nil, msg, agg) {
+		t.Error("FastAggregateVerify() = true with no signers")
+	}
+
+	aggPK, err := AggregatePublicKeys(pks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(aggPK, msg, agg) {
+		t.Error("Verify(aggregate key) = false")
+	}
+
+	// 1 + 2 + 3 + 4 = 10: the aggregate key equals the key of the summed
+	// secrets
+	pk10, _ := secretKey(10).PublicKey()
+	if aggPK != pk10 {
+		t.Errorf("AggregatePublicKeys() = %s, want %s", aggPK.Hex(), pk10.Hex())
+	}
+
+	if _, err := AggregateSignatures(nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("AggregateSignatures(nil) error = %v, want %v", err, ErrEmpty)
+	}
+	if _, err := AggregatePublicKeys([]PublicKey{{}}); !errors.Is(err, ErrInvalidPublicKey) {
+		t.Errorf("AggregatePublicKeys(invalid) error = %v, want %v", err, ErrInvalidPublicKey)
+	}
+}
+
+func TestText(t *testing.T) {
+	pk, _ := secretKey(1).PublicKey()
+	text, _ := pk.MarshalText()
+	var decoded PublicKey
+	if err := decoded.UnmarshalText(text); err != nil || decoded != pk {
+		t.Errorf("PublicKey text round trip = %s, %v", decoded.Hex(), err)
+	}
+	var sig Signature
+	if err := sig.UnmarshalText([]byte("0x1234")); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("short signature error = %v, want %v", err, ErrInvalidLength)
+	}
+}
@@ -0,0 +1,49 @@
+package bls
+
+import (
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/ssz"
+)
+
+// DomainType identifies what a consensus signature is for.
+type DomainType [4]byte
+
+// Domain types from the consensus specs.
+var (
+	DomainBeaconProposer              = DomainType{0x00, 0x00, 0x00, 0x00}
+	DomainBeaconAttester              = DomainType{0x01, 0x00, 0x00, 0x00}
+	DomainRandao                      = DomainType{0x02, 0x00, 0x00, 0x00}
+	DomainDeposit                     = DomainType{0x03, 0x00, 0x00, 0x00}
+	DomainVoluntaryExit               = DomainType{0x04, 0x00, 0x00, 0x00}
+	DomainSelectionProof              = DomainType{0x05, 0x00, 0x00, 0x00}
+	DomainAggregateAndProof           = DomainType{0x06, 0x00, 0x00, 0x00}
+	DomainSyncCommittee               = DomainType{0x07, 0x00, 0x00, 0x00}
+	DomainSyncCommitteeSelectionProof = DomainType{0x08, 0x00, 0x00, 0x00}
+	DomainContributionAndProof        = DomainType{0x09, 0x00, 0x00, 0x00}
+	DomainBLSToExecutionChange        = DomainType{0x0a, 0x00, 0x00, 0x00}
+	DomainApplicationMask             = DomainType{0x00, 0x00, 0x00, 0x01}
+)
+
+// ForkDataRoot returns hash_tree_root(ForkData(version,
+// genesisValidatorsRoot)).
+func ForkDataRoot(version [4]byte, genesisValidatorsRoot hash.Hash) hash.Hash {
+	return ssz.ContainerRoot(ssz.BytesRoot(version[:]), genesisValidatorsRoot)
+}
+
+// Domain returns compute_domain(domainType, forkVersion,
+// genesisValidatorsRoot): the domain type followed by the first 28 bytes
+// of the fork data root. Deposits are signed with the genesis fork version
+// and a zero genesis validators root.
+func Domain(domainType DomainType, forkVersion [4]byte, genesisValidatorsRoot hash.Hash) hash.Hash {
+	root := ForkDataRoot(forkVersion, genesisValidatorsRoot)
+	var d hash.Hash
+	copy(d[:4], domainType[:])
+	copy(d[4:], root[:28])
+	return d
+}
+
+// SigningRoot returns hash_tree_root(SigningData(objectRoot, domain)), the
+// message actually signed for a consensus object.
+func SigningRoot(objectRoot hash.Hash, domain hash.Hash) hash.Hash {
+	return ssz.ContainerRoot(objectRoot, domain)
+}
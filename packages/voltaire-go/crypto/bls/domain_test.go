@@ -0,0 +1,35 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/sha256"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+func TestDomain(t *testing.T) {
+	// Mainnet deposit domain: genesis fork version and zero validators root
+	const want = "0x03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9"
+	if got := Domain(DomainDeposit, [4]byte{}, hash.Zero).Hex(); got != want {
+		t.Errorf("Domain(deposit) = %s, want %s", got, want)
+	}
+
+	version := [4]byte{0x04, 0x00, 0x00, 0x00}
+	gvr := hash.MustFromHex("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95")
+	var versionChunk hash.Hash
+	copy(versionChunk[:], version[:])
+	root := sha256.Sum(versionChunk[:], gvr[:])
+
+	d := Domain(DomainBeaconProposer, version, gvr)
+	if [4]byte(d[:4]) != DomainBeaconProposer || [28]byte(d[4:]) != [28]byte(root[:28]) {
+		t.Errorf("Domain(proposer) = %s, fork data root %s", d.Hex(), root.Hex())
+	}
+}
+
+func TestSigningRoot(t *testing.T) {
+	object := hash.MustFromHex("0x1111111111111111111111111111111111111111111111111111111111111111")
+	domain := Domain(DomainBeaconAttester, [4]byte{}, hash.Zero)
+	if got, want := SigningRoot(object, domain), sha256.Sum(object[:], domain[:]); got != want {
+		t.Errorf("SigningRoot() = %s, want %s", got.Hex(), want.Hex())
+	}
+}
@@ -0,0 +1,493 @@
+// Package musig2 implements BIP-327 MuSig2 multi-signatures over
+// secp256k1.
+//
+// Signers aggregate their public keys with KeyAgg, exchange public nonces
+// from NonceGen, combine them with NonceAgg, and each produce a partial
+// signature in a Session. Aggregating the partial signatures gives an
+// ordinary BIP-340 signature that verifies under the aggregated x-only
+// key with schnorr.Verify.
+//
+// Public keys are 33-byte compressed points, as in BIP-327. A secret
+// nonce must never be used twice: Session.Sign clears it.
+//
+// Secret nonces and private keys are multiplied by the generator in
+// constant time by the native library. Key aggregation, nonce
+// aggregation and verification work only on public values and use the
+// faster variable-time arithmetic.
+package musig2
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/voltaire-labs/voltaire-go/crypto/schnorr"
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+)
+
+// Sizes
+const (
+	PrivateKeySize       = 32
+	PublicKeySize        = 33
+	PubNonceSize         = 66
+	AggNonceSize         = 66
+	SecNonceSize         = 97
+	PartialSignatureSize = 32
+)
+
+// Errors
+var (
+	ErrNoKeys            = errors.New("musig2: no public keys")
+	ErrInvalidPublicKey  = errors.New("musig2: invalid public key")
+	ErrInvalidPrivateKey = errors.New("musig2: private key out of range [1, n-1]")
+	ErrInvalidTweak      = errors.New("musig2: tweak out of range or produces infinity")
+	ErrInvalidNonce      = errors.New("musig2: invalid public nonce")
+	ErrInvalidSecNonce   = errors.New("musig2: secret nonce is zero or already used")
+	ErrKeyMismatch       = errors.New("musig2: secret nonce or private key does not match a signer key")
+	ErrInvalidPartialSig = errors.New("musig2: partial signature out of range")
+	ErrNonceGenFailed    = errors.New("musig2: nonce generation failed")
+)
+
+// PubNonce is a signer's public nonce: two compressed points.
+type PubNonce [PubNonceSize]byte
+
+// AggNonce is the aggregate of all public nonces. Either half may be 33
+// zero bytes, encoding the point at infinity.
+type AggNonce [AggNonceSize]byte
+
+// SecNonce is a signer's secret nonce, k1 || k2 || public key. It must be
+// kept private and used for a single signature.
+type SecNonce [SecNonceSize]byte
+
+// PartialSignature is one signer's share of the final signature.
+type PartialSignature [PartialSignatureSize]byte
+
+// Tagged hash prefixes used by BIP-327.
+const (
+	tagKeyAggList  = "KeyAgg list"
+	tagKeyAggCoeff = "KeyAgg coefficient"
+	tagAux         = "MuSig/aux"
+	tagNonce       = "MuSig/nonce"
+	tagNonceCoef   = "MuSig/noncecoef"
+	tagChallenge   = "BIP0340/challenge"
+)
+
+// KeyAggContext is the result of key aggregation, optionally tweaked. It
+// is immutable; ApplyTweak returns a new context.
+type KeyAggContext struct {
+	pubkeys [][PublicKeySize]byte
+	list    [32]byte
+	second  [PublicKeySize]byte
+	q       secp256k1.JacobianPoint // affine
+	gacc    secp256k1.ModNScalar
+	tacc    secp256k1.ModNScalar
+}
+
+// SortKeys returns a copy of pubkeys sorted lexicographically, the
+// canonical order for KeyAgg.
+func SortKeys(pubkeys [][PublicKeySize]byte) [][PublicKeySize]byte {
+	sorted := append([][PublicKeySize]byte(nil), pubkeys...)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i][:], sorted[j][:]) < 0 })
+	return sorted
+}
+
+// KeyAgg aggregates pubkeys in the given order. Signers must agree on the
+// order; use SortKeys for a canonical one.
+func KeyAgg(pubkeys [][PublicKeySize]byte) (*KeyAggContext, error) {
+	if len(pubkeys) == 0 {
+		return nil, ErrNoKeys
+	}
+	ctx := &KeyAggContext{pubkeys: append([][PublicKeySize]byte(nil), pubkeys...)}
+	ctx.list = schnorr.TaggedHash(tagKeyAggList, concatKeys(pubkeys))
+	for _, pk := range pubkeys[1:] {
+		if pk != pubkeys[0] {
+			ctx.second = pk
+			break
+		}
+	}
+
+	for i, pk := range pubkeys {
+		p, err := parsePoint(pk[:])
+		if err != nil {
+			return nil, fmt.Errorf("%w at index %d", ErrInvalidPublicKey, i)
+		}
+		a := ctx.coeff(pk)
+		var term secp256k1.JacobianPoint
+		secp256k1.ScalarMultNonConst(&a, &p, &term)
+		secp256k1.AddNonConst(&ctx.q, &term, &ctx.q)
+	}
+	if isInfinity(&ctx.q) {
+		return nil, ErrInvalidPublicKey
+	}
+	ctx.q.ToAffine()
+	ctx.gacc.SetInt(1)
+	return ctx, nil
+}
+
+// ApplyTweak returns the context tweaked by t: x-only tweaks as used by
+// BIP-341 Taproot, or plain tweaks as used by BIP-32 derivation.
+func (ctx *KeyAggContext) ApplyTweak(tweak [32]byte, xOnly bool) (*KeyAggContext, error) {
+	var t secp256k1.ModNScalar
+	if overflow := t.SetBytes(&tweak); overflow != 0 {
+		return nil, ErrInvalidTweak
+	}
+	var g secp256k1.ModNScalar
+	g.SetInt(1)
+	if xOnly && ctx.q.Y.IsOdd() {
+		g.Negate()
+	}
+
+	out := *ctx
+	var gq, tg secp256k1.JacobianPoint
+	secp256k1.ScalarMultNonConst(&g, &ctx.q, &gq)
+	secp256k1.ScalarBaseMultNonConst(&t, &tg)
+	secp256k1.AddNonConst(&gq, &tg, &out.q)
+	if isInfinity(&out.q) {
+		return nil, ErrInvalidTweak
+	}
+	out.q.ToAffine()
+	out.gacc.Mul2(&g, &ctx.gacc)
+	out.tacc.Mul2(&g, &ctx.tacc).Add(&t)
+	return &out, nil
+}
+
+// AggregatedKey returns the x-only aggregated key that the final
+// signature verifies under.
+func (ctx *KeyAggContext) AggregatedKey() schnorr.PublicKey {
+	var pk schnorr.PublicKey
+	ctx.q.X.PutBytesUnchecked(pk[:])
+	return pk
+}
+
+// PlainKey returns the aggregated key as a compressed point.
+func (ctx *KeyAggContext) PlainKey() [PublicKeySize]byte {
+	return compress(&ctx.q)
+}
+
+// coeff returns the key aggregation coefficient of pk. The second
+// distinct key gets coefficient one.
+func (ctx *KeyAggContext) coeff(pk [PublicKeySize]byte) secp256k1.ModNScalar {
+	var a secp256k1.ModNScalar
+	if pk == ctx.second {
+		a.SetInt(1)
+		return a
+	}
+	h := schnorr.TaggedHash(tagKeyAggCoeff, ctx.list[:], pk[:])
+	a.SetBytes(&h)
+	return a
+}
+
+func (ctx *KeyAggContext) hasKey(pk [PublicKeySize]byte) bool {
+	for _, k := range ctx.pubkeys {
+		if k == pk {
+			return true
+		}
+	}
+	return false
+}
+
+// NonceParams are the inputs to NonceGen. Only PublicKey is required; the
+// others are mixed into the nonce as defense in depth against a weak
+// random source.
+type NonceParams struct {
+	PublicKey     [PublicKeySize]byte
+	PrivateKey    []byte
+	AggregatedKey []byte
+	// Message is the message to be signed, if known. A nil Message means
+	// "unknown"; a non-nil empty one is the empty message.
+	Message []byte
+	Extra   []byte
+}
+
+// NonceGen returns a fresh nonce pair for one signing session, using
+// randomness from crypto/rand.
+func NonceGen(p NonceParams) (SecNonce, PubNonce, error) {
+	var rnd [32]byte
+	if _, err := rand.Read(rnd[:]); err != nil {
+		return SecNonce{}, PubNonce{}, err
+	}
+	return nonceGen(rnd, p)
+}
+
+func nonceGen(rnd [32]byte, p NonceParams) (SecNonce, PubNonce, error) {
+	if len(p.PrivateKey) > 0 {
+		if len(p.PrivateKey) != PrivateKeySize {
+			return SecNonce{}, PubNonce{}, ErrInvalidPrivateKey
+		}
+		mask := schnorr.TaggedHash(tagAux, rnd[:])
+		for i := range rnd {
+			rnd[i] = p.PrivateKey[i] ^ mask[i]
+		}
+	}
+
+	msgPrefixed := []byte{0}
+	if p.Message != nil {
+		msgPrefixed = binary.BigEndian.AppendUint64([]byte{1}, uint64(len(p.Message)))
+		msgPrefixed = append(msgPrefixed, p.Message...)
+	}
+	extraLen := binary.BigEndian.AppendUint32(nil, uint32(len(p.Extra)))
+
+	var sec SecNonce
+	var pub PubNonce
+	for i := 0; i < 2; i++ {
+		h := schnorr.TaggedHash(tagNonce,
+			rnd[:],
+			[]byte{byte(len(p.PublicKey))}, p.PublicKey[:],
+			[]byte{byte(len(p.AggregatedKey))}, p.AggregatedKey,
+			msgPrefixed,
+			extraLen, p.Extra,
+			[]byte{byte(i)},
+		)
+		var k secp256k1.ModNScalar
+		k.SetBytes(&h)
+		if k.IsZero() {
+			return SecNonce{}, PubNonce{}, ErrNonceGenFailed
+		}
+		r, err := baseMult(&k)
+		if err != nil {
+			return SecNonce{}, PubNonce{}, ErrNonceGenFailed
+		}
+		k.PutBytesUnchecked(sec[32*i:])
+		c := compress(&r)
+		copy(pub[33*i:], c[:])
+	}
+	copy(sec[64:], p.PublicKey[:])
+	return sec, pub, nil
+}
+
+// NonceAgg sums the public nonces of all signers.
+func NonceAgg(pubnonces []PubNonce) (AggNonce, error) {
+	var agg AggNonce
+	for j := 0; j < 2; j++ {
+		var r secp256k1.JacobianPoint
+		for i, n := range pubnonces {
+			p, err := parsePoint(n[33*j : 33*j+33])
+			if err != nil {
+				return AggNonce{}, fmt.Errorf("%w at index %d", ErrInvalidNonce, i)
+			}
+			secp256k1.AddNonConst(&r, &p, &r)
+		}
+		if !isInfinity(&r) {
+			r.ToAffine()
+			c := compress(&r)
+			copy(agg[33*j:], c[:])
+		}
+	}
+	return agg, nil
+}
+
+// Session holds the values shared by all signers for one message: the
+// key aggregation context, the aggregate nonce and the message.
+type Session struct {
+	ctx *KeyAggContext
+	b   secp256k1.ModNScalar
+	e   secp256k1.ModNScalar
+	r   secp256k1.JacobianPoint // affine
+}
+
+// NewSession prepares signing msg under ctx with the aggregate nonce.
+func NewSession(ctx *KeyAggContext, aggNonce AggNonce, msg []byte) (*Session, error) {
+	r1, err := parsePointExt(aggNonce[:33])
+	if err != nil {
+		return nil, ErrInvalidNonce
+	}
+	r2, err := parsePointExt(aggNonce[33:])
+	if err != nil {
+		return nil, ErrInvalidNonce
+	}
+
+	s := &Session{ctx: ctx}
+	qx := ctx.AggregatedKey()
+	bh := schnorr.TaggedHash(tagNonceCoef, aggNonce[:], qx[:], msg)
+	s.b.SetBytes(&bh)
+
+	// R = R1 + b*R2, or G if that is infinity
+	var br2 secp256k1.JacobianPoint
+	secp256k1.ScalarMultNonConst(&s.b, &r2, &br2)
+	secp256k1.AddNonConst(&r1, &br2, &s.r)
+	if isInfinity(&s.r) {
+		var one secp256k1.ModNScalar
+		one.SetInt(1)
+		secp256k1.ScalarBaseMultNonConst(&one, &s.r)
+	}
+	s.r.ToAffine()
+
+	var rx [32]byte
+	s.r.X.PutBytesUnchecked(rx[:])
+	eh := schnorr.TaggedHash(tagChallenge, rx[:], qx[:], msg)
+	s.e.SetBytes(&eh)
+	return s, nil
+}
+
+// Sign returns the partial signature of priv, consuming secnonce. The
+// secret nonce is zeroed even on failure, so it cannot be reused.
+func (s *Session) Sign(secnonce *SecNonce, priv [PrivateKeySize]byte) (PartialSignature, error) {
+	sec := *secnonce
+	*secnonce = SecNonce{}
+
+	var k1, k2 secp256k1.ModNScalar
+	o1 := k1.SetByteSlice(sec[:32])
+	o2 := k2.SetByteSlice(sec[32:64])
+	if o1 || o2 || k1.IsZero() || k2.IsZero() {
+		return PartialSignature{}, ErrInvalidSecNonce
+	}
+	if s.r.Y.IsOdd() {
+		k1.Negate()
+		k2.Negate()
+	}
+
+	var d secp256k1.ModNScalar
+	if overflow := d.SetBytes(&priv); overflow != 0 || d.IsZero() {
+		return PartialSignature{}, ErrInvalidPrivateKey
+	}
+	p, err := baseMult(&d)
+	if err != nil {
+		return PartialSignature{}, ErrInvalidPrivateKey
+	}
+	pk := compress(&p)
+	if !bytes.Equal(pk[:], sec[64:]) || !s.ctx.hasKey(pk) {
+		return PartialSignature{}, ErrKeyMismatch
+	}
+
+	// d = g * gacc * d', with g = -1 if Q has odd y
+	a := s.ctx.coeff(pk)
+	d.Mul(&s.ctx.gacc)
+	if s.ctx.q.Y.IsOdd() {
+		d.Negate()
+	}
+
+	// s = k1 + b*k2 + e*a*d
+	sig := new(secp256k1.ModNScalar).Mul2(&s.b, &k2).Add(&k1)
+	sig.Add(new(secp256k1.ModNScalar).Mul2(&s.e, &a).Mul(&d))
+
+	var psig PartialSignature
+	sig.PutBytesUnchecked(psig[:])
+	return psig, nil
+}
+
+// PartialVerify reports whether psig is a valid partial signature by the
+// signer with public key pk and public nonce pubnonce.
+func (s *Session) PartialVerify(psig PartialSignature, pubnonce PubNonce, pk [PublicKeySize]byte) bool {
+	var sig secp256k1.ModNScalar
+	if overflow := sig.SetByteSlice(psig[:]); overflow {
+		return false
+	}
+	if !s.ctx.hasKey(pk) {
+		return false
+	}
+	r1, err1 := parsePoint(pubnonce[:33])
+	r2, err2 := parsePoint(pubnonce[33:])
+	p, err3 := parsePoint(pk[:])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return false
+	}
+
+	// Re = R1 + b*R2, negated if R has odd y
+	var re, br2 secp256k1.JacobianPoint
+	secp256k1.ScalarMultNonConst(&s.b, &r2, &br2)
+	secp256k1.AddNonConst(&r1, &br2, &re)
+	if s.r.Y.IsOdd() {
+		re.ToAffine()
+		re.Y.Negate(1).Normalize()
+	}
+
+	// s*G == Re + e*a*g*gacc*P
+	a := s.ctx.coeff(pk)
+	c := new(secp256k1.ModNScalar).Mul2(&s.e, &a).Mul(&s.ctx.gacc)
+	if s.ctx.q.Y.IsOdd() {
+		c.Negate()
+	}
+	var lhs, cp, rhs secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(&sig, &lhs)
+	secp256k1.ScalarMultNonConst(c, &p, &cp)
+	secp256k1.AddNonConst(&re, &cp, &rhs)
+	lhs.ToAffine()
+	rhs.ToAffine()
+	return lhs.X.Equals(&rhs.X) && lhs.Y.Equals(&rhs.Y)
+}
+
+// Aggregate combines the partial signatures of all signers into a BIP-340
+// signature under ctx.AggregatedKey().
+func (s *Session) Aggregate(psigs []PartialSignature) (schnorr.Signature, error) {
+	var sum secp256k1.ModNScalar
+	for i, psig := range psigs {
+		var si secp256k1.ModNScalar
+		if overflow := si.SetByteSlice(psig[:]); overflow {
+			return schnorr.Signature{}, fmt.Errorf("%w at index %d", ErrInvalidPartialSig, i)
+		}
+		sum.Add(&si)
+	}
+
+	// s += e * g * tacc
+	et := new(secp256k1.ModNScalar).Mul2(&s.e, &s.ctx.tacc)
+	if s.ctx.q.Y.IsOdd() {
+		et.Negate()
+	}
+	sum.Add(et)
+
+	var sig schnorr.Signature
+	s.r.X.PutBytesUnchecked(sig[:32])
+	sum.PutBytesUnchecked(sig[32:])
+	return sig, nil
+}
+
+// baseMult returns k*G in affine form for a secret scalar k, using the
+// native library's constant-time multiplication rather than decred's
+// ScalarBaseMultNonConst, which branches on the bits of k.
+func baseMult(k *secp256k1.ModNScalar) (secp256k1.JacobianPoint, error) {
+	xy, err := ffi.Secp256k1PublicKey(k.Bytes())
+	if err != nil {
+		return secp256k1.JacobianPoint{}, err
+	}
+	var p secp256k1.JacobianPoint
+	p.X.SetByteSlice(xy[:32])
+	p.Y.SetByteSlice(xy[32:])
+	p.Z.SetInt(1)
+	return p, nil
+}
+
+// parsePoint decodes a compressed point.
+func parsePoint(b []byte) (secp256k1.JacobianPoint, error) {
+	var p secp256k1.JacobianPoint
+	pk, err := secp256k1.ParsePubKey(b)
+	if err != nil || len(b) != PublicKeySize {
+		return p, ErrInvalidPublicKey
+	}
+	pk.AsJacobian(&p)
+	return p, nil
+}
+
+// parsePointExt decodes a compressed point, or 33 zero bytes as infinity.
+func parsePointExt(b []byte) (secp256k1.JacobianPoint, error) {
+	if bytes.Equal(b, make([]byte, PublicKeySize)) {
+		return secp256k1.JacobianPoint{}, nil
+	}
+	return parsePoint(b)
+}
+
+// compress returns the compressed encoding of an affine point.
+func compress(p *secp256k1.JacobianPoint) [PublicKeySize]byte {
+	var out [PublicKeySize]byte
+	out[0] = 0x02
+	if p.Y.IsOdd() {
+		out[0] = 0x03
+	}
+	p.X.PutBytesUnchecked(out[1:])
+	return out
+}
+
+func isInfinity(p *secp256k1.JacobianPoint) bool {
+	return (p.X.IsZero() && p.Y.IsZero()) || p.Z.IsZero()
+}
+
+func concatKeys(pubkeys [][PublicKeySize]byte) []byte {
+	b := make([]byte, 0, len(pubkeys)*PublicKeySize)
+	for _, pk := range pubkeys {
+		b = append(b, pk[:]...)
+	}
+	return b
+}
@@ -0,0 +1,665 @@
+package musig2
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/voltaire-labs/voltaire-go/crypto/schnorr"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Public keys from the BIP-327 key_agg_vectors.json.
+var vectorKeys = [][PublicKeySize]byte{
+	[PublicKeySize]byte(mustHex("02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9")),
+	[PublicKeySize]byte(mustHex("03DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659")),
+	[PublicKeySize]byte(mustHex("023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66")),
+}
+
+func TestKeyAggVectors(t *testing.T) {
+	tests := []struct {
+		indices []int
+		want    string
+	}{
+		{[]int{0, 1, 2}, "90539EEDE565F5D054F32CC0C220126889ED1E5D193BAF15AEF344FE59D4610C"},
+		{[]int{2, 1, 0}, "6204DE8B083426DC6EAF9502D27024D53FC826BF7D2012148A0575435DF54B2B"},
+		{[]int{0, 0, 0}, "B436E3BAD62B8CD409969A224731C193D051162D8C5AE8B109306127DA3AA935"},
+		{[]int{0, 0, 1, 1}, "69BC22BFA5D106306E48A20679DE1D7389386124D07571D0D872686028C26A3E"},
+	}
+
+	for _, tt := range tests {
+		var keys [][PublicKeySize]byte
+		for _, i := range tt.indices {
+			keys = append(keys, vectorKeys[i])
+		}
+		ctx, err := KeyAgg(keys)
+		if err != nil {
+			t.Fatalf("KeyAgg(%v) error = %v", tt.indices, err)
+		}
+		if got := ctx.AggregatedKey(); got != schnorr.PublicKey(mustHex(tt.want)) {
+			t.Errorf("KeyAgg(%v) = %X, want %s", tt.indices, got, tt.want)
+		}
+	}
+}
+
+func TestKeyAggInvalid(t *testing.T) {
+	if _, err := KeyAgg(nil); !errors.Is(err, ErrNoKeys) {
+		t.Errorf("KeyAgg(nil) error = %v, want %v", err, ErrNoKeys)
+	}
+	bad := vectorKeys[0]
+	bad[0] = 0x04
+	if _, err := KeyAgg([][PublicKeySize]byte{vectorKeys[1], bad}); !errors.Is(err, ErrInvalidPublicKey) {
+		t.Errorf("KeyAgg(bad prefix) error = %v, want %v", err, ErrInvalidPublicKey)
+	}
+	ctx, _ := KeyAgg(vectorKeys)
+	var n [32]byte
+	copy(n[:], mustHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141"))
+	if _, err := ctx.ApplyTweak(n, true); !errors.Is(err, ErrInvalidTweak) {
+		t.Errorf("ApplyTweak(n) error = %v, want %v", err, ErrInvalidTweak)
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	sorted := SortKeys(vectorKeys)
+	if sorted[0] != vectorKeys[2] || sorted[1] != vectorKeys[0] || sorted[2] != vectorKeys[1] {
+		t.Errorf("SortKeys() = %X", sorted)
+	}
+	if vectorKeys[1][0] != 0x03 {
+		t.Error("SortKeys() modified its input")
+	}
+}
+
+type signer struct {
+	priv [PrivateKeySize]byte
+	pub  [PublicKeySize]byte
+	sec  SecNonce
+	pubn PubNonce
+}
+
+// sign runs a full signing session for the given private keys and tweaks
+// and returns the aggregated key and final signature.
+func sign(t *testing.T, privs []byte, tweaks []tweak, msg []byte) (schnorr.PublicKey, schnorr.Signature) {
+	t.Helper()
+	signers := make([]signer, len(privs))
+	keys := make([][PublicKeySize]byte, len(privs))
+	for i, b := range privs {
+		signers[i].priv[31] = b
+		keys[i] = pubKey(signers[i].priv)
+		signers[i].pub = keys[i]
+	}
+
+	ctx, err := KeyAgg(SortKeys(keys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tw := range tweaks {
+		if ctx, err = ctx.ApplyTweak(tw.tweak, tw.xOnly); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pubnonces := make([]PubNonce, len(signers))
+	for i := range signers {
+		s := &signers[i]
+		aggPK := ctx.AggregatedKey()
+		s.sec, s.pubn, err = NonceGen(NonceParams{PublicKey: s.pub, PrivateKey: s.priv[:], AggregatedKey: aggPK[:], Message: msg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubnonces[i] = s.pubn
+	}
+	aggNonce, err := NonceAgg(pubnonces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := NewSession(ctx, aggNonce, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	psigs := make([]PartialSignature, len(signers))
+	for i := range signers {
+		s := &signers[i]
+		if psigs[i], err = session.Sign(&s.sec, s.priv); err != nil {
+			t.Fatal(err)
+		}
+		if !session.PartialVerify(psigs[i], s.pubn, s.pub) {
+			t.Errorf("PartialVerify(signer %d) = false", i)
+		}
+		if other := signers[(i+1)%len(signers)].pub; other != s.pub && session.PartialVerify(psigs[i], s.pubn, other) {
+			t.Errorf("PartialVerify(signer %d, wrong key) = true", i)
+		}
+	}
+	sig, err := session.Aggregate(psigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ctx.AggregatedKey(), sig
+}
+
+func pubKey(priv [PrivateKeySize]byte) [PublicKeySize]byte {
+	return [PublicKeySize]byte(secp256k1.PrivKeyFromBytes(priv[:]).PubKey().SerializeCompressed())
+}
+
+type tweak = struct {
+	tweak [32]byte
+	xOnly bool
+}
+
+func TestSignAndAggregate(t *testing.T) {
+	msg := mustHex("599C67EA410D005B9DA90817CF03ED3B1C868E4DA4EDF00A5880B0082C237869")
+	taproot := [32]byte(mustHex("E8F791FF9225A2AF0102AFFF4A9A723D9612A682A25EBE79802B263CDFCD83BB"))
+	bip32 := [32]byte(mustHex("AE2EA797CC0FE72AC5B97B97F3C6957D7E4199A167A58EB08BCAFFDA70AC0455"))
+
+	tests := []struct {
+		name   string
+		privs  []byte
+		tweaks []tweak
+		msg    []byte
+	}{
+		{"two signers", []byte{1, 2}, nil, msg},
+		{"three signers", []byte{3, 5, 7}, nil, msg},
+		{"single signer", []byte{9}, nil, msg},
+		{"duplicate keys", []byte{4, 4, 6}, nil, msg},
+		{"empty message", []byte{1, 2}, nil, []byte{}},
+		{"x-only tweak", []byte{1, 2, 3}, []tweak{{taproot, true}}, msg},
+		{"plain then x-only tweak", []byte{1, 2, 3}, []tweak{{bip32, false}, {taproot, true}}, msg},
+		{"mixed tweaks", []byte{8, 10}, []tweak{{bip32, false}, {taproot, true}, {bip32, true}, {taproot, false}}, msg},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pk, sig := sign(t, tt.privs, tt.tweaks, tt.msg)
+			if !schnorr.Verify(pk, tt.msg, sig) {
+				t.Errorf("schnorr.Verify(%X, %X) = false", pk, sig)
+			}
+			if schnorr.Verify(pk, append([]byte{0}, tt.msg...), sig) {
+				t.Error("signature verifies for a different message")
+			}
+		})
+	}
+}
+
+func TestSignRejectsReuse(t *testing.T) {
+	var priv [PrivateKeySize]byte
+	priv[31] = 1
+	pk := pubKey(priv)
+	ctx, _ := KeyAgg([][PublicKeySize]byte{pk})
+	sec, pub, err := NonceGen(NonceParams{PublicKey: pk})
+	if err != nil {
+		t.Fatal(err)
+	}
+	agg, _ := NonceAgg([]PubNonce{pub})
+	session, err := NewSession(ctx, agg, []byte("msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := priv
+	other[31] = 2
+	if _, err := session.Sign(&sec, other); !errors.Is(err, ErrKeyMismatch) {
+		t.Errorf("Sign(wrong key) error = %v, want %v", err, ErrKeyMismatch)
+	}
+	if sec != (SecNonce{}) {
+		t.Error("Sign() did not clear the secret nonce")
+	}
+	if _, err := session.Sign(&sec, priv); !errors.Is(err, ErrInvalidSecNonce) {
+		t.Errorf("Sign(used nonce) error = %v, want %v", err, ErrInvalidSecNonce)
+	}
+}
+
+func TestNonceAgg(t *testing.T) {
+	var priv [PrivateKeySize]byte
+	priv[31] = 1
+	_, pub, err := NonceGen(NonceParams{PublicKey: pubKey(priv)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A nonce and its negation sum to infinity, encoded as zeros
+	neg := pub
+	neg[0] ^= 1
+	neg[33] ^= 1
+	agg, err := NonceAgg([]PubNonce{pub, neg})
+	if err != nil || agg != (AggNonce{}) {
+		t.Errorf("NonceAgg(R, -R) = %X, %v", agg, err)
+	}
+	ctx, _ := KeyAgg(vectorKeys)
+	if _, err := NewSession(ctx, agg, nil); err != nil {
+		t.Errorf("NewSession(infinite nonce) error = %v", err)
+	}
+
+	bad := pub
+	bad[33] = 0x05
+	if _, err := NonceAgg([]PubNonce{pub, bad}); !errors.Is(err, ErrInvalidNonce) {
+		t.Errorf("NonceAgg(bad) error = %v, want %v", err, ErrInvalidNonce)
+	}
+}
+
+func TestNonceGenDeterministic(t *testing.T) {
+	p := NonceParams{PublicKey: vectorKeys[0], Message: []byte{}}
+	sec1, pub1, _ := nonceGen([32]byte{1}, p)
+	sec2, pub2, _ := nonceGen([32]byte{1}, p)
+	if sec1 != sec2 || pub1 != pub2 {
+		t.Error("nonceGen() is not deterministic in its randomness")
+	}
+	// An absent message differs from an empty one
+	p.Message = nil
+	if sec3, _, _ := nonceGen([32]byte{1}, p); sec3 == sec1 {
+		t.Error("nil and empty messages give the same nonce")
+	}
+	p.PrivateKey = []byte{1}
+	if _, _, err := nonceGen([32]byte{1}, p); !errors.Is(err, ErrInvalidPrivateKey) {
+		t.Errorf("nonceGen(short key) error = %v, want %v", err, ErrInvalidPrivateKey)
+	}
+}
+
+// Inputs shared by the BIP-327 sign_verify and tweak vectors.
+var (
+	vectorPriv = [PrivateKeySize]byte(mustHex("7FB9E0E687ADA1EEBF7ECFE2F21E73EBDB51A7D450948DFE8D76D7F2D1007671"))
+	vectorSec  = SecNonce(mustHex("508B81A611F100A6B2B6B29656590898AF488BCF2E1F55CF22E5CFB84421FE61" +
+		"FA27FD49B1D50085B481285E1CA205D55C82CC1B31FF5CD54A489829355901F7" +
+		"03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9"))
+	vectorMsg = mustHex("F95466D086770E689964664219266FE5ED215C92AE20BAB5C9D79ADDDDF3C0CF")
+)
+
+// vectorKeyAgg aggregates the keys at indices of pubkeys, given as hex.
+func vectorKeyAgg(t *testing.T, pubkeys []string, indices []int) (*KeyAggContext, error) {
+	t.Helper()
+	keys := make([][PublicKeySize]byte, len(indices))
+	for i, j := range indices {
+		keys[i] = [PublicKeySize]byte(mustHex(pubkeys[j]))
+	}
+	return KeyAgg(keys)
+}
+
+func TestNonceGenVectors(t *testing.T) {
+	pk := [PublicKeySize]byte(mustHex("024D4B6CD1361032CA9BD2AEB9D900AA4D45D9EAD80AC9423374C451A7254D0766"))
+	tests := []struct {
+		msg     []byte
+		wantSec string
+		wantPub string
+	}{
+		{
+			bytes32(0x01),
+			"B114E502BEAA4E301DD08A50264172C84E41650E6CB726B410C0694D59EFFB6495B5CAF28D045B973D63E3C99A44B807BDE375FD6CB39E46DC4A511708D0E9D2",
+			"02F7BE7089E8376EB355272368766B17E88E7DB72047D05E56AA881EA52B3B35DF02C29C8046FDD0DED4C7E55869137200FBDBFE2EB654267B6D7013602CAED3115A",
+		},
+		{
+			[]byte{},
+			"E862B068500320088138468D47E0E6F147E01B6024244AE45EAC40ACE5929B9F0789E051170B9E705D0B9EB49049A323BBBBB206D8E05C19F46C6228742AA7A9",
+			"023034FA5E2679F01EE66E12225882A7A48CC66719B1B9D3B6C4DBD743EFEDA2C503F3FD6F01EB3A8E9CB315D73F1F3D287CAFBB44AB321153C6287F407600205109",
+		},
+	}
+
+	for _, tt := range tests {
+		sec, pub, err := nonceGen([32]byte(bytes32(0x0F)), NonceParams{
+			PublicKey:     pk,
+			PrivateKey:    bytes32(0x02),
+			AggregatedKey: bytes32(0x07),
+			Message:       tt.msg,
+			Extra:         bytes32(0x08),
+		})
+		if err != nil {
+			t.Fatalf("nonceGen(msg %X) error = %v", tt.msg, err)
+		}
+		if want := SecNonce(append(mustHex(tt.wantSec), pk[:]...)); sec != want {
+			t.Errorf("nonceGen(msg %X) secnonce = %X, want %X", tt.msg, sec, want)
+		}
+		if want := PubNonce(mustHex(tt.wantPub)); pub != want {
+			t.Errorf("nonceGen(msg %X) pubnonce = %X, want %X", tt.msg, pub, want)
+		}
+	}
+}
+
+func bytes32(b byte) []byte {
+	out := make([]byte, 32)
+	for i := range out {
+		out[i] = b
+	}
+	return out
+}
+
+func TestNonceAggVectors(t *testing.T) {
+	pnonces := []string{
+		"020151C80F435648DF67A22B749CD798CE54E0321D034B92B709B567D60A42E66603BA47FBC1834437B3212E89A84D8425E7BF12E0245D98262268EBDCB385D50641",
+		"03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60248C264CDD57D3C24D79990B0F865674EB62A0F9018277A95011B41BFC193B833",
+		"020151C80F435648DF67A22B749CD798CE54E0321D034B92B709B567D60A42E6660279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798",
+		"03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60379BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798",
+		// Invalid: public nonce from signer 1 has an invalid tag
+		"04FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60248C264CDD57D3C24D79990B0F865674EB62A0F9018277A95011B41BFC193B833",
+		// Invalid: second half is not an x-coordinate on the curve
+		"03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60248C264CDD57D3C24D79990B0F865674EB62A0F9018277A95011B41BFC193B831",
+		// Invalid: second half exceeds the field size
+		"03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A602FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+	}
+	tests := []struct {
+		indices []int
+		want    string // empty for an expected error
+	}{
+		{[]int{0, 1}, "035FE1873B4F2967F52FEA4A06AD5A8ECCBE9D0FD73068012C894E2E87CCB5804B024725377345BDE0E9C33AF3C43C0A29A9249F2F2956FA8CFEB55C8573D0262DC8"},
+		// Sum of the second halves is infinity
+		{[]int{2, 3}, "035FE1873B4F2967F52FEA4A06AD5A8ECCBE9D0FD73068012C894E2E87CCB5804B000000000000000000000000000000000000000000000000000000000000000000"},
+		{[]int{0, 4}, ""},
+		{[]int{5, 1}, ""},
+		{[]int{6, 1}, ""},
+	}
+
+	for _, tt := range tests {
+		var in []PubNonce
+		for _, i := range tt.indices {
+			in = append(in, PubNonce(mustHex(pnonces[i])))
+		}
+		got, err := NonceAgg(in)
+		if tt.want == "" {
+			if !errors.Is(err, ErrInvalidNonce) {
+				t.Errorf("NonceAgg(%v) error = %v, want %v", tt.indices, err, ErrInvalidNonce)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("NonceAgg(%v) error = %v", tt.indices, err)
+		}
+		if want := AggNonce(mustHex(tt.want)); got != want {
+			t.Errorf("NonceAgg(%v) = %X, want %X", tt.indices, got, want)
+		}
+	}
+}
+
+func TestSignVerifyVectors(t *testing.T) {
+	pubkeys := []string{
+		"03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9",
+		"02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		"02DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA661",
+		// Invalid: not an x-coordinate on the curve
+		"020000000000000000000000000000000000000000000000000000000000000007",
+	}
+	pnonces := []string{
+		"0337C87821AFD50A8644D820A8F3E02E499C931865C2360FB43D0A0D20DAFE07EA0287BF891D2A6DEAEBADC909352AA9405D1428C15F4B75F04DAE642A95C2548480",
+		"0279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F817980279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798",
+		"032DE2662628C90B03F5E720284EB52FF7D71F4284F627B68A853D78C78E1FFE9303E4C5524E83FFE1493B9077CF1CA6BEB2090C93D930321071AD40B2F44E599046",
+		"0237C87821AFD50A8644D820A8F3E02E499C931865C2360FB43D0A0D20DAFE07EA0387BF891D2A6DEAEBADC909352AA9405D1428C15F4B75F04DAE642A95C2548480",
+		// Invalid: first half is not an x-coordinate on the curve
+		"0200000000000000000000000000000000000000000000000000000000000000090287BF891D2A6DEAEBADC909352AA9405D1428C15F4B75F04DAE642A95C2548480",
+	}
+	aggnonces := []string{
+		"028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61037496A3CC86926D452CAFCFD55D25972CA1675D549310DE296BFF42F72EEEA8C9",
+		"000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		// Invalid: bad tag
+		"048465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61037496A3CC86926D452CAFCFD55D25972CA1675D549310DE296BFF42F72EEEA8C9",
+		// Invalid: second half is not an x-coordinate on the curve
+		"028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61020000000000000000000000000000000000000000000000000000000000000009",
+		// Invalid: second half exceeds the field size
+		"028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD6102FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+	}
+	msgs := [][]byte{vectorMsg, {}, bytes.Repeat([]byte{0x26}, 38)}
+
+	// The signer is always pubkeys[0], at position signer in keys.
+	valid := []struct {
+		keys, nonces []int
+		aggnonce     int
+		msg          int
+		signer       int
+		want         string
+	}{
+		{[]int{0, 1, 2}, []int{0, 1, 2}, 0, 0, 0, "012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB"},
+		{[]int{1, 0, 2}, []int{1, 0, 2}, 0, 0, 1, "9FF2F7AAA856150CC8819254218D3ADEEB0535269051897724F9DB3789513A52"},
+		{[]int{1, 2, 0}, []int{1, 2, 0}, 0, 0, 2, "FA23C359F6FAC4E7796BB93BC9F0532A95468C539BA20FF86D7C76ED92227900"},
+		// Both halves of the aggregate nonce are infinity
+		{[]int{0, 1}, []int{0, 3}, 1, 0, 0, "AE386064B26105404798F75DE2EB9AF5EDA5387B064B83D049CB7C5E08879531"},
+		{[]int{0, 1, 2}, []int{0, 1, 2}, 0, 1, 0, "D7D63FFD644CCDA4E62BC2BC0B1D02DD32A1DC3030E155195810231D1037D82D"},
+		{[]int{0, 1, 2}, []int{0, 1, 2}, 0, 2, 0, "E184351828DA5094A97C79CABDAAA0BFB87608C32E8829A4DF5340A6F243B78C"},
+	}
+	for _, tt := range valid {
+		ctx, err := vectorKeyAgg(t, pubkeys, tt.keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var in []PubNonce
+		for _, i := range tt.nonces {
+			in = append(in, PubNonce(mustHex(pnonces[i])))
+		}
+		agg, err := NonceAgg(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := AggNonce(mustHex(aggnonces[tt.aggnonce])); agg != want {
+			t.Errorf("NonceAgg(%v) = %X, want %X", tt.nonces, agg, want)
+		}
+		s, err := NewSession(ctx, agg, msgs[tt.msg])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sec := vectorSec
+		psig, err := s.Sign(&sec, vectorPriv)
+		if err != nil {
+			t.Fatalf("Sign(keys %v, msg %d) error = %v", tt.keys, tt.msg, err)
+		}
+		if want := PartialSignature(mustHex(tt.want)); psig != want {
+			t.Errorf("Sign(keys %v, msg %d) = %X, want %X", tt.keys, tt.msg, psig, want)
+		}
+		pk := [PublicKeySize]byte(mustHex(pubkeys[0]))
+		if !s.PartialVerify(psig, PubNonce(mustHex(pnonces[tt.nonces[tt.signer]])), pk) {
+			t.Errorf("PartialVerify(keys %v, msg %d) = false", tt.keys, tt.msg)
+		}
+	}
+
+	signErrors := []struct {
+		name     string
+		keys     []int
+		aggnonce int
+		sec      SecNonce
+		want     error
+	}{
+		{"signer not in keys", []int{1, 2}, 0, vectorSec, ErrKeyMismatch},
+		{"invalid pubkey", []int{1, 0, 3}, 0, vectorSec, ErrInvalidPublicKey},
+		{"aggnonce bad tag", []int{1, 2, 0}, 2, vectorSec, ErrInvalidNonce},
+		{"aggnonce not on curve", []int{1, 2, 0}, 3, vectorSec, ErrInvalidNonce},
+		{"aggnonce exceeds field", []int{1, 2, 0}, 4, vectorSec, ErrInvalidNonce},
+		{"zero secnonce", []int{0, 1, 2}, 0, SecNonce(append(make([]byte, 64), mustHex(pubkeys[0])...)), ErrInvalidSecNonce},
+	}
+	for _, tt := range signErrors {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := vectorKeyAgg(t, pubkeys, tt.keys)
+			if err == nil {
+				var s *Session
+				if s, err = NewSession(ctx, AggNonce(mustHex(aggnonces[tt.aggnonce])), vectorMsg); err == nil {
+					_, err = s.Sign(&tt.sec, vectorPriv)
+				}
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	verifyFails := []struct {
+		name   string
+		psig   string
+		keys   []int
+		nonces []int
+		signer int
+	}{
+		{"wrong signature", "FED54434AD4CFE953FC527DC6A5E5BE8F6234907B7C187559557CE87A0541C46", []int{0, 1, 2}, []int{0, 1, 2}, 0},
+		{"wrong signer", "012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB", []int{0, 1, 2}, []int{0, 1, 2}, 1},
+		{"signature exceeds group size", "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", []int{0, 1, 2}, []int{0, 1, 2}, 0},
+		{"invalid pubnonce", "012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB", []int{0, 1, 2}, []int{4, 1, 2}, 0},
+	}
+	for _, tt := range verifyFails {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := vectorKeyAgg(t, pubkeys, tt.keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s, err := NewSession(ctx, AggNonce(mustHex(aggnonces[0])), vectorMsg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pk := [PublicKeySize]byte(mustHex(pubkeys[tt.keys[tt.signer]]))
+			pubnonce := PubNonce(mustHex(pnonces[tt.nonces[tt.signer]]))
+			if s.PartialVerify(PartialSignature(mustHex(tt.psig)), pubnonce, pk) {
+				t.Error("PartialVerify() = true")
+			}
+		})
+	}
+}
+
+func TestTweakVectors(t *testing.T) {
+	pubkeys := []string{
+		"03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9",
+		"02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		"02DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+	}
+	tweaks := []string{
+		"E8F791FF9225A2AF0102AFFF4A9A723D9612A682A25EBE79802B263CDFCD83BB",
+		"AE2EA797CC0FE72AC5B97B97F3C6957D7E4199A167A58EB08BCAFFDA70AC0455",
+		"F52ECBC565B3D8BEA2DFD5B75A4F457E54369809322E4120831626F290FA87E0",
+		"1969AD73CC177FA0B4FCED6DF1F7BF9907E665FDE9BA196A74FED0A3CF5AEF9D",
+		// Invalid: equal to the group order
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+	}
+	aggnonce := AggNonce(mustHex("028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61037496A3CC86926D452CAFCFD55D25972CA1675D549310DE296BFF42F72EEEA8C9"))
+
+	tests := []struct {
+		tweaks []int
+		xOnly  []bool
+		want   string // empty for an expected error
+	}{
+		{[]int{0}, []bool{true}, "E28A5C66E61E178C2BA19DB77B6CF9F7E2F0F56C17918CD13135E60CC848FE91"},
+		{[]int{0}, []bool{false}, "38B0767798252F21BF5702C48028B095428320F73A4B14DB1E25DE58543D2D2D"},
+		{[]int{0, 1}, []bool{false, true}, "408A0A21C4A0F5DACAF9646AD6EB6FECD7F7A11F03ED1F48DFFF2185BC2C2408"},
+		{[]int{0, 1, 2, 3}, []bool{false, false, true, true}, "45ABD206E61E3DF2EC9E264A6FEC8292141A633C28586388235541F9ADE75435"},
+		{[]int{0, 1, 2, 3}, []bool{true, false, true, false}, "B255FDCAC27B40C7CE7848E2D3B7BF5EA0ED756DA81565AC804CCCA3E1D5D239"},
+		{[]int{4}, []bool{false}, ""},
+	}
+
+	for _, tt := range tests {
+		ctx, err := vectorKeyAgg(t, pubkeys, []int{1, 2, 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, j := range tt.tweaks {
+			if ctx, err = ctx.ApplyTweak([32]byte(mustHex(tweaks[j])), tt.xOnly[i]); err != nil {
+				break
+			}
+		}
+		if tt.want == "" {
+			if !errors.Is(err, ErrInvalidTweak) {
+				t.Errorf("ApplyTweak(%v) error = %v, want %v", tt.tweaks, err, ErrInvalidTweak)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ApplyTweak(%v) error = %v", tt.tweaks, err)
+		}
+		s, err := NewSession(ctx, aggnonce, vectorMsg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sec := vectorSec
+		psig, err := s.Sign(&sec, vectorPriv)
+		if err != nil {
+			t.Fatalf("Sign(tweaks %v %v) error = %v", tt.tweaks, tt.xOnly, err)
+		}
+		if want := PartialSignature(mustHex(tt.want)); psig != want {
+			t.Errorf("Sign(tweaks %v %v) = %X, want %X", tt.tweaks, tt.xOnly, psig, want)
+		}
+	}
+}
+
+func TestSigAggVectors(t *testing.T) {
+	pubkeys := []string{
+		"03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9",
+		"02D2DC6F5DF7C56ACF38C7FA0AE7A759AE30E19B37359DFDE015872324C7EF6E05",
+		"03C7FB101D97FF930ACD0C6760852EF64E69083DE0B06AC6335724754BB4B0522C",
+		"02352433B21E7E05D3B452B81CAE566E06D2E003ECE16D1074AABA4289E0E3D581",
+	}
+	pnonces := []string{
+		"036E5EE6E28824029FEA3E8A9DDD2C8483F5AF98F7177C3AF3CB6F47CAF8D94AE902DBA67E4A1F3680826172DA15AFB1A8CA85C7C5CC88900905C8DC8C328511B53E",
+		"03E4F798DA48A76EEC1C9CC5AB7A880FFBA201A5F064E627EC9CB0031D1D58FC5103E06180315C5A522B7EC7C08B69DCD721C313C940819296D0A7AB8E8795AC1F00",
+		"02C0068FD25523A31578B8077F24F78F5BD5F2422AFF47C1FADA0F36B3CEB6C7D202098A55D1736AA5FCC21CF0729CCE852575C06C081125144763C2C4C4A05C09B6",
+		"023F7042046E0397822C4144A17F8B63D78748696A46C3B9F0A901D296EC3406C302022B0B464292CF9751D699F10980AC764E6F671EFCA15069BBE62B0D1C62522A",
+	}
+	tweaks := []string{
+		"B511DA492182A91B0FFB9A98020D55F260AE86D7ECBD0399C7383D59A5F2AF7C",
+		"A815FE049EE3C5AAB66310477FBC8BCCCAC2F3395F59F921C364ACD78A2F48DC",
+		"75448A87274B056468B977BE06EB1E9F657577B7320B0A3376EA51FD420D18A8",
+	}
+	psigs := []string{
+		"B15D2CD3C3D22B04DAE438CE653F6B4ECF042F42CFDED7C41B64AAF9B4AF53FB",
+		"6193D6AC61B354E9105BBDC8937A3454A6D705B6D57322A5A472A02CE99FCB64",
+		"9A87D3B79EC67228CB97878B76049B15DBD05B8158D17B5B9114D3C226887505",
+		"66F82EA90923689B855D36C6B7E032FB9970301481B99E01CDB4D6AC7C347A15",
+		"97B890A26C981DA8102D3BC294159D171D72810FDF7C6A691DEF02F0F7AF3FDC",
+		"53FA9E08BA5243CBCB0D797C5EE83BC6728E539EB76C2D0BF0F971EE4E909971",
+		// Invalid: equal to the group order
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+	}
+	msg := mustHex("599C67EA410D005B9DA90817CF03ED3B1C868E4DA4EDF00A5880B0082C237869")
+
+	tests := []struct {
+		keys, nonces []int
+		tweaks       []int
+		xOnly        []bool
+		psigs        []int
+		want         string // empty for an expected error
+	}{
+		{[]int{0, 1}, []int{0, 1}, nil, nil, []int{0, 1}, "041DA22223CE65C92C9A0D6C2CAC828AAF1EEE56304FEC371DDF91EBB2B9EF0912F1038025857FEDEB3FF696F8B99FA4BB2C5812F6095A2E0004EC99CE18DE1E"},
+		{[]int{0, 2}, []int{0, 2}, nil, nil, []int{2, 3}, "1069B67EC3D2F3C7C08291ACCB17A9C9B8F2819A52EB5DF8726E17E7D6B52E9F01800260A7E9DAC450F4BE522DE4CE12BA91AEAF2B4279219EF74BE1D286ADD9"},
+		{[]int{0, 3}, []int{0, 3}, []int{0, 1, 2}, []bool{true, false, true}, []int{4, 5}, "839B08820B681DBA8DAF4CC7B104E8F2638F9388F8D7A555DC17B6E6971D7426CE07BF6AB01F1DB50E4E33719295F4094572B79868E440FB3DEFD3FAC1DB589E"},
+		{[]int{0, 3}, []int{0, 3}, []int{0, 1, 2}, []bool{true, false, true}, []int{5, 6}, ""},
+	}
+
+	for _, tt := range tests {
+		ctx, err := vectorKeyAgg(t, pubkeys, tt.keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, j := range tt.tweaks {
+			if ctx, err = ctx.ApplyTweak([32]byte(mustHex(tweaks[j])), tt.xOnly[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var in []PubNonce
+		for _, i := range tt.nonces {
+			in = append(in, PubNonce(mustHex(pnonces[i])))
+		}
+		agg, err := NonceAgg(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := NewSession(ctx, agg, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ps []PartialSignature
+		for _, i := range tt.psigs {
+			ps = append(ps, PartialSignature(mustHex(psigs[i])))
+		}
+		sig, err := s.Aggregate(ps)
+		if tt.want == "" {
+			if !errors.Is(err, ErrInvalidPartialSig) {
+				t.Errorf("Aggregate(%v) error = %v, want %v", tt.psigs, err, ErrInvalidPartialSig)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Aggregate(%v) error = %v", tt.psigs, err)
+		}
+		if want := schnorr.Signature(mustHex(tt.want)); sig != want {
+			t.Errorf("Aggregate(%v) = %X, want %X", tt.psigs, sig, want)
+		}
+		if !schnorr.Verify(ctx.AggregatedKey(), msg, sig) {
+			t.Errorf("schnorr.Verify(Aggregate(%v)) = false", tt.psigs)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Package p256 verifies secp256r1 (NIST P-256) ECDSA signatures, as the
+// RIP-7212 precompile does.
+//
+// P-256 is the curve used by passkeys and secure enclaves. Account
+// abstraction wallets verify such signatures on-chain through the
+// precompile; Verify lets off-chain code reach the same verdict.
+package p256
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+)
+
+// Verify reports whether r, s is a valid signature of hash by the public
+// key (x, y). Like RIP-7212 it requires r and s in [1, n-1] and a public
+// key on the curve, and accepts high-s signatures. hash is used as is,
+// without hashing.
+func Verify(hash, r, s, x, y [32]byte) bool {
+	pub, ok := publicKey(x, y)
+	if !ok {
+		return false
+	}
+	return ecdsa.Verify(pub, hash[:], new(big.Int).SetBytes(r[:]), new(big.Int).SetBytes(s[:]))
+}
+
+// publicKey returns the ECDSA public key (x, y), or false if the point is
+// not on the curve. Validating through crypto/ecdh keeps invalid points
+// away from crypto/elliptic, which panics on them.
+func publicKey(x, y [32]byte) (*ecdsa.PublicKey, bool) {
+	var uncompressed [65]byte
+	uncompressed[0] = 4
+	copy(uncompressed[1:33], x[:])
+	copy(uncompressed[33:], y[:])
+	if _, err := ecdh.P256().NewPublicKey(uncompressed[:]); err != nil {
+		return nil, false
+	}
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x[:]),
+		Y:     new(big.Int).SetBytes(y[:]),
+	}, true
+}
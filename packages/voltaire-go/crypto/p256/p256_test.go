@@ -0,0 +1,60 @@
+package p256
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+)
+
+func fixed(x *big.Int) [32]byte {
+	var b [32]byte
+	x.FillBytes(b[:])
+	return b
+}
+
+func TestVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("voltaire"))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, y := fixed(key.X), fixed(key.Y)
+
+	if !Verify(hash, fixed(r), fixed(s), x, y) {
+		t.Fatal("valid signature rejected")
+	}
+
+	// RIP-7212 does not enforce low s
+	n := elliptic.P256().Params().N
+	highS := new(big.Int).Sub(n, s)
+	if !Verify(hash, fixed(r), fixed(highS), x, y) {
+		t.Error("high-s signature rejected")
+	}
+
+	offCurve := y
+	offCurve[31] ^= 1
+	tests := []struct {
+		name             string
+		hash, r, s, x, y [32]byte
+	}{
+		{"wrong hash", sha256.Sum256([]byte("other")), fixed(r), fixed(s), x, y},
+		{"zero r", hash, [32]byte{}, fixed(s), x, y},
+		{"s = n", hash, fixed(r), fixed(n), x, y},
+		{"off curve", hash, fixed(r), fixed(s), x, offCurve},
+		{"infinity", hash, fixed(r), fixed(s), [32]byte{}, [32]byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Verify(tt.hash, tt.r, tt.s, tt.x, tt.y) {
+				t.Error("invalid signature accepted")
+			}
+		})
+	}
+}
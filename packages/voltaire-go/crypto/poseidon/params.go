@@ -0,0 +1,151 @@
+package poseidon
+
+import (
+	"math/big"
+	"sync"
+)
+
+// Round numbers for widths 2 to 17, as used by circomlib: 8 full rounds
+// and a width-dependent number of partial rounds.
+const fullRounds = 8
+
+var partialRounds = [MaxInputs]int{56, 57, 56, 60, 60, 63, 64, 63, 60, 66, 60, 65, 70, 60, 64, 68}
+
+// params are the round constants and MDS matrix for one width.
+type params struct {
+	t       int
+	partial int
+	c       []*big.Int   // (fullRounds + partial) * t round constants
+	mds     [][]*big.Int // t x t
+}
+
+var (
+	paramsOnce  [MaxInputs]sync.Once
+	paramsCache [MaxInputs]*params
+)
+
+// paramsFor returns the parameters for width t, generating them on first
+// use.
+func paramsFor(t int) *params {
+	i := t - 2
+	paramsOnce[i].Do(func() {
+		paramsCache[i] = generate(t, partialRounds[i])
+	})
+	return paramsCache[i]
+}
+
+// generate derives the parameters with the Grain LFSR procedure of the
+// Poseidon reference implementation (generate_parameters_grain.sage),
+// which is how circomlib's constants were produced.
+func generate(t, partial int) *params {
+	g := newGrain(t, partial)
+	p := &params{t: t, partial: partial}
+
+	p.c = make([]*big.Int, (fullRounds+partial)*t)
+	for i := range p.c {
+		// Rejection sampling keeps the constants uniform below the modulus
+		for {
+			x := g.bits(fieldBits)
+			if x.Cmp(Modulus) < 0 {
+				p.c[i] = x
+				break
+			}
+		}
+	}
+
+	// Cauchy matrix M[i][j] = 1 / (x_i + y_j) from 2t distinct elements
+	for {
+		vals := make([]*big.Int, 2*t)
+		for i := range vals {
+			vals[i] = g.bits(fieldBits)
+			vals[i].Mod(vals[i], Modulus)
+		}
+		if m, ok := cauchy(vals[:t], vals[t:]); ok {
+			p.mds = m
+			return p
+		}
+	}
+}
+
+func cauchy(xs, ys []*big.Int) ([][]*big.Int, bool) {
+	seen := make(map[string]bool)
+	for _, v := range append(append([]*big.Int(nil), xs...), ys...) {
+		if seen[v.String()] {
+			return nil, false
+		}
+		seen[v.String()] = true
+	}
+	m := make([][]*big.Int, len(xs))
+	for i, x := range xs {
+		m[i] = make([]*big.Int, len(ys))
+		for j, y := range ys {
+			sum := new(big.Int).Add(x, y)
+			sum.Mod(sum, Modulus)
+			if sum.Sign() == 0 {
+				return nil, false
+			}
+			m[i][j] = sum.ModInverse(sum, Modulus)
+		}
+	}
+	return m, true
+}
+
+// fieldBits is the bit length of the BN254 scalar field modulus.
+const fieldBits = 254
+
+// grain is the 80-bit Grain LFSR used to derive Poseidon parameters.
+type grain struct {
+	state [80]byte
+}
+
+func newGrain(t, partial int) *grain {
+	g := new(grain)
+	pos := 0
+	put := func(v, n int) {
+		for i := n - 1; i >= 0; i-- {
+			g.state[pos] = byte(v>>i) & 1
+			pos++
+		}
+	}
+	put(1, 2)          // prime field
+	put(0, 4)          // x^alpha S-box
+	put(fieldBits, 12) // field size
+	put(t, 12)
+	put(fullRounds, 10)
+	put(partial, 10)
+	put(1<<30-1, 30)
+
+	for i := 0; i < 160; i++ {
+		g.next()
+	}
+	return g
+}
+
+func (g *grain) next() byte {
+	s := &g.state
+	b := s[62] ^ s[51] ^ s[38] ^ s[23] ^ s[13] ^ s[0]
+	copy(s[:], s[1:])
+	s[79] = b
+	return b
+}
+
+// bit returns the next output bit using the self-shrinking rule: bits
+// are read in pairs, and the second is kept only if the first is one.
+func (g *grain) bit() byte {
+	for g.next() == 0 {
+		g.next()
+	}
+	return g.next()
+}
+
+// bits returns the next n output bits as a big-endian integer.
+func (g *grain) bits(n int) *big.Int {
+	x := new(big.Int)
+	for i := 0; i < n; i++ {
+		x.Lsh(x, 1)
+		if g.bit() == 1 {
+			x.SetBit(x, 0, 1)
+		}
+	}
+	return x
+}
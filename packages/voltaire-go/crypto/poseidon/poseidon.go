@@ -0,0 +1,177 @@
+// Package poseidon implements the Poseidon hash over the BN254 scalar
+// field with the parameters used by circomlib and Noir.
+//
+// Hash is the fixed-arity function of circomlib's poseidon circuits and
+// Noir's std::hash::poseidon::bn254::hash_N. Sponge absorbs any number of
+// elements with the same permutation.
+//
+// Parameters for each width are derived on first use with the reference
+// Grain LFSR procedure rather than stored as tables.
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+)
+
+// MaxInputs is the largest arity Hash accepts, giving a state width of 17.
+const MaxInputs = 16
+
+// Modulus is the BN254 scalar field modulus.
+var Modulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+// Errors
+var (
+	ErrInputCount   = errors.New("poseidon: input count must be between 1 and 16")
+	ErrInvalidWidth = errors.New("poseidon: sponge width must be between 2 and 17")
+	ErrNotInField   = errors.New("poseidon: input not below the field modulus")
+)
+
+// Hash returns the Poseidon hash of 1 to 16 field elements. A nil input
+// is treated as zero.
+func Hash(inputs ...*big.Int) (*big.Int, error) {
+	if len(inputs) == 0 || len(inputs) > MaxInputs {
+		return nil, ErrInputCount
+	}
+	state := make([]*big.Int, len(inputs)+1)
+	state[0] = new(big.Int)
+	for i, x := range inputs {
+		e, err := element(x)
+		if err != nil {
+			return nil, err
+		}
+		state[i+1] = e
+	}
+	permute(paramsFor(len(state)), state)
+	return state[0], nil
+}
+
+// HashBytes hashes 32-byte big-endian field elements.
+func HashBytes(inputs ...[32]byte) ([32]byte, error) {
+	xs := make([]*big.Int, len(inputs))
+	for i, b := range inputs {
+		xs[i] = new(big.Int).SetBytes(b[:])
+	}
+	h, err := Hash(xs...)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	var out [32]byte
+	h.FillBytes(out[:])
+	return out, nil
+}
+
+// Sponge is a Poseidon sponge with capacity one: elements are added into
+// the rate part of the state, which is permuted each time it fills.
+type Sponge struct {
+	p     *params
+	state []*big.Int
+	pos   int
+}
+
+// NewSponge returns a sponge with the given state width, absorbing
+// width-1 elements per permutation.
+func NewSponge(width int) (*Sponge, error) {
+	if width < 2 || width > MaxInputs+1 {
+		return nil, ErrInvalidWidth
+	}
+	s := &Sponge{p: paramsFor(width), state: make([]*big.Int, width)}
+	for i := range s.state {
+		s.state[i] = new(big.Int)
+	}
+	return s, nil
+}
+
+// Absorb adds field elements to the sponge. A nil input is treated as
+// zero.
+func (s *Sponge) Absorb(inputs ...*big.Int) error {
+	for _, x := range inputs {
+		e, err := element(x)
+		if err != nil {
+			return err
+		}
+		v := s.state[1+s.pos]
+		v.Add(v, e).Mod(v, Modulus)
+		s.pos++
+		if s.pos == len(s.state)-1 {
+			permute(s.p, s.state)
+			s.pos = 0
+		}
+	}
+	return nil
+}
+
+// Squeeze returns the next output element. It permutes first if elements
+// are pending, and between consecutive squeezes.
+func (s *Sponge) Squeeze() *big.Int {
+	if s.pos != 0 {
+		permute(s.p, s.state)
+		s.pos = 0
+	}
+	out := new(big.Int).Set(s.state[1])
+	// Mark the rate as used so the next squeeze permutes
+	s.pos = len(s.state) - 1
+	return out
+}
+
+// Permute applies the Poseidon permutation of width len(state) in place.
+// Elements must be below Modulus.
+func Permute(state []*big.Int) error {
+	if len(state) < 2 || len(state) > MaxInputs+1 {
+		return ErrInvalidWidth
+	}
+	for i, x := range state {
+		e, err := element(x)
+		if err != nil {
+			return err
+		}
+		state[i] = e
+	}
+	permute(paramsFor(len(state)), state)
+	return nil
+}
+
+func permute(p *params, state []*big.Int) {
+	t := p.t
+	half := fullRounds / 2
+	next := make([]*big.Int, t)
+	tmp := new(big.Int)
+	for r := 0; r < fullRounds+p.partial; r++ {
+		for i := range state {
+			state[i].Add(state[i], p.c[r*t+i])
+		}
+		if r < half || r >= half+p.partial {
+			for i := range state {
+				sbox(state[i], tmp)
+			}
+		} else {
+			sbox(state[0], tmp)
+		}
+		for i := range next {
+			acc := new(big.Int)
+			for j, x := range state {
+				acc.Add(acc, tmp.Mul(p.mds[i][j], x))
+			}
+			next[i] = acc.Mod(acc, Modulus)
+		}
+		copy(state, next)
+	}
+}
+
+// sbox sets x to x^5 mod p, using tmp as scratch.
+func sbox(x, tmp *big.Int) {
+	x.Mod(x, Modulus)
+	tmp.Mul(x, x).Mod(tmp, Modulus)
+	tmp.Mul(tmp, tmp).Mod(tmp, Modulus)
+	x.Mul(x, tmp).Mod(x, Modulus)
+}
+
+func element(x *big.Int) (*big.Int, error) {
+	if x == nil {
+		return new(big.Int), nil
+	}
+	if x.Sign() < 0 || x.Cmp(Modulus) >= 0 {
+		return nil, ErrNotInField
+	}
+	return new(big.Int).Set(x), nil
+}
@@ -0,0 +1,249 @@
+// Package schnorr implements BIP-340 Schnorr signatures over secp256k1.
+//
+// Public keys are x-only: the 32-byte x coordinate of a point with even
+// y. Signatures are 64 bytes, R.x || s. Messages are signed as given, of
+// any length; BIP-340 callers usually sign a 32-byte tagged hash.
+//
+// Secret scalars (private keys and nonces) are multiplied by the
+// generator in constant time by the native library. Verification works
+// only on public values and uses the faster variable-time arithmetic.
+package schnorr
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+	"github.com/voltaire-labs/voltaire-go/primitives/hex"
+)
+
+// Sizes
+const (
+	PrivateKeySize = 32
+	PublicKeySize  = 32
+	SignatureSize  = 64
+)
+
+// Errors
+var (
+	ErrInvalidPrivateKey = errors.New("schnorr: private key out of range [1, n-1]")
+	ErrInvalidPublicKey  = errors.New("schnorr: invalid public key")
+	ErrInvalidLength     = errors.New("schnorr: invalid length")
+	ErrSigningFailed     = errors.New("schnorr: signing failed")
+)
+
+// PublicKey is an x-only public key.
+type PublicKey [PublicKeySize]byte
+
+// Signature is a BIP-340 signature: R.x || s.
+type Signature [SignatureSize]byte
+
+// Tagged hash prefixes, precomputed as sha256(tag) || sha256(tag).
+var (
+	tagAux       = tagPrefix("BIP0340/aux")
+	tagNonce     = tagPrefix("BIP0340/nonce")
+	tagChallenge = tagPrefix("BIP0340/challenge")
+)
+
+// PublicKeyFromPrivate returns the x-only public key of priv.
+func PublicKeyFromPrivate(priv [PrivateKeySize]byte) (PublicKey, error) {
+	_, p, err := keyPair(priv)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return xOnly(&p), nil
+}
+
+// PublicKeyFromCompressed returns the x-only form of a 33-byte compressed
+// public key, dropping its y parity.
+func PublicKeyFromCompressed(b []byte) (PublicKey, error) {
+	if len(b) != 33 {
+		return PublicKey{}, ErrInvalidLength
+	}
+	if _, err := secp256k1.ParsePubKey(b); err != nil {
+		return PublicKey{}, ErrInvalidPublicKey
+	}
+	return PublicKey(b[1:]), nil
+}
+
+// IsValid reports whether pk is the x coordinate of a curve point.
+func (pk PublicKey) IsValid() bool {
+	_, ok := liftX(pk)
+	return ok
+}
+
+// Hex returns the 0x-prefixed hex encoding of pk.
+func (pk PublicKey) Hex() string {
+	return hex.EncodeData(pk[:])
+}
+
+// Hex returns the 0x-prefixed hex encoding of sig.
+func (sig Signature) Hex() string {
+	return hex.EncodeData(sig[:])
+}
+
+// Sign signs msg with fresh auxiliary randomness from crypto/rand.
+func Sign(priv [PrivateKeySize]byte, msg []byte) (Signature, error) {
+	var aux [32]byte
+	if _, err := rand.Read(aux[:]); err != nil {
+		return Signature{}, err
+	}
+	return SignWithAux(priv, msg, aux)
+}
+
+// SignWithAux signs msg using aux as the auxiliary randomness. Signing is
+// deterministic given aux; an all-zero aux is allowed but gives up the
+// side-channel protection that random aux provides.
+func SignWithAux(priv [PrivateKeySize]byte, msg []byte, aux [32]byte) (Signature, error) {
+	d, p, err := keyPair(priv)
+	if err != nil {
+		return Signature{}, err
+	}
+	pub := xOnly(&p)
+
+	// t = bytes(d) xor hash_aux(aux)
+	t := d.Bytes()
+	mask := taggedHash(tagAux, aux[:])
+	for i := range t {
+		t[i] ^= mask[i]
+	}
+
+	rnd := taggedHash(tagNonce, t[:], pub[:], msg)
+	var k secp256k1.ModNScalar
+	k.SetBytes(&rnd)
+	if k.IsZero() {
+		return Signature{}, ErrSigningFailed
+	}
+	r, err := baseMult(&k)
+	if err != nil {
+		return Signature{}, ErrSigningFailed
+	}
+	if r.Y.IsOdd() {
+		k.Negate()
+	}
+
+	var sig Signature
+	r.X.PutBytesUnchecked(sig[:32])
+	e := challenge(sig[:32], pub, msg)
+	s := new(secp256k1.ModNScalar).Mul2(&e, &d).Add(&k)
+	s.PutBytesUnchecked(sig[32:])
+
+	// BIP-340 recommends verifying to guard against faults
+	if !Verify(pub, msg, sig) {
+		return Signature{}, ErrSigningFailed
+	}
+	return sig, nil
+}
+
+// Verify reports whether sig is a valid signature of msg by pk.
+func Verify(pk PublicKey, msg []byte, sig Signature) bool {
+	p, ok := liftX(pk)
+	if !ok {
+		return false
+	}
+	var rx secp256k1.FieldVal
+	if overflow := rx.SetByteSlice(sig[:32]); overflow {
+		return false
+	}
+	var s secp256k1.ModNScalar
+	if overflow := s.SetByteSlice(sig[32:]); overflow {
+		return false
+	}
+	e := challenge(sig[:32], pk, msg)
+
+	// R = s*G - e*P
+	var sg, ep, r secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(&s, &sg)
+	secp256k1.ScalarMultNonConst(e.Negate(), &p, &ep)
+	secp256k1.AddNonConst(&sg, &ep, &r)
+	if (r.X.IsZero() && r.Y.IsZero()) || r.Z.IsZero() {
+		return false
+	}
+	r.ToAffine()
+	return !r.Y.IsOdd() && r.X.Equals(&rx)
+}
+
+// TaggedHash returns the BIP-340 tagged hash
+// sha256(sha256(tag) || sha256(tag) || data...).
+func TaggedHash(tag string, data ...[]byte) [32]byte {
+	return taggedHash(tagPrefix(tag), data...)
+}
+
+// keyPair returns the secret scalar of priv, negated if needed so that
+// its public point has even y, and that point in affine form.
+func keyPair(priv [PrivateKeySize]byte) (secp256k1.ModNScalar, secp256k1.JacobianPoint, error) {
+	var d secp256k1.ModNScalar
+	if overflow := d.SetBytes(&priv); overflow != 0 || d.IsZero() {
+		return secp256k1.ModNScalar{}, secp256k1.JacobianPoint{}, ErrInvalidPrivateKey
+	}
+	p, err := baseMult(&d)
+	if err != nil {
+		return secp256k1.ModNScalar{}, secp256k1.JacobianPoint{}, ErrInvalidPrivateKey
+	}
+	if p.Y.IsOdd() {
+		d.Negate()
+		p.Y.Negate(1).Normalize()
+	}
+	return d, p, nil
+}
+
+// baseMult returns k*G in affine form for a secret scalar k. decred's
+// ScalarBaseMultNonConst branches on the bits of k, so the product comes
+// from the native library's constant-time multiplication instead.
+func baseMult(k *secp256k1.ModNScalar) (secp256k1.JacobianPoint, error) {
+	xy, err := ffi.Secp256k1PublicKey(k.Bytes())
+	if err != nil {
+		return secp256k1.JacobianPoint{}, err
+	}
+	var p secp256k1.JacobianPoint
+	p.X.SetByteSlice(xy[:32])
+	p.Y.SetByteSlice(xy[32:])
+	p.Z.SetInt(1)
+	return p, nil
+}
+
+// liftX returns the point with x coordinate pk and even y.
+func liftX(pk PublicKey) (secp256k1.JacobianPoint, bool) {
+	var x, y secp256k1.FieldVal
+	if overflow := x.SetByteSlice(pk[:]); overflow {
+		return secp256k1.JacobianPoint{}, false
+	}
+	if !secp256k1.DecompressY(&x, false, &y) {
+		return secp256k1.JacobianPoint{}, false
+	}
+	var one secp256k1.FieldVal
+	one.SetInt(1)
+	return secp256k1.MakeJacobianPoint(&x, &y, &one), true
+}
+
+// challenge returns hash_challenge(r || pk || msg) reduced mod n.
+func challenge(r []byte, pk PublicKey, msg []byte) secp256k1.ModNScalar {
+	h := taggedHash(tagChallenge, r, pk[:], msg)
+	var e secp256k1.ModNScalar
+	e.SetBytes(&h)
+	return e
+}
+
+func xOnly(p *secp256k1.JacobianPoint) PublicKey {
+	var pk PublicKey
+	p.X.PutBytesUnchecked(pk[:])
+	return pk
+}
+
+func tagPrefix(tag string) []byte {
+	h := sha256.Sum256([]byte(tag))
+	return append(h[:], h[:]...)
+}
+
+func taggedHash(prefix []byte, data ...[]byte) [32]byte {
+	h := sha256.New()
+	h.Write(prefix)
+	for _, d := range data {
+		h.Write(d)
+	}
+	var out [32]byte
+	h.Sum(out[:0])
+	return out
+}
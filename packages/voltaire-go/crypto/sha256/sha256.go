@@ -2,10 +2,19 @@
 package sha256
 
 import (
+	stdsha256 "crypto/sha256"
+	gohash "hash"
+
 	"github.com/voltaire-labs/voltaire-go/internal/ffi"
 	"github.com/voltaire-labs/voltaire-go/primitives/hash"
 )
 
+// Size is the size of a SHA-256 hash in bytes.
+const Size = 32
+
+// BlockSize is the block size of SHA-256 in bytes.
+const BlockSize = 64
+
 // Hash computes the SHA-256 hash of data.
 func Hash(data []byte) hash.Hash {
 	return hash.Hash(ffi.SHA256(data))
@@ -34,3 +43,10 @@ func DoubleHash(data []byte) hash.Hash {
 	first := Hash(data)
 	return Hash(first[:])
 }
+
+// New returns a new hash.Hash computing SHA-256 incrementally. The native
+// library has no streaming API, so this uses the standard library; its
+// output matches Hash.
+func New() gohash.Hash {
+	return stdsha256.New()
+}
@@ -2,6 +2,8 @@ package sha256
 
 import (
 	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
 )
 
 func TestHash(t *testing.T) {
@@ -51,3 +53,15 @@ func TestKnownVectors(t *testing.T) {
 		})
 	}
 }
+
+func TestNew(t *testing.T) {
+	h := New()
+	h.Write([]byte("hel"))
+	h.Write([]byte("lo"))
+	if got, want := hash.Hash(h.Sum(nil)), HashString("hello"); got != want {
+		t.Errorf("New() = %s, want %s", got.Hex(), want.Hex())
+	}
+	if h.Size() != Size || h.BlockSize() != BlockSize {
+		t.Errorf("Size() = %d, BlockSize() = %d", h.Size(), h.BlockSize())
+	}
+}
@@ -0,0 +1,133 @@
+// Package voltaire exports the error taxonomy shared by the voltaire-go
+// packages.
+//
+// Failures reported by the native library are returned as the sentinel
+// errors below, so callers can match them with errors.Is regardless of
+// which package returned them:
+//
+//	addr, err := address.FromHex(s)
+//	if errors.Is(err, voltaire.ErrInvalidHex) {
+//		// ...
+//	}
+//
+// CodeOf maps an error back to the numeric code used by the C API.
+//
+// EnableCGOStats and CGOStats report how often each native call is made
+// and how many bytes cross the cgo boundary.
+package voltaire
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+)
+
+// Code is a numeric error code from the Voltaire C API.
+type Code int
+
+// Error codes, matching PRIMITIVES_ERROR_* in primitives.h.
+const (
+	CodeOK                   Code = 0
+	CodeInvalidHex           Code = -1
+	CodeInvalidLength        Code = -2
+	CodeInvalidChecksum      Code = -3
+	CodeOutOfMemory          Code = -4
+	CodeInvalidInput         Code = -5
+	CodeInvalidSignature     Code = -6
+	CodeInvalidSelector      Code = -7
+	CodeUnsupportedType      Code = -8
+	CodeMaxLengthExceeded    Code = -9
+	CodeAccessListInvalid    Code = -10
+	CodeAuthorizationInvalid Code = -11
+	CodeKZGNotLoaded         Code = -20
+	CodeKZGInvalidBlob       Code = -21
+	CodeKZGInvalidProof      Code = -22
+)
+
+// CodeUnknown is Go-only: primitives.h has no equivalent, and the C API
+// never returns it. It is the code of ErrUnknown and of errors outside the
+// taxonomy, and lies below every PRIMITIVES_ERROR_* value so it cannot
+// collide with a code added to the C API later.
+const CodeUnknown Code = math.MinInt32
+
+// Errors
+var (
+	ErrInvalidHex           = ffi.ErrInvalidHex
+	ErrInvalidLength        = ffi.ErrInvalidLength
+	ErrInvalidChecksum      = ffi.ErrInvalidChecksum
+	ErrOutOfMemory          = ffi.ErrOutOfMemory
+	ErrInvalidInput         = ffi.ErrInvalidInput
+	ErrInvalidSignature     = ffi.ErrInvalidSignature
+	ErrInvalidSelector      = ffi.ErrInvalidSelector
+	ErrUnsupportedType      = ffi.ErrUnsupportedType
+	ErrMaxLengthExceeded    = ffi.ErrMaxLengthExceeded
+	ErrAccessListInvalid    = ffi.ErrAccessListInvalid
+	ErrAuthorizationInvalid = ffi.ErrAuthorizationInvalid
+	ErrKZGNotLoaded         = ffi.ErrKZGNotLoaded
+	ErrKZGInvalidBlob       = ffi.ErrKZGInvalidBlob
+	ErrKZGInvalidProof      = ffi.ErrKZGInvalidProof
+	ErrUnknown              = ffi.ErrUnknown
+)
+
+var codes = []struct {
+	code Code
+	err  error
+}{
+	{CodeInvalidHex, ErrInvalidHex},
+	{CodeInvalidLength, ErrInvalidLength},
+	{CodeInvalidChecksum, ErrInvalidChecksum},
+	{CodeOutOfMemory, ErrOutOfMemory},
+	{CodeInvalidInput, ErrInvalidInput},
+	{CodeInvalidSignature, ErrInvalidSignature},
+	{CodeInvalidSelector, ErrInvalidSelector},
+	{CodeUnsupportedType, ErrUnsupportedType},
+	{CodeMaxLengthExceeded, ErrMaxLengthExceeded},
+	{CodeAccessListInvalid, ErrAccessListInvalid},
+	{CodeAuthorizationInvalid, ErrAuthorizationInvalid},
+	{CodeKZGNotLoaded, ErrKZGNotLoaded},
+	{CodeKZGInvalidBlob, ErrKZGInvalidBlob},
+	{CodeKZGInvalidProof, ErrKZGInvalidProof},
+	{CodeUnknown, ErrUnknown},
+}
+
+// CodeOf returns the C API error code of err.
+// Returns CodeOK for nil and CodeUnknown for errors outside the taxonomy.
+func CodeOf(err error) Code {
+	if err == nil {
+		return CodeOK
+	}
+	for _, c := range codes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return CodeUnknown
+}
+
+// Err returns the sentinel error for a code, or nil for CodeOK.
+func (c Code) Err() error {
+	if c == CodeOK {
+		return nil
+	}
+	for _, e := range codes {
+		if e.code == c {
+			return e.err
+		}
+	}
+	return ErrUnknown
+}
+
+// String returns the error message for the code.
+func (c Code) String() string {
+	if c == CodeOK {
+		return "ok"
+	}
+	for _, e := range codes {
+		if e.code == c {
+			return e.err.Error()
+		}
+	}
+	return fmt.Sprintf("voltaire: error code %d", int(c))
+}
@@ -0,0 +1,74 @@
+package voltaire
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{"nil", nil, CodeOK},
+		{"sentinel", ErrInvalidSignature, CodeInvalidSignature},
+		{"wrapped", fmt.Errorf("decode: %w", ErrInvalidLength), CodeInvalidLength},
+		{"foreign", errors.New("something else"), CodeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.want {
+				t.Errorf("CodeOf(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageErrorsMatch(t *testing.T) {
+	_, err := address.FromHex("0x" + strings.Repeat("zz", address.Size))
+	if !errors.Is(err, ErrInvalidHex) {
+		t.Errorf("address.FromHex error = %v, want ErrInvalidHex", err)
+	}
+
+	_, err = hash.FromBytes([]byte{1, 2, 3})
+	if !errors.Is(err, ErrInvalidLength) || CodeOf(err) != CodeInvalidLength {
+		t.Errorf("hash.FromBytes error = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestCodeErr(t *testing.T) {
+	for _, c := range codes {
+		if got := c.code.Err(); got != c.err {
+			t.Errorf("Code(%d).Err() = %v, want %v", c.code, got, c.err)
+		}
+		if got := CodeOf(c.code.Err()); got != c.code {
+			t.Errorf("CodeOf(Code(%d).Err()) = %d", c.code, got)
+		}
+	}
+
+	if CodeOK.Err() != nil {
+		t.Error("CodeOK.Err() should be nil")
+	}
+	if Code(-42).Err() != ErrUnknown {
+		t.Error("unlisted code should map to ErrUnknown")
+	}
+	if CodeOf(Code(-42).Err()) != CodeUnknown {
+		t.Error("unlisted code should report CodeUnknown")
+	}
+}
+
+func TestCodeString(t *testing.T) {
+	if got := CodeInvalidHex.String(); got != "voltaire: invalid hex string" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := Code(-42).String(); got != "voltaire: error code -42" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -9,7 +9,10 @@ package ffi
 #include <string.h>
 */
 import "C"
-import "unsafe"
+import (
+	"sync"
+	"unsafe"
+)
 
 // Re-export C types for internal use
 type (
@@ -31,12 +34,31 @@ const U256Size = 32
 // SignatureSize is the size of a signature (r + s + v) in bytes.
 const SignatureSize = 65
 
+// Layout checks for the structs copied with memcpy. Each pair of array
+// types has a negative (overflowing) length, and so fails to compile, if
+// the C layout in primitives.h drifts from the Go sizes above.
+type (
+	_ [unsafe.Sizeof(CAddress{}) - AddressSize]byte
+	_ [AddressSize - unsafe.Sizeof(CAddress{})]byte
+	_ [unsafe.Sizeof(CHash{}) - HashSize]byte
+	_ [HashSize - unsafe.Sizeof(CHash{})]byte
+	_ [unsafe.Sizeof(CU256{}) - U256Size]byte
+	_ [U256Size - unsafe.Sizeof(CU256{})]byte
+	_ [unsafe.Sizeof(CSignature{}) - SignatureSize]byte
+	_ [SignatureSize - unsafe.Sizeof(CSignature{})]byte
+	_ [unsafe.Offsetof(CSignature{}.s) - 32]byte
+	_ [32 - unsafe.Offsetof(CSignature{}.s)]byte
+	_ [unsafe.Offsetof(CSignature{}.v) - 64]byte
+	_ [64 - unsafe.Offsetof(CSignature{}.v)]byte
+)
+
 // ============================================================================
 // Address Functions
 // ============================================================================
 
 // AddressFromHex creates an address from a hex string.
 func AddressFromHex(hex string) ([AddressSize]byte, error) {
+	defer track("AddressFromHex", begin(), len(hex), AddressSize)
 	cHex := C.CString(hex)
 	defer C.free(unsafe.Pointer(cHex))
 
@@ -53,6 +75,7 @@ func AddressFromHex(hex string) ([AddressSize]byte, error) {
 
 // AddressToHex converts an address to hex string (lowercase, with 0x prefix).
 func AddressToHex(addr [AddressSize]byte) string {
+	defer track("AddressToHex", begin(), AddressSize, 42)
 	var cAddr CAddress
 	C.memcpy(unsafe.Pointer(&cAddr.bytes[0]), unsafe.Pointer(&addr[0]), AddressSize)
 
@@ -63,6 +86,7 @@ func AddressToHex(addr [AddressSize]byte) string {
 
 // AddressToChecksumHex converts an address to EIP-55 checksummed hex string.
 func AddressToChecksumHex(addr [AddressSize]byte) string {
+	defer track("AddressToChecksumHex", begin(), AddressSize, 42)
 	var cAddr CAddress
 	C.memcpy(unsafe.Pointer(&cAddr.bytes[0]), unsafe.Pointer(&addr[0]), AddressSize)
 
@@ -73,6 +97,7 @@ func AddressToChecksumHex(addr [AddressSize]byte) string {
 
 // AddressIsZero returns true if the address is the zero address.
 func AddressIsZero(addr [AddressSize]byte) bool {
+	defer track("AddressIsZero", begin(), AddressSize, 1)
 	var cAddr CAddress
 	C.memcpy(unsafe.Pointer(&cAddr.bytes[0]), unsafe.Pointer(&addr[0]), AddressSize)
 	return bool(C.primitives_address_is_zero(&cAddr))
@@ -80,6 +105,7 @@ func AddressIsZero(addr [AddressSize]byte) bool {
 
 // AddressEquals returns true if two addresses are equal.
 func AddressEquals(a, b [AddressSize]byte) bool {
+	defer track("AddressEquals", begin(), 2*AddressSize, 1)
 	var cA, cB CAddress
 	C.memcpy(unsafe.Pointer(&cA.bytes[0]), unsafe.Pointer(&a[0]), AddressSize)
 	C.memcpy(unsafe.Pointer(&cB.bytes[0]), unsafe.Pointer(&b[0]), AddressSize)
@@ -88,17 +114,125 @@ func AddressEquals(a, b [AddressSize]byte) bool {
 
 // AddressValidateChecksum validates an EIP-55 checksummed address.
 func AddressValidateChecksum(hex string) bool {
+	defer track("AddressValidateChecksum", begin(), len(hex), 1)
 	cHex := C.CString(hex)
 	defer C.free(unsafe.Pointer(cHex))
 	return bool(C.primitives_address_validate_checksum(cHex))
 }
 
+// AddressToChecksumHexBatch converts addresses to EIP-55 checksummed hex
+// strings in a single call.
+func AddressToChecksumHexBatch(addrs [][AddressSize]byte) []string {
+	defer track("AddressToChecksumHexBatch", begin(), AddressSize*len(addrs), 42*len(addrs))
+	out := make([]string, len(addrs))
+	if len(addrs) == 0 {
+		return out
+	}
+
+	cAddrs := make([]CAddress, len(addrs))
+	for i := range addrs {
+		C.memcpy(unsafe.Pointer(&cAddrs[i].bytes[0]), unsafe.Pointer(&addrs[i][0]), AddressSize)
+	}
+	buf := make([]byte, 42*len(addrs))
+	C.primitives_address_to_checksum_hex_batch(&cAddrs[0], C.size_t(len(addrs)), (*C.uint8_t)(unsafe.Pointer(&buf[0])))
+
+	for i := range out {
+		out[i] = string(buf[42*i : 42*(i+1)])
+	}
+	return out
+}
+
+// AddressValidateChecksumBatch validates EIP-55 checksummed addresses in a
+// single call.
+func AddressValidateChecksumBatch(hexes []string) []bool {
+	out := make([]bool, len(hexes))
+	if len(hexes) == 0 {
+		return out
+	}
+
+	// One byte of slack keeps &buf[0] valid when every string is empty
+	total := 1
+	for _, h := range hexes {
+		total += len(h)
+	}
+	defer track("AddressValidateChecksumBatch", begin(), total-1, len(hexes))
+
+	buf := make([]byte, 0, total)
+	lens := make([]C.size_t, len(hexes))
+	for i, h := range hexes {
+		buf = append(buf, h...)
+		lens[i] = C.size_t(len(h))
+	}
+	buf = buf[:total]
+
+	valid := make([]C.bool, len(hexes))
+	C.primitives_address_validate_checksum_batch((*C.uint8_t)(unsafe.Pointer(&buf[0])), &lens[0], C.size_t(len(hexes)), &valid[0])
+
+	for i := range out {
+		out[i] = bool(valid[i])
+	}
+	return out
+}
+
+// CalculateCreateAddresses computes the CREATE addresses of sender for
+// count consecutive nonces starting at startNonce in a single call.
+func CalculateCreateAddresses(sender [AddressSize]byte, startNonce uint64, count int) ([][AddressSize]byte, error) {
+	defer track("CalculateCreateAddresses", begin(), AddressSize+8, AddressSize*max(count, 0))
+	if count < 0 {
+		return nil, ErrInvalidInput
+	}
+	out := make([][AddressSize]byte, count)
+	if count == 0 {
+		return out, nil
+	}
+
+	var cSender CAddress
+	C.memcpy(unsafe.Pointer(&cSender.bytes[0]), unsafe.Pointer(&sender[0]), AddressSize)
+	cAddrs := make([]CAddress, count)
+	result := C.primitives_calculate_create_addresses(&cSender, C.uint64_t(startNonce), C.size_t(count), &cAddrs[0])
+	if result != 0 {
+		return nil, MapError(int(result))
+	}
+
+	for i := range out {
+		C.memcpy(unsafe.Pointer(&out[i][0]), unsafe.Pointer(&cAddrs[i].bytes[0]), AddressSize)
+	}
+	return out, nil
+}
+
+// CalculateCreate2Addresses computes the CREATE2 address of sender for
+// each salt and the given init code hash in a single call.
+func CalculateCreate2Addresses(sender [AddressSize]byte, initCodeHash [HashSize]byte, salts [][32]byte) [][AddressSize]byte {
+	defer track("CalculateCreate2Addresses", begin(), AddressSize+HashSize+32*len(salts), AddressSize*len(salts))
+	out := make([][AddressSize]byte, len(salts))
+	if len(salts) == 0 {
+		return out
+	}
+
+	var cSender CAddress
+	C.memcpy(unsafe.Pointer(&cSender.bytes[0]), unsafe.Pointer(&sender[0]), AddressSize)
+	cAddrs := make([]CAddress, len(salts))
+	C.primitives_calculate_create2_addresses(
+		&cSender,
+		(*C.uint8_t)(unsafe.Pointer(&initCodeHash[0])),
+		(*C.uint8_t)(unsafe.Pointer(&salts[0][0])),
+		C.size_t(len(salts)),
+		&cAddrs[0],
+	)
+
+	for i := range out {
+		C.memcpy(unsafe.Pointer(&out[i][0]), unsafe.Pointer(&cAddrs[i].bytes[0]), AddressSize)
+	}
+	return out
+}
+
 // ============================================================================
 // Hash Functions
 // ============================================================================
 
 // Keccak256 computes the Keccak-256 hash of data.
 func Keccak256(data []byte) [HashSize]byte {
+	defer track("Keccak256", begin(), len(data), HashSize)
 	var cHash CHash
 	if len(data) == 0 {
 		C.primitives_keccak256(nil, 0, &cHash)
@@ -113,6 +247,7 @@ func Keccak256(data []byte) [HashSize]byte {
 
 // HashToHex converts a hash to hex string (with 0x prefix).
 func HashToHex(hash [HashSize]byte) string {
+	defer track("HashToHex", begin(), HashSize, 66)
 	var cHash CHash
 	C.memcpy(unsafe.Pointer(&cHash.bytes[0]), unsafe.Pointer(&hash[0]), HashSize)
 
@@ -123,6 +258,7 @@ func HashToHex(hash [HashSize]byte) string {
 
 // HashFromHex creates a hash from a hex string.
 func HashFromHex(hex string) ([HashSize]byte, error) {
+	defer track("HashFromHex", begin(), len(hex), HashSize)
 	cHex := C.CString(hex)
 	defer C.free(unsafe.Pointer(cHex))
 
@@ -139,18 +275,33 @@ func HashFromHex(hex string) ([HashSize]byte, error) {
 
 // HashEquals returns true if two hashes are equal (constant-time).
 func HashEquals(a, b [HashSize]byte) bool {
+	defer track("HashEquals", begin(), 2*HashSize, 1)
 	var cA, cB CHash
 	C.memcpy(unsafe.Pointer(&cA.bytes[0]), unsafe.Pointer(&a[0]), HashSize)
 	C.memcpy(unsafe.Pointer(&cB.bytes[0]), unsafe.Pointer(&b[0]), HashSize)
 	return bool(C.primitives_hash_equals(&cA, &cB))
 }
 
+// ConstantTimeEqual reports whether a and b are equal, in time that
+// depends only on their length.
+func ConstantTimeEqual(a, b []byte) bool {
+	defer track("ConstantTimeEqual", begin(), len(a)+len(b), 1)
+	if len(a) != len(b) {
+		return false
+	}
+	if len(a) == 0 {
+		return true
+	}
+	return bool(C.primitives_constant_time_equal(bytesPtr(a), bytesPtr(b), C.size_t(len(a))))
+}
+
 // ============================================================================
 // Hex Utilities
 // ============================================================================
 
 // HexToBytes converts a hex string to bytes.
 func HexToBytes(hex string) ([]byte, error) {
+	defer track("HexToBytes", begin(), len(hex), len(hex)/2)
 	cHex := C.CString(hex)
 	defer C.free(unsafe.Pointer(cHex))
 
@@ -176,6 +327,7 @@ func HexToBytes(hex string) ([]byte, error) {
 
 // BytesToHex converts bytes to a hex string (with 0x prefix).
 func BytesToHex(data []byte) string {
+	defer track("BytesToHex", begin(), len(data), 2+2*len(data))
 	if len(data) == 0 {
 		return "0x"
 	}
@@ -199,6 +351,7 @@ func BytesToHex(data []byte) string {
 
 // U256FromHex parses a U256 from a hex string.
 func U256FromHex(hex string) ([U256Size]byte, error) {
+	defer track("U256FromHex", begin(), len(hex), U256Size)
 	cHex := C.CString(hex)
 	defer C.free(unsafe.Pointer(cHex))
 
@@ -215,6 +368,7 @@ func U256FromHex(hex string) ([U256Size]byte, error) {
 
 // U256ToHex converts a U256 to hex string (with 0x prefix).
 func U256ToHex(value [U256Size]byte) string {
+	defer track("U256ToHex", begin(), U256Size, 66)
 	var cU256 CU256
 	C.memcpy(unsafe.Pointer(&cU256.bytes[0]), unsafe.Pointer(&value[0]), U256Size)
 
@@ -229,6 +383,7 @@ func U256ToHex(value [U256Size]byte) string {
 
 // SHA256 computes the SHA-256 hash of data.
 func SHA256(data []byte) [HashSize]byte {
+	defer track("SHA256", begin(), len(data), HashSize)
 	var hash [HashSize]byte
 	if len(data) == 0 {
 		C.primitives_sha256(nil, 0, (*C.uint8_t)(unsafe.Pointer(&hash[0])))
@@ -240,6 +395,7 @@ func SHA256(data []byte) [HashSize]byte {
 
 // RIPEMD160 computes the RIPEMD-160 hash of data.
 func RIPEMD160(data []byte) [20]byte {
+	defer track("RIPEMD160", begin(), len(data), 20)
 	var hash [20]byte
 	if len(data) == 0 {
 		C.primitives_ripemd160(nil, 0, (*C.uint8_t)(unsafe.Pointer(&hash[0])))
@@ -251,6 +407,7 @@ func RIPEMD160(data []byte) [20]byte {
 
 // Blake2b computes the Blake2b hash of data.
 func Blake2b(data []byte) [HashSize]byte {
+	defer track("Blake2b", begin(), len(data), HashSize)
 	var hash [HashSize]byte
 	if len(data) == 0 {
 		C.primitives_blake2b(nil, 0, (*C.uint8_t)(unsafe.Pointer(&hash[0])))
@@ -260,6 +417,446 @@ func Blake2b(data []byte) [HashSize]byte {
 	return hash
 }
 
+// ============================================================================
+// ENS
+// ============================================================================
+
+// ENSNormalize normalizes a non-empty ENS name per ENSIP-15.
+func ENSNormalize(name string) (string, error) {
+	defer track("ENSNormalize", begin(), len(name), len(name))
+	return ensTransform(name, func(in, out *C.uint8_t, inLen, outLen C.size_t) C.int {
+		return C.primitives_ens_normalize(in, inLen, out, outLen)
+	})
+}
+
+// ENSBeautify normalizes a non-empty ENS name per ENSIP-15 for display,
+// keeping emoji presentation selectors.
+func ENSBeautify(name string) (string, error) {
+	defer track("ENSBeautify", begin(), len(name), len(name))
+	return ensTransform(name, func(in, out *C.uint8_t, inLen, outLen C.size_t) C.int {
+		return C.primitives_ens_beautify(in, inLen, out, outLen)
+	})
+}
+
+// ensTransform calls an ENS export, growing the output buffer if the
+// mapped name is longer than expected.
+func ensTransform(name string, call func(in, out *C.uint8_t, inLen, outLen C.size_t) C.int) (string, error) {
+	if name == "" {
+		return "", ErrInvalidInput
+	}
+	in := []byte(name)
+	out := make([]byte, 2*len(in)+64)
+	for {
+		result := call(bytesPtr(in), bytesPtr(out), C.size_t(len(in)), C.size_t(len(out)))
+		if result >= 0 {
+			return string(out[:result]), nil
+		}
+		// UTS-46 mappings expand a character to at most a few dozen bytes
+		if err := MapError(int(result)); err != ErrInvalidLength || len(out) > 32*len(in)+64 {
+			return "", err
+		}
+		out = make([]byte, 4*len(out))
+	}
+}
+
+// ============================================================================
+// secp256k1
+// ============================================================================
+
+// PublicKeySize is the size of an uncompressed public key (x || y) in bytes.
+const PublicKeySize = 64
+
+// Secp256k1PublicKey derives the uncompressed public key (x || y) for a
+// private key. Returns ErrInvalidInput if the key is zero or not below the
+// curve order.
+func Secp256k1PublicKey(privateKey [32]byte) ([PublicKeySize]byte, error) {
+	defer track("Secp256k1PublicKey", begin(), 32, PublicKeySize)
+	var pubkey [PublicKeySize]byte
+	result := C.primitives_secp256k1_pubkey_from_private(
+		(*C.uint8_t)(unsafe.Pointer(&privateKey[0])),
+		(*C.uint8_t)(unsafe.Pointer(&pubkey[0])),
+	)
+	if result != 0 {
+		return [PublicKeySize]byte{}, MapError(int(result))
+	}
+	return pubkey, nil
+}
+
+// Secp256k1RecoverAddress recovers the address whose key signed hash.
+// v is the recovery id, either 0/1 or 27/28. Returns ErrInvalidSignature
+// if no public key can be recovered.
+func Secp256k1RecoverAddress(hash, r, s [32]byte, v byte) ([AddressSize]byte, error) {
+	defer track("Secp256k1RecoverAddress", begin(), 97, AddressSize)
+	var cAddr CAddress
+	result := C.primitives_secp256k1_recover_address(
+		(*C.uint8_t)(unsafe.Pointer(&hash[0])),
+		(*C.uint8_t)(unsafe.Pointer(&r[0])),
+		(*C.uint8_t)(unsafe.Pointer(&s[0])),
+		C.uint8_t(v),
+		&cAddr,
+	)
+	if result != 0 {
+		return [AddressSize]byte{}, MapError(int(result))
+	}
+
+	var addr [AddressSize]byte
+	C.memcpy(unsafe.Pointer(&addr[0]), unsafe.Pointer(&cAddr.bytes[0]), AddressSize)
+	return addr, nil
+}
+
+// ============================================================================
+// BLS12-381
+// ============================================================================
+
+// BLS key and signature sizes in bytes (compressed points).
+const (
+	BLSSecretKeySize = 32
+	BLSPublicKeySize = 48
+	BLSSignatureSize = 96
+)
+
+// BLSSecretToPublic derives the compressed public key of a secret key.
+// Returns ErrInvalidInput if the key is zero or not below the group order.
+func BLSSecretToPublic(secretKey [BLSSecretKeySize]byte) ([BLSPublicKeySize]byte, error) {
+	defer track("BLSSecretToPublic", begin(), BLSSecretKeySize, BLSPublicKeySize)
+	var pk [BLSPublicKeySize]byte
+	result := C.primitives_bls_secret_to_public(
+		(*C.uint8_t)(unsafe.Pointer(&secretKey[0])),
+		(*C.uint8_t)(unsafe.Pointer(&pk[0])),
+	)
+	if result != 0 {
+		return [BLSPublicKeySize]byte{}, MapError(int(result))
+	}
+	return pk, nil
+}
+
+// BLSSign signs message with the Ethereum consensus ciphersuite.
+func BLSSign(secretKey [BLSSecretKeySize]byte, message []byte) ([BLSSignatureSize]byte, error) {
+	defer track("BLSSign", begin(), BLSSecretKeySize+len(message), BLSSignatureSize)
+	var sig [BLSSignatureSize]byte
+	result := C.primitives_bls_sign(
+		(*C.uint8_t)(unsafe.Pointer(&secretKey[0])),
+		bytesPtr(message), C.size_t(len(message)),
+		(*C.uint8_t)(unsafe.Pointer(&sig[0])),
+	)
+	if result != 0 {
+		return [BLSSignatureSize]byte{}, MapError(int(result))
+	}
+	return sig, nil
+}
+
+// BLSVerify reports whether sig is a valid signature of message by pk.
+// Malformed keys and signatures are reported as invalid.
+func BLSVerify(pk [BLSPublicKeySize]byte, message []byte, sig [BLSSignatureSize]byte) bool {
+	defer track("BLSVerify", begin(), BLSPublicKeySize+len(message)+BLSSignatureSize, 1)
+	return C.primitives_bls_verify(
+		(*C.uint8_t)(unsafe.Pointer(&pk[0])),
+		bytesPtr(message), C.size_t(len(message)),
+		(*C.uint8_t)(unsafe.Pointer(&sig[0])),
+	) == 1
+}
+
+// BLSAggregateSignatures sums signatures. Returns ErrInvalidInput for an
+// empty list and ErrInvalidSignature if any signature does not decode.
+func BLSAggregateSignatures(sigs [][BLSSignatureSize]byte) ([BLSSignatureSize]byte, error) {
+	defer track("BLSAggregateSignatures", begin(), BLSSignatureSize*len(sigs), BLSSignatureSize)
+	if len(sigs) == 0 {
+		return [BLSSignatureSize]byte{}, ErrInvalidInput
+	}
+	var agg [BLSSignatureSize]byte
+	result := C.primitives_bls_aggregate_signatures(
+		(*C.uint8_t)(unsafe.Pointer(&sigs[0][0])), C.size_t(len(sigs)),
+		(*C.uint8_t)(unsafe.Pointer(&agg[0])),
+	)
+	if result != 0 {
+		return [BLSSignatureSize]byte{}, MapError(int(result))
+	}
+	return agg, nil
+}
+
+// BLSAggregatePublicKeys sums public keys. Returns ErrInvalidInput for an
+// empty list or if any key does not decode or is the point at infinity.
+func BLSAggregatePublicKeys(pks [][BLSPublicKeySize]byte) ([BLSPublicKeySize]byte, error) {
+	defer track("BLSAggregatePublicKeys", begin(), BLSPublicKeySize*len(pks), BLSPublicKeySize)
+	if len(pks) == 0 {
+		return [BLSPublicKeySize]byte{}, ErrInvalidInput
+	}
+	var agg [BLSPublicKeySize]byte
+	result := C.primitives_bls_aggregate_public_keys(
+		(*C.uint8_t)(unsafe.Pointer(&pks[0][0])), C.size_t(len(pks)),
+		(*C.uint8_t)(unsafe.Pointer(&agg[0])),
+	)
+	if result != 0 {
+		return [BLSPublicKeySize]byte{}, MapError(int(result))
+	}
+	return agg, nil
+}
+
+// BLSFastAggregateVerify reports whether sig is a valid aggregate
+// signature of message by all of pks.
+func BLSFastAggregateVerify(pks [][BLSPublicKeySize]byte, message []byte, sig [BLSSignatureSize]byte) bool {
+	defer track("BLSFastAggregateVerify", begin(), BLSPublicKeySize*len(pks)+len(message)+BLSSignatureSize, 1)
+	if len(pks) == 0 {
+		return false
+	}
+	return C.primitives_bls_fast_aggregate_verify(
+		(*C.uint8_t)(unsafe.Pointer(&pks[0][0])), C.size_t(len(pks)),
+		bytesPtr(message), C.size_t(len(message)),
+		(*C.uint8_t)(unsafe.Pointer(&sig[0])),
+	) == 1
+}
+
+// bytesPtr returns a C pointer to the first byte of b, or nil if b is
+// empty.
+func bytesPtr(b []byte) *C.uint8_t {
+	if len(b) == 0 {
+		return nil
+	}
+	return (*C.uint8_t)(unsafe.Pointer(&b[0]))
+}
+
+// ============================================================================
+// BN254
+// ============================================================================
+
+// BN254 precompile sizes in bytes.
+const (
+	BN254G1Size       = 64
+	BN254AddInputSize = 2 * BN254G1Size
+	BN254MulInputSize = BN254G1Size + 32
+	BN254PairSize     = 192
+)
+
+// BN254Add adds two G1 points in the ECADD precompile format. Returns
+// ErrInvalidInput if a point is not on the curve.
+func BN254Add(input [BN254AddInputSize]byte) ([BN254G1Size]byte, error) {
+	defer track("BN254Add", begin(), BN254AddInputSize, BN254G1Size)
+	var out [BN254G1Size]byte
+	result := C.primitives_bn254_add(
+		(*C.uint8_t)(unsafe.Pointer(&input[0])),
+		(*C.uint8_t)(unsafe.Pointer(&out[0])),
+	)
+	if result != 0 {
+		return [BN254G1Size]byte{}, MapError(int(result))
+	}
+	return out, nil
+}
+
+// BN254Mul multiplies a G1 point by a scalar in the ECMUL precompile
+// format.
+func BN254Mul(input [BN254MulInputSize]byte) ([BN254G1Size]byte, error) {
+	defer track("BN254Mul", begin(), BN254MulInputSize, BN254G1Size)
+	var out [BN254G1Size]byte
+	result := C.primitives_bn254_mul(
+		(*C.uint8_t)(unsafe.Pointer(&input[0])),
+		(*C.uint8_t)(unsafe.Pointer(&out[0])),
+	)
+	if result != 0 {
+		return [BN254G1Size]byte{}, MapError(int(result))
+	}
+	return out, nil
+}
+
+// BN254Pairing runs the ECPAIRING check on input, a sequence of
+// BN254PairSize-byte (G1, G2) pairs.
+func BN254Pairing(input []byte) (bool, error) {
+	defer track("BN254Pairing", begin(), len(input), 1)
+	if len(input)%BN254PairSize != 0 {
+		return false, ErrInvalidLength
+	}
+	result := C.primitives_bn254_pairing(bytesPtr(input), C.size_t(len(input)))
+	if result < 0 {
+		return false, MapError(int(result))
+	}
+	return result == 1, nil
+}
+
+// ============================================================================
+// KZG (EIP-4844)
+// ============================================================================
+
+// KZG sizes in bytes.
+const (
+	KZGBlobSize       = 131072
+	KZGCommitmentSize = 48
+	KZGProofSize      = 48
+	KZGFieldSize      = 32
+)
+
+// kzgSetup tracks the trusted setup loaded in the native library. KZG
+// operations hold the read lock; loading and freeing hold the write lock.
+//
+// The native library holds a single setup and must free it before loading
+// another, so a failed load leaves none. failed records that a requested
+// setup could not be loaded; KZG operations then return ErrKZGNotLoaded
+// rather than silently falling back to the mainnet setup.
+var kzgSetup struct {
+	sync.RWMutex
+	loaded bool
+	failed bool
+}
+
+// KZGLoadDefaultTrustedSetup loads the mainnet trusted setup embedded in the
+// native library, replacing any setup already loaded.
+func KZGLoadDefaultTrustedSetup() error {
+	defer track("KZGLoadDefaultTrustedSetup", begin(), 0, 0)
+	kzgSetup.Lock()
+	defer kzgSetup.Unlock()
+	return kzgLoad(func() C.int { return C.kzg_load_trusted_setup() })
+}
+
+// KZGLoadTrustedSetup loads a trusted setup in the c-kzg-4844 text format,
+// replacing any setup already loaded. Returns ErrKZGNotLoaded if text is
+// malformed; the previous setup is gone by then, and KZG operations fail
+// with ErrKZGNotLoaded until a setup loads or KZGFreeTrustedSetup is
+// called. Empty text is rejected before anything is freed.
+func KZGLoadTrustedSetup(text []byte) error {
+	defer track("KZGLoadTrustedSetup", begin(), len(text), 0)
+	if len(text) == 0 {
+		return ErrKZGNotLoaded
+	}
+	kzgSetup.Lock()
+	defer kzgSetup.Unlock()
+	return kzgLoad(func() C.int {
+		return C.kzg_load_trusted_setup_from_text(bytesPtr(text), C.size_t(len(text)))
+	})
+}
+
+// KZGFreeTrustedSetup releases the loaded trusted setup and clears a
+// failed load. The next KZG operation loads the embedded mainnet setup
+// again.
+func KZGFreeTrustedSetup() error {
+	defer track("KZGFreeTrustedSetup", begin(), 0, 0)
+	kzgSetup.Lock()
+	defer kzgSetup.Unlock()
+	if err := kzgFree(); err != nil {
+		return err
+	}
+	kzgSetup.failed = false
+	return nil
+}
+
+// kzgReload frees any loaded setup and runs load. The caller holds the
+// write lock.
+func kzgReload(load func() C.int) error {
+	if err := kzgFree(); err != nil {
+		return err
+	}
+	if result := load(); result != 0 {
+		return MapError(int(result))
+	}
+	kzgSetup.loaded = true
+	return nil
+}
+
+// kzgLoad runs a load requested by the caller, recording whether it left
+// no setup loaded. The caller holds the write lock.
+func kzgLoad(load func() C.int) error {
+	err := kzgReload(load)
+	kzgSetup.failed = err != nil && !kzgSetup.loaded
+	return err
+}
+
+// kzgFree frees the loaded setup, if any. The caller holds the write lock.
+func kzgFree() error {
+	if !kzgSetup.loaded {
+		return nil
+	}
+	if result := C.kzg_free_trusted_setup(); result != 0 {
+		return MapError(int(result))
+	}
+	kzgSetup.loaded = false
+	return nil
+}
+
+// kzgAcquire takes the read lock, loading the embedded setup first if none
+// is loaded and no load has failed. On success the caller must call
+// kzgSetup.RUnlock.
+func kzgAcquire() error {
+	for {
+		kzgSetup.RLock()
+		if kzgSetup.loaded {
+			return nil
+		}
+		kzgSetup.RUnlock()
+
+		kzgSetup.Lock()
+		var err error
+		switch {
+		case kzgSetup.failed:
+			err = ErrKZGNotLoaded
+		case !kzgSetup.loaded:
+			err = kzgReload(func() C.int { return C.kzg_load_trusted_setup() })
+		}
+		kzgSetup.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// KZGBlobToCommitment computes the KZG commitment to blob. Returns
+// ErrKZGInvalidBlob if a field element is not canonical.
+func KZGBlobToCommitment(blob *[KZGBlobSize]byte) ([KZGCommitmentSize]byte, error) {
+	defer track("KZGBlobToCommitment", begin(), KZGBlobSize, KZGCommitmentSize)
+	if err := kzgAcquire(); err != nil {
+		return [KZGCommitmentSize]byte{}, err
+	}
+	defer kzgSetup.RUnlock()
+	var commitment [KZGCommitmentSize]byte
+	result := C.kzg_blob_to_commitment(
+		(*C.uint8_t)(unsafe.Pointer(&blob[0])),
+		(*C.uint8_t)(unsafe.Pointer(&commitment[0])),
+	)
+	if result != 0 {
+		return [KZGCommitmentSize]byte{}, MapError(int(result))
+	}
+	return commitment, nil
+}
+
+// KZGComputeProof evaluates the blob polynomial at z and returns the proof
+// and the evaluation y.
+func KZGComputeProof(blob *[KZGBlobSize]byte, z [KZGFieldSize]byte) ([KZGProofSize]byte, [KZGFieldSize]byte, error) {
+	defer track("KZGComputeProof", begin(), KZGBlobSize+KZGFieldSize, KZGProofSize+KZGFieldSize)
+	if err := kzgAcquire(); err != nil {
+		return [KZGProofSize]byte{}, [KZGFieldSize]byte{}, err
+	}
+	defer kzgSetup.RUnlock()
+	var proof [KZGProofSize]byte
+	var y [KZGFieldSize]byte
+	result := C.kzg_compute_proof(
+		(*C.uint8_t)(unsafe.Pointer(&blob[0])),
+		(*C.uint8_t)(unsafe.Pointer(&z[0])),
+		(*C.uint8_t)(unsafe.Pointer(&proof[0])),
+		(*C.uint8_t)(unsafe.Pointer(&y[0])),
+	)
+	if result != 0 {
+		return [KZGProofSize]byte{}, [KZGFieldSize]byte{}, MapError(int(result))
+	}
+	return proof, y, nil
+}
+
+// KZGVerifyProof reports whether proof shows that the polynomial committed
+// to by commitment evaluates to y at z. Returns ErrKZGInvalidProof if an
+// input does not decode.
+func KZGVerifyProof(commitment [KZGCommitmentSize]byte, z, y [KZGFieldSize]byte, proof [KZGProofSize]byte) (bool, error) {
+	defer track("KZGVerifyProof", begin(), KZGCommitmentSize+2*KZGFieldSize+KZGProofSize, 1)
+	if err := kzgAcquire(); err != nil {
+		return false, err
+	}
+	defer kzgSetup.RUnlock()
+	result := C.kzg_verify_proof(
+		(*C.uint8_t)(unsafe.Pointer(&commitment[0])),
+		(*C.uint8_t)(unsafe.Pointer(&z[0])),
+		(*C.uint8_t)(unsafe.Pointer(&y[0])),
+		(*C.uint8_t)(unsafe.Pointer(&proof[0])),
+	)
+	if result < 0 {
+		return false, MapError(int(result))
+	}
+	return result == 1, nil
+}
+
 // ============================================================================
 // Version
 // ============================================================================
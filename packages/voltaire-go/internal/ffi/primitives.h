@@ -63,6 +63,10 @@ int primitives_address_to_checksum_hex(const PrimitivesAddress * address, uint8_
 bool primitives_address_is_zero(const PrimitivesAddress * address);
 bool primitives_address_equals(const PrimitivesAddress * a, const PrimitivesAddress * b);
 bool primitives_address_validate_checksum(const char * hex);
+int primitives_address_to_checksum_hex_batch(const PrimitivesAddress * addresses, size_t count, uint8_t * buf);
+int primitives_address_validate_checksum_batch(const uint8_t * hex, const size_t * lens, size_t count, bool * out_valid);
+int primitives_calculate_create_addresses(const PrimitivesAddress * sender, uint64_t start_nonce, size_t count, PrimitivesAddress * out_addresses);
+int primitives_calculate_create2_addresses(const PrimitivesAddress * sender, const uint8_t * init_code_hash, const uint8_t * salts, size_t count, PrimitivesAddress * out_addresses);
 
 // ============================================================================
 // Keccak-256 API
@@ -72,6 +76,7 @@ int primitives_keccak256(const uint8_t * data, size_t data_len, PrimitivesHash *
 int primitives_hash_to_hex(const PrimitivesHash * hash, uint8_t * buf);
 int primitives_hash_from_hex(const char * hex, PrimitivesHash * out_hash);
 bool primitives_hash_equals(const PrimitivesHash * a, const PrimitivesHash * b);
+bool primitives_constant_time_equal(const uint8_t * a, const uint8_t * b, size_t len);
 
 // ============================================================================
 // Hex utilities API
@@ -95,6 +100,50 @@ int primitives_sha256(const uint8_t * data, size_t data_len, uint8_t * out_hash)
 int primitives_ripemd160(const uint8_t * data, size_t data_len, uint8_t * out_hash);
 int primitives_blake2b(const uint8_t * data, size_t data_len, uint8_t * out_hash);
 
+// ============================================================================
+// ENS (ENSIP-15)
+// ============================================================================
+
+int primitives_ens_normalize(const uint8_t * name, size_t name_len, uint8_t * out_buf, size_t buf_len);
+int primitives_ens_beautify(const uint8_t * name, size_t name_len, uint8_t * out_buf, size_t buf_len);
+
+// ============================================================================
+// secp256k1
+// ============================================================================
+
+int primitives_secp256k1_recover_address(const uint8_t * message_hash, const uint8_t * r, const uint8_t * s, uint8_t v, PrimitivesAddress * out_address);
+int primitives_secp256k1_pubkey_from_private(const uint8_t * private_key, uint8_t * out_pubkey);
+
+// ============================================================================
+// BLS12-381
+// ============================================================================
+
+int primitives_bls_secret_to_public(const uint8_t * secret_key, uint8_t * out_public_key);
+int primitives_bls_sign(const uint8_t * secret_key, const uint8_t * message, size_t message_len, uint8_t * out_signature);
+int primitives_bls_verify(const uint8_t * public_key, const uint8_t * message, size_t message_len, const uint8_t * signature);
+int primitives_bls_aggregate_signatures(const uint8_t * signatures, size_t count, uint8_t * out_signature);
+int primitives_bls_aggregate_public_keys(const uint8_t * public_keys, size_t count, uint8_t * out_public_key);
+int primitives_bls_fast_aggregate_verify(const uint8_t * public_keys, size_t count, const uint8_t * message, size_t message_len, const uint8_t * signature);
+
+// ============================================================================
+// BN254
+// ============================================================================
+
+int primitives_bn254_add(const uint8_t * input, uint8_t * out_point);
+int primitives_bn254_mul(const uint8_t * input, uint8_t * out_point);
+int primitives_bn254_pairing(const uint8_t * input, size_t input_len);
+
+// ============================================================================
+// KZG (EIP-4844)
+// ============================================================================
+
+int kzg_load_trusted_setup(void);
+int kzg_load_trusted_setup_from_text(const uint8_t * text, size_t text_len);
+int kzg_free_trusted_setup(void);
+int kzg_blob_to_commitment(const uint8_t * blob, uint8_t * out_commitment);
+int kzg_compute_proof(const uint8_t * blob, const uint8_t * z, uint8_t * out_proof, uint8_t * out_y);
+int kzg_verify_proof(const uint8_t * commitment, const uint8_t * z, const uint8_t * y, const uint8_t * proof);
+
 // ============================================================================
 // Version
 // ============================================================================
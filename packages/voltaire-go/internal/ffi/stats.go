@@ -0,0 +1,91 @@
+package ffi
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CallStats summarizes the calls made through one wrapper.
+type CallStats struct {
+	Function string
+	Calls    uint64
+	BytesIn  uint64
+	BytesOut uint64
+	Time     time.Duration
+}
+
+type callCounters struct {
+	calls, bytesIn, bytesOut, nanos atomic.Uint64
+}
+
+var stats struct {
+	enabled  atomic.Bool
+	cgoStart atomic.Int64
+	counters sync.Map // string -> *callCounters
+}
+
+// EnableStats turns call accounting on or off. Counters are kept when it
+// is turned off.
+func EnableStats(on bool) {
+	stats.enabled.Store(on)
+}
+
+// ResetStats clears the counters and restarts the cgo call count.
+func ResetStats() {
+	stats.counters.Range(func(k, _ any) bool {
+		stats.counters.Delete(k)
+		return true
+	})
+	stats.cgoStart.Store(runtime.NumCgoCall())
+}
+
+// ReadStats returns the per-wrapper counters sorted by name, and the
+// number of cgo calls made by the process since the last reset.
+func ReadStats() ([]CallStats, int64) {
+	var out []CallStats
+	stats.counters.Range(func(k, v any) bool {
+		c := v.(*callCounters)
+		out = append(out, CallStats{
+			Function: k.(string),
+			Calls:    c.calls.Load(),
+			BytesIn:  c.bytesIn.Load(),
+			BytesOut: c.bytesOut.Load(),
+			Time:     time.Duration(c.nanos.Load()),
+		})
+		return true
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].Function < out[j].Function })
+	return out, runtime.NumCgoCall() - stats.cgoStart.Load()
+}
+
+// begin returns the start time of a call, or the zero time when
+// accounting is off.
+func begin() time.Time {
+	if !stats.enabled.Load() {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// track records a call of fn that started at start and passed in bytes to
+// and out bytes from the native library. Wrappers defer it as
+//
+//	defer track("Name", begin(), in, out)
+func track(fn string, start time.Time, in, out int) {
+	if start.IsZero() {
+		return
+	}
+	elapsed := time.Since(start)
+	v, ok := stats.counters.Load(fn)
+	if !ok {
+		v, _ = stats.counters.LoadOrStore(fn, new(callCounters))
+	}
+	c := v.(*callCounters)
+	c.calls.Add(1)
+	c.bytesIn.Add(uint64(in))
+	c.bytesOut.Add(uint64(out))
+	c.nanos.Add(uint64(elapsed))
+}
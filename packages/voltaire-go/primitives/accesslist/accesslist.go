@@ -0,0 +1,159 @@
+// Package accesslist provides EIP-2930 access lists.
+//
+// An access list declares the accounts and storage slots a transaction
+// will touch. They are charged up front and then treated as warm
+// (EIP-2929), which is cheaper when the same slots would otherwise be
+// accessed cold.
+package accesslist
+
+import (
+	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+)
+
+// Intrinsic gas charged per access list entry (EIP-2930).
+const (
+	AddressCost    = 2400
+	StorageKeyCost = 1900
+)
+
+// Errors
+var (
+	ErrInvalidRLP   = errors.New("accesslist: invalid RLP structure")
+	ErrInvalidField = errors.New("accesslist: invalid field")
+)
+
+// AccessTuple is an account and the storage keys accessed in it.
+type AccessTuple struct {
+	Address     address.Address `json:"address"`
+	StorageKeys []hash.Hash     `json:"storageKeys"`
+}
+
+// AccessList is an EIP-2930 access list.
+type AccessList []AccessTuple
+
+// StorageKeyCount returns the total number of storage keys in the list.
+func (al AccessList) StorageKeyCount() int {
+	n := 0
+	for _, t := range al {
+		n += len(t.StorageKeys)
+	}
+	return n
+}
+
+// IntrinsicGas returns the gas charged for the access list as part of the
+// transaction's intrinsic gas. Duplicates are charged as listed; call
+// Dedup first to avoid paying for them.
+func (al AccessList) IntrinsicGas() uint64 {
+	return uint64(len(al))*AddressCost + uint64(al.StorageKeyCount())*StorageKeyCost
+}
+
+// Contains returns true if the list includes addr.
+func (al AccessList) Contains(addr address.Address) bool {
+	for _, t := range al {
+		if t.Address == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsSlot returns true if the list includes the storage key for addr.
+func (al AccessList) ContainsSlot(addr address.Address, key hash.Hash) bool {
+	for _, t := range al {
+		if t.Address != addr {
+			continue
+		}
+		for _, k := range t.StorageKeys {
+			if k == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Dedup returns a copy of the list with tuples for the same address merged
+// and duplicate storage keys removed. Addresses and keys keep the order of
+// their first occurrence.
+func (al AccessList) Dedup() AccessList {
+	out := make(AccessList, 0, len(al))
+	index := make(map[address.Address]int)
+	seen := make(map[address.Address]map[hash.Hash]bool)
+
+	for _, t := range al {
+		i, ok := index[t.Address]
+		if !ok {
+			i = len(out)
+			index[t.Address] = i
+			seen[t.Address] = make(map[hash.Hash]bool)
+			out = append(out, AccessTuple{Address: t.Address, StorageKeys: []hash.Hash{}})
+		}
+		for _, k := range t.StorageKeys {
+			if !seen[t.Address][k] {
+				seen[t.Address][k] = true
+				out[i].StorageKeys = append(out[i].StorageKeys, k)
+			}
+		}
+	}
+	return out
+}
+
+// EncodeRLP returns rlp([[address, [storageKey, ...]], ...]).
+func (al AccessList) EncodeRLP() []byte {
+	items := make([]interface{}, len(al))
+	for i, t := range al {
+		keys := make([]interface{}, len(t.StorageKeys))
+		for j := range t.StorageKeys {
+			keys[j] = t.StorageKeys[j][:]
+		}
+		items[i] = []interface{}{t.Address[:], keys}
+	}
+
+	// Cannot fail: all items are supported types
+	encoded, _ := rlp.EncodeList(items)
+	return encoded
+}
+
+// DecodeRLP decodes an RLP-encoded access list.
+func DecodeRLP(data []byte) (AccessList, error) {
+	decoded, err := rlp.DecodeBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := decoded.([]interface{})
+	if !ok {
+		return nil, ErrInvalidRLP
+	}
+
+	al := make(AccessList, 0, len(items))
+	for _, item := range items {
+		fields, ok := item.([]interface{})
+		if !ok || len(fields) != 2 {
+			return nil, ErrInvalidRLP
+		}
+
+		addr, ok := fields[0].([]byte)
+		if !ok || len(addr) != address.Size {
+			return nil, ErrInvalidField
+		}
+		keys, ok := fields[1].([]interface{})
+		if !ok {
+			return nil, ErrInvalidField
+		}
+
+		t := AccessTuple{Address: address.Address(addr), StorageKeys: make([]hash.Hash, len(keys))}
+		for i, k := range keys {
+			b, ok := k.([]byte)
+			if !ok || len(b) != hash.Size {
+				return nil, ErrInvalidField
+			}
+			t.StorageKeys[i] = hash.Hash(b)
+		}
+		al = append(al, t)
+	}
+	return al, nil
+}
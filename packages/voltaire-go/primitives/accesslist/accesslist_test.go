@@ -0,0 +1,143 @@
+package accesslist
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+var (
+	addrA = address.MustFromHex("0xde0b295669a9fd93d5f28d9ec85e40f4cb697bae")
+	addrB = address.MustFromHex("0xbb9bc244d798123fde783fcc1c72d3bb8c189413")
+	key0  = hash.Hash{}
+	key1  = hash.Hash{31: 1}
+)
+
+func TestIntrinsicGas(t *testing.T) {
+	tests := []struct {
+		name string
+		al   AccessList
+		want uint64
+	}{
+		{"empty", nil, 0},
+		{"address only", AccessList{{Address: addrA}}, 2400},
+		{"address and keys", AccessList{{Address: addrA, StorageKeys: []hash.Hash{key0, key1}}}, 2400 + 2*1900},
+		{"duplicates charged", AccessList{{Address: addrA, StorageKeys: []hash.Hash{key0}}, {Address: addrA, StorageKeys: []hash.Hash{key0}}}, 2*2400 + 2*1900},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.al.IntrinsicGas(); got != tt.want {
+				t.Errorf("IntrinsicGas() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDedup(t *testing.T) {
+	al := AccessList{
+		{Address: addrA, StorageKeys: []hash.Hash{key1, key0}},
+		{Address: addrB},
+		{Address: addrA, StorageKeys: []hash.Hash{key0, key1, key0}},
+	}
+	want := AccessList{
+		{Address: addrA, StorageKeys: []hash.Hash{key1, key0}},
+		{Address: addrB, StorageKeys: []hash.Hash{}},
+	}
+
+	got := al.Dedup()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Dedup() = %v, want %v", got, want)
+	}
+	if got.IntrinsicGas() != 2*2400+2*1900 {
+		t.Errorf("deduplicated gas = %d", got.IntrinsicGas())
+	}
+	if len(al[0].StorageKeys) != 2 {
+		t.Error("Dedup modified its receiver")
+	}
+}
+
+func TestContains(t *testing.T) {
+	al := AccessList{{Address: addrA, StorageKeys: []hash.Hash{key1}}}
+
+	if !al.Contains(addrA) || al.Contains(addrB) {
+		t.Error("Contains() returned wrong result")
+	}
+	if !al.ContainsSlot(addrA, key1) || al.ContainsSlot(addrA, key0) || al.ContainsSlot(addrB, key1) {
+		t.Error("ContainsSlot() returned wrong result")
+	}
+}
+
+func TestEncodeRLP(t *testing.T) {
+	al := AccessList{{Address: addrA, StorageKeys: []hash.Hash{key1}}}
+
+	// [[address, [key]]]
+	want := "f838" + "f7" + "94de0b295669a9fd93d5f28d9ec85e40f4cb697bae" +
+		"e1" + "a00000000000000000000000000000000000000000000000000000000000000001"
+	if got := hex.EncodeToString(al.EncodeRLP()); got != want {
+		t.Errorf("EncodeRLP() = %s, want %s", got, want)
+	}
+	if got := hex.EncodeToString(AccessList(nil).EncodeRLP()); got != "c0" {
+		t.Errorf("empty list = %s, want c0", got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	al := AccessList{
+		{Address: addrA, StorageKeys: []hash.Hash{key0, key1}},
+		{Address: addrB, StorageKeys: []hash.Hash{}},
+	}
+
+	got, err := DecodeRLP(al.EncodeRLP())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, al) {
+		t.Errorf("got %v, want %v", got, al)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{"not a list", "80", ErrInvalidRLP},
+		{"tuple not a list", "c101", ErrInvalidRLP},
+		{"short address", "c3c201c0", ErrInvalidField},
+		{"keys not a list", "d7d694de0b295669a9fd93d5f28d9ec85e40f4cb697bae80", ErrInvalidField},
+		{"short key", "d8d794de0b295669a9fd93d5f28d9ec85e40f4cb697baec101", ErrInvalidField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tt.data)
+			if _, err := DecodeRLP(data); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	al := AccessList{{Address: addrA, StorageKeys: []hash.Hash{key1}}}
+
+	data, err := json.Marshal(al)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got AccessList
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, al) {
+		t.Errorf("JSON round trip = %v, want %v", got, al)
+	}
+}
@@ -0,0 +1,11 @@
+package accesslist
+
+import "testing"
+
+func FuzzDecodeRLP(f *testing.F) {
+	f.Add([]byte{0xc0})
+	f.Add([]byte{0xc2, 0xc1, 0xc0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		DecodeRLP(data)
+	})
+}
@@ -3,6 +3,7 @@ package address
 
 import (
 	"bytes"
+	"database/sql/driver"
 	"encoding/hex"
 	"fmt"
 
@@ -68,7 +69,9 @@ func (a Address) IsZero() bool {
 	return ffi.AddressIsZero(a)
 }
 
-// Equal returns true if the addresses are equal.
+// Equal returns true if the addresses are equal. The native comparison
+// is constant-time, so Equal is also the one to use when timing must not
+// leak, for example when an address is derived from a secret.
 func (a Address) Equal(other Address) bool {
 	return ffi.AddressEquals(a, other)
 }
@@ -105,30 +108,92 @@ func (a Address) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
+// Accepts 0x-prefixed hex per the JSON-RPC spec; null leaves the value
+// unchanged.
 func (a *Address) UnmarshalJSON(data []byte) error {
-	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) < 4 || data[0] != '"' || data[len(data)-1] != '"' {
 		return ffi.ErrInvalidInput
 	}
+	if data[1] != '0' || (data[2] != 'x' && data[2] != 'X') {
+		return ffi.ErrInvalidHex
+	}
 	return a.UnmarshalText(data[1 : len(data)-1])
 }
 
+// Value implements driver.Valuer, storing the raw 20 bytes (e.g. a bytea column).
+func (a Address) Value() (driver.Value, error) {
+	return a.Bytes(), nil
+}
+
+// Scan implements sql.Scanner.
+// Accepts raw 20-byte values and hex strings. NULL is an error rather
+// than the zero address; scan nullable columns into sql.Null[Address].
+func (a *Address) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return fmt.Errorf("%w: NULL, use sql.Null[Address] for nullable columns", ffi.ErrInvalidInput)
+	case []byte:
+		if len(v) == Size {
+			copy(a[:], v)
+			return nil
+		}
+		return a.UnmarshalText(v)
+	case string:
+		return a.UnmarshalText([]byte(v))
+	default:
+		return ffi.ErrUnsupportedType
+	}
+}
+
 // ValidateChecksum validates that a hex string has a valid EIP-55 checksum.
 func ValidateChecksum(s string) bool {
 	return ffi.AddressValidateChecksum(s)
 }
 
-// FromPrivateKey derives an address from a private key.
+// ValidateChecksumBatch reports for each hex string whether it has a valid
+// EIP-55 checksum. The whole batch crosses the FFI boundary once, which is
+// much cheaper than calling ValidateChecksum per address.
+func ValidateChecksumBatch(hexes []string) []bool {
+	return ffi.AddressValidateChecksumBatch(hexes)
+}
+
+// ToChecksumHexBatch returns the EIP-55 checksummed hex of each address,
+// crossing the FFI boundary once for the whole batch.
+func ToChecksumHexBatch(addrs []Address) []string {
+	raw := make([][Size]byte, len(addrs))
+	for i, a := range addrs {
+		raw[i] = a
+	}
+	return ffi.AddressToChecksumHexBatch(raw)
+}
+
+// FromPrivateKey derives the address controlled by a 32-byte secp256k1
+// private key. Returns an error if the key is zero or not below the curve
+// order.
 func FromPrivateKey(privateKey []byte) (Address, error) {
 	if len(privateKey) != 32 {
 		return Address{}, ffi.ErrInvalidLength
 	}
-	// TODO: Implement via secp256k1 -> keccak256[12:]
-	return Address{}, fmt.Errorf("not implemented")
+	pubkey, err := ffi.Secp256k1PublicKey([32]byte(privateKey))
+	if err != nil {
+		return Address{}, err
+	}
+	return FromPublicKey(pubkey[:])
 }
 
-// FromPublicKey derives an address from an uncompressed public key (64 bytes).
+// FromPublicKey derives an address from an uncompressed public key, either
+// 64 bytes (x || y) or 65 bytes with the 0x04 prefix.
+// Address = keccak256(x || y)[12:]
 func FromPublicKey(publicKey []byte) (Address, error) {
-	if len(publicKey) != 64 {
+	switch {
+	case len(publicKey) == 65 && publicKey[0] == 0x04:
+		publicKey = publicKey[1:]
+	case len(publicKey) == 65:
+		return Address{}, ffi.ErrInvalidInput
+	case len(publicKey) != 64:
 		return Address{}, ffi.ErrInvalidLength
 	}
 	hash := ffi.Keccak256(publicKey)
@@ -1,8 +1,14 @@
 package address
 
 import (
+	"database/sql"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"flag"
 	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
 )
 
 func TestFromHex(t *testing.T) {
@@ -110,6 +116,40 @@ func TestValidateChecksum(t *testing.T) {
 	}
 }
 
+func TestChecksumBatch(t *testing.T) {
+	inputs := []string{
+		"0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
+		"0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
+		"",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"not an address",
+	}
+	want := []bool{true, false, false, true, false}
+
+	got := ValidateChecksumBatch(inputs)
+	if len(got) != len(want) {
+		t.Fatalf("got %d results, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] || got[i] != ValidateChecksum(inputs[i]) {
+			t.Errorf("ValidateChecksumBatch()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	addrs := []Address{
+		MustFromHex("0xd8da6bf26964af9d7eed9e03e53415d37aa96045"),
+		MustFromHex("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"),
+	}
+	hexes := ToChecksumHexBatch(addrs)
+	if len(hexes) != 2 || hexes[0] != inputs[0] || hexes[1] != inputs[3] {
+		t.Errorf("ToChecksumHexBatch() = %v", hexes)
+	}
+
+	if len(ValidateChecksumBatch(nil)) != 0 || len(ToChecksumHexBatch(nil)) != 0 {
+		t.Error("empty batches should return empty results")
+	}
+}
+
 func TestJSONMarshal(t *testing.T) {
 	addr, _ := FromHex("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
 
@@ -180,3 +220,149 @@ func TestMustFromHex(t *testing.T) {
 
 	MustFromHex("invalid")
 }
+
+func TestJSONUnmarshalRPC(t *testing.T) {
+	addr := MustFromHex("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
+	if err := json.Unmarshal([]byte(`null`), &addr); err != nil {
+		t.Fatalf("Unmarshal(null) error: %v", err)
+	}
+	if addr.IsZero() {
+		t.Error("null should leave the address unchanged")
+	}
+
+	var a Address
+	if err := json.Unmarshal([]byte(`"d8da6bf26964af9d7eed9e03e53415d37aa96045"`), &a); err == nil {
+		t.Error("expected error for missing 0x prefix")
+	}
+}
+
+func TestSQLValueScan(t *testing.T) {
+	addr := MustFromHex("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
+
+	v, err := addr.Value()
+	if err != nil {
+		t.Fatalf("Value error: %v", err)
+	}
+	raw, ok := v.([]byte)
+	if !ok || len(raw) != Size {
+		t.Fatalf("Value() = %T %v, want 20 raw bytes", v, v)
+	}
+
+	tests := []struct {
+		name string
+		src  interface{}
+	}{
+		{"raw bytes", raw},
+		{"hex string", "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"},
+		{"hex bytes", []byte("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Address
+			if err := got.Scan(tt.src); err != nil {
+				t.Fatalf("Scan error: %v", err)
+			}
+			if got != addr {
+				t.Errorf("got %s, want %s", got, addr)
+			}
+		})
+	}
+
+	var got Address
+	if err := got.Scan(int64(1)); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+	if err := got.Scan(nil); err == nil {
+		t.Error("expected error for NULL")
+	}
+}
+
+func TestSQLNull(t *testing.T) {
+	var a Address
+	if err := a.Scan(nil); !errors.Is(err, ffi.ErrInvalidInput) {
+		t.Errorf("Scan(nil) error = %v, want %v", err, ffi.ErrInvalidInput)
+	}
+
+	var n sql.Null[Address]
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Null.Scan(nil) = %+v, %v, want invalid", n, err)
+	}
+	want := MustFromHex("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
+	if err := n.Scan(want.Hex()); err != nil || !n.Valid || n.V != want {
+		t.Errorf("Null.Scan(%s) = %+v, %v", want, n, err)
+	}
+}
+
+func TestFlagTextVar(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var addr Address
+	fs.TextVar(&addr, "to", Zero, "recipient")
+
+	if err := fs.Parse([]string{"-to", "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if addr.Hex() != "0xd8da6bf26964af9d7eed9e03e53415d37aa96045" {
+		t.Errorf("got %s", addr.Hex())
+	}
+}
+
+func TestFromPrivateKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{"one", "0x0000000000000000000000000000000000000000000000000000000000000001", "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", false},
+		{"web3 docs key", "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318", "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23", false},
+		{"zero", "0x0000000000000000000000000000000000000000000000000000000000000000", "", true},
+		{"curve order", "0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", "", true},
+		{"short", "0x01", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := hex.DecodeString(tt.key[2:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := FromPrivateKey(key)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ChecksumHex() != tt.want {
+				t.Errorf("got %s, want %s", got.ChecksumHex(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromPublicKey(t *testing.T) {
+	// Public key of private key 1 (the generator point)
+	pub, _ := hex.DecodeString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")
+	want := MustFromHex("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf")
+
+	got, err := FromPublicKey(pub)
+	if err != nil || got != want {
+		t.Errorf("64-byte key: got %s, %v", got.Hex(), err)
+	}
+
+	got, err = FromPublicKey(append([]byte{0x04}, pub...))
+	if err != nil || got != want {
+		t.Errorf("65-byte key: got %s, %v", got.Hex(), err)
+	}
+
+	if _, err := FromPublicKey(append([]byte{0x02}, pub...)); err == nil {
+		t.Error("expected error for bad prefix")
+	}
+	if _, err := FromPublicKey(pub[:33]); err == nil {
+		t.Error("expected error for compressed key")
+	}
+}
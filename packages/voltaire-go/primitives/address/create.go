@@ -0,0 +1,90 @@
+package address
+
+import (
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+)
+
+// Create3ProxyInitCodeHash is the keccak256 hash of the minimal proxy
+// init code used by CREATE3 factories (0xSequence, Solady):
+// 0x67363d3d37363d34f03d5260086018f3.
+var Create3ProxyInitCodeHash = hash.Hash{
+	0x21, 0xc3, 0x5d, 0xbe, 0x1b, 0x34, 0x4a, 0x24, 0x88, 0xcf, 0x33, 0x21, 0xd6, 0xce, 0x54, 0x2f,
+	0x8e, 0x9f, 0x30, 0x55, 0x44, 0xff, 0x09, 0xe4, 0x99, 0x3a, 0x62, 0x31, 0x9a, 0x49, 0x7c, 0x1f,
+}
+
+// CalculateCreateAddress computes the address of a contract deployed with
+// CREATE: keccak256(rlp([sender, nonce]))[12:].
+func CalculateCreateAddress(sender Address, nonce uint64) Address {
+	// Cannot fail: both items are supported types
+	encoded, _ := rlp.EncodeList([]interface{}{sender[:], nonce})
+	return fromHash(ffi.Keccak256(encoded))
+}
+
+// CalculateCreateAddresses computes the CREATE addresses of sender for
+// count consecutive nonces starting at startNonce. The whole batch crosses
+// the FFI boundary once. It returns an error if count is negative or the
+// nonce range overflows uint64.
+func CalculateCreateAddresses(sender Address, startNonce uint64, count int) ([]Address, error) {
+	raw, err := ffi.CalculateCreateAddresses(sender, startNonce, count)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]Address, len(raw))
+	for i, a := range raw {
+		addrs[i] = a
+	}
+	return addrs, nil
+}
+
+// CalculateCreate2Address computes the address of a contract deployed with
+// CREATE2: keccak256(0xff || deployer || salt || keccak256(initCode))[12:].
+func CalculateCreate2Address(deployer Address, salt [32]byte, initCode []byte) Address {
+	return CalculateCreate2AddressFromHash(deployer, salt, ffi.Keccak256(initCode))
+}
+
+// CalculateCreate2AddressFromHash computes a CREATE2 address from the
+// keccak256 hash of the init code, for factories that publish only the
+// code hash.
+func CalculateCreate2AddressFromHash(deployer Address, salt [32]byte, initCodeHash hash.Hash) Address {
+	buf := make([]byte, 0, 1+Size+32+32)
+	buf = append(buf, 0xff)
+	buf = append(buf, deployer[:]...)
+	buf = append(buf, salt[:]...)
+	buf = append(buf, initCodeHash[:]...)
+	return fromHash(ffi.Keccak256(buf))
+}
+
+// CalculateCreate2AddressesFromHash computes the CREATE2 address of
+// deployer for each salt, crossing the FFI boundary once for the whole
+// batch.
+func CalculateCreate2AddressesFromHash(deployer Address, salts [][32]byte, initCodeHash hash.Hash) []Address {
+	raw := ffi.CalculateCreate2Addresses(deployer, initCodeHash, salts)
+	addrs := make([]Address, len(raw))
+	for i, a := range raw {
+		addrs[i] = a
+	}
+	return addrs
+}
+
+// CalculateCreate3Address computes the address of a contract deployed
+// through a CREATE3 factory. The factory deploys the minimal proxy with
+// CREATE2 using salt, and the proxy deploys the contract with CREATE at
+// nonce 1, so the result depends only on factory and salt, not on the
+// contract's init code.
+//
+// Factories that derive the CREATE2 salt from the caller (for example by
+// hashing it with msg.sender) must have that derivation applied to salt
+// before calling this function.
+func CalculateCreate3Address(factory Address, salt [32]byte) Address {
+	proxy := CalculateCreate2AddressFromHash(factory, salt, Create3ProxyInitCodeHash)
+	return CalculateCreateAddress(proxy, 1)
+}
+
+// fromHash returns the last 20 bytes of a 32-byte hash.
+func fromHash(h [32]byte) Address {
+	var addr Address
+	copy(addr[:], h[12:])
+	return addr
+}
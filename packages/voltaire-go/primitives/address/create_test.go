@@ -0,0 +1,111 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+func TestCalculateCreateAddress(t *testing.T) {
+	sender := MustFromHex("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+	tests := []struct {
+		nonce uint64
+		want  string
+	}{
+		{0, "0xcd234a471b72ba2f1ccf0a70fcaba648a5eecd8d"},
+		{1, "0x343c43a37d37dff08ae8c4a11544c718abb4fcf8"},
+		{2, "0xf778b86fa74e846c4f0a1fbd1335fe81c00a0c91"},
+		{3, "0xfffd933a0bc612844eaf0c6fe3e5b8e9b6c1d19c"},
+	}
+
+	for _, tt := range tests {
+		if got := CalculateCreateAddress(sender, tt.nonce); got.Hex() != tt.want {
+			t.Errorf("nonce %d: got %s, want %s", tt.nonce, got.Hex(), tt.want)
+		}
+	}
+}
+
+func TestCalculateCreateAddresses(t *testing.T) {
+	sender := MustFromHex("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+
+	got, err := CalculateCreateAddresses(sender, 126, 260)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 260 {
+		t.Fatalf("got %d addresses, want 260", len(got))
+	}
+	// Spans the single-byte, one-byte-length and two-byte nonce encodings
+	for i, addr := range got {
+		if want := CalculateCreateAddress(sender, 126+uint64(i)); addr != want {
+			t.Errorf("nonce %d: got %s, want %s", 126+i, addr.Hex(), want.Hex())
+		}
+	}
+
+	if got, err := CalculateCreateAddresses(sender, 0, 0); err != nil || len(got) != 0 {
+		t.Errorf("empty batch = %v, %v", got, err)
+	}
+	if got, err := CalculateCreateAddresses(sender, ^uint64(0), 1); err != nil || got[0] != CalculateCreateAddress(sender, ^uint64(0)) {
+		t.Errorf("max nonce = %v, %v", got, err)
+	}
+	if _, err := CalculateCreateAddresses(sender, ^uint64(0), 2); err != ffi.ErrInvalidInput {
+		t.Errorf("overflow error = %v, want %v", err, ffi.ErrInvalidInput)
+	}
+	if _, err := CalculateCreateAddresses(sender, 0, -1); err != ffi.ErrInvalidInput {
+		t.Errorf("negative count error = %v, want %v", err, ffi.ErrInvalidInput)
+	}
+}
+
+func TestCalculateCreate2Address(t *testing.T) {
+	// Examples from EIP-1014
+	tests := []struct {
+		name     string
+		deployer string
+		salt     string
+		initCode []byte
+		want     string
+	}{
+		{"example 0", "0x0000000000000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000", []byte{0x00}, "0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38"},
+		{"example 1", "0xdeadbeef00000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000", []byte{0x00}, "0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3"},
+		{"example 2", "0xdeadbeef00000000000000000000000000000000", "0x000000000000000000000000feed000000000000000000000000000000000000", []byte{0x00}, "0xD04116cDd17beBE565EB2422F2497E06cC1C9833"},
+		{"example 5", "0x00000000000000000000000000000000deadbeef", "0x00000000000000000000000000000000000000000000000000000000cafebabe", []byte{0xde, 0xad, 0xbe, 0xef}, "0x60f3f640a8508fC6a86d45DF051962668E1e8AC7"},
+		{"empty init code", "0x0000000000000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000", nil, "0xE33C0C7F7df4809055C3ebA6c09CFe4BaF1BD9e0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployer := MustFromHex(tt.deployer)
+			salt := [32]byte(hash.MustFromHex(tt.salt))
+
+			got := CalculateCreate2Address(deployer, salt, tt.initCode)
+			if got.ChecksumHex() != tt.want {
+				t.Errorf("got %s, want %s", got.ChecksumHex(), tt.want)
+			}
+
+			fromHash := CalculateCreate2AddressFromHash(deployer, salt, ffi.Keccak256(tt.initCode))
+			if fromHash != got {
+				t.Errorf("FromHash = %s, want %s", fromHash.Hex(), got.Hex())
+			}
+		})
+	}
+}
+
+func TestCalculateCreate3Address(t *testing.T) {
+	proxyInitCode := []byte{0x67, 0x36, 0x3d, 0x3d, 0x37, 0x36, 0x3d, 0x34, 0xf0, 0x3d, 0x52, 0x60, 0x08, 0x60, 0x18, 0xf3}
+	if got := hash.Hash(ffi.Keccak256(proxyInitCode)); got != Create3ProxyInitCodeHash {
+		t.Fatalf("Create3ProxyInitCodeHash = %s, want %s", Create3ProxyInitCodeHash.Hex(), got.Hex())
+	}
+
+	factory := MustFromHex("0x9fbb3df7c40da2e5a0de984ffe2ccb7c47cd0abf")
+	var salt [32]byte
+	salt[31] = 1
+
+	proxy := CalculateCreate2Address(factory, salt, proxyInitCode)
+	if want := CalculateCreateAddress(proxy, 1); CalculateCreate3Address(factory, salt) != want {
+		t.Errorf("CREATE3 address does not match CREATE from proxy at nonce 1")
+	}
+	if got := CalculateCreate3Address(factory, salt); got.Hex() != "0xd0181dc27d8744826fe896eae941e35aa9aa36c0" {
+		t.Errorf("got %s", got.Hex())
+	}
+}
@@ -0,0 +1,182 @@
+package address
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+// DefaultMinerBatchSize is the number of salts each SaltMiner worker
+// hashes per FFI call when BatchSize is not set.
+const DefaultMinerBatchSize = 4096
+
+// SaltMiner searches for a CREATE2 salt whose address satisfies Match.
+//
+// Candidate salts are Salt with its last 8 bytes replaced by a counter, so
+// the first 24 bytes can carry a fixed prefix, such as the caller address
+// required by factories with front-running protection.
+type SaltMiner struct {
+	Deployer     Address
+	InitCodeHash hash.Hash
+	Salt         [32]byte
+
+	// Match reports whether an address is acceptable. It is called
+	// concurrently from every worker.
+	Match func(Address) bool
+
+	// Workers is the number of goroutines; zero uses runtime.NumCPU.
+	Workers int
+	// BatchSize is the number of salts per FFI call; zero uses
+	// DefaultMinerBatchSize.
+	BatchSize int
+
+	// Progress, if set, is called after each batch with the total number
+	// of salts tried so far. It is called concurrently from every worker.
+	Progress func(tried uint64)
+}
+
+// Mine runs until a matching salt is found or ctx is done, and returns the
+// salt and the resulting address. If ctx is done first it returns
+// ctx.Err().
+func (m SaltMiner) Mine(ctx context.Context) ([32]byte, Address, error) {
+	if m.Match == nil {
+		return [32]byte{}, Address{}, ffi.ErrInvalidInput
+	}
+	workers := m.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	batch := m.BatchSize
+	if batch <= 0 {
+		batch = DefaultMinerBatchSize
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once      sync.Once
+		found     bool
+		foundSalt [32]byte
+		foundAddr Address
+		tried     atomic.Uint64
+		wg        sync.WaitGroup
+	)
+
+	// Worker w covers counters [w*batch, (w+1)*batch), then skips ahead by
+	// workers*batch, so no two workers try the same salt.
+	stride := uint64(workers) * uint64(batch)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(start uint64) {
+			defer wg.Done()
+			salts := make([][32]byte, batch)
+			for ; ctx.Err() == nil; start += stride {
+				for i := range salts {
+					salts[i] = m.Salt
+					binary.BigEndian.PutUint64(salts[i][24:], start+uint64(i))
+				}
+				for i, addr := range CalculateCreate2AddressesFromHash(m.Deployer, salts, m.InitCodeHash) {
+					if m.Match(addr) {
+						once.Do(func() {
+							found, foundSalt, foundAddr = true, salts[i], addr
+							cancel()
+						})
+						return
+					}
+				}
+				n := tried.Add(uint64(batch))
+				if m.Progress != nil {
+					m.Progress(n)
+				}
+			}
+		}(uint64(w) * uint64(batch))
+	}
+	wg.Wait()
+
+	if found {
+		return foundSalt, foundAddr, nil
+	}
+	return [32]byte{}, Address{}, ctx.Err()
+}
+
+// MatchPrefix returns a matcher for addresses whose lowercase hex starts
+// with prefix. prefix is hex nibbles with an optional 0x and any case.
+func MatchPrefix(prefix string) (func(Address) bool, error) {
+	nibbles, err := parseNibbles(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return func(a Address) bool {
+		for i, n := range nibbles {
+			if nibble(a, i) != n {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// MatchSuffix returns a matcher for addresses whose lowercase hex ends with
+// suffix. suffix is hex nibbles with an optional 0x and any case.
+func MatchSuffix(suffix string) (func(Address) bool, error) {
+	nibbles, err := parseNibbles(suffix)
+	if err != nil {
+		return nil, err
+	}
+	offset := 2*Size - len(nibbles)
+	return func(a Address) bool {
+		for i, n := range nibbles {
+			if nibble(a, offset+i) != n {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// MatchRegexp returns a matcher for addresses whose lowercase hex, without
+// the 0x prefix, matches re.
+func MatchRegexp(re *regexp.Regexp) func(Address) bool {
+	return func(a Address) bool {
+		var buf [2 * Size]byte
+		hex.Encode(buf[:], a[:])
+		return re.Match(buf[:])
+	}
+}
+
+// parseNibbles decodes a hex pattern of at most 40 nibbles.
+func parseNibbles(s string) ([]byte, error) {
+	s = strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+	if len(s) > 2*Size {
+		return nil, ffi.ErrInvalidLength
+	}
+	nibbles := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			nibbles[i] = c - '0'
+		case c >= 'a' && c <= 'f':
+			nibbles[i] = c - 'a' + 10
+		default:
+			return nil, ffi.ErrInvalidHex
+		}
+	}
+	return nibbles, nil
+}
+
+// nibble returns the i-th hex digit of a.
+func nibble(a Address, i int) byte {
+	if i%2 == 0 {
+		return a[i/2] >> 4
+	}
+	return a[i/2] & 0x0f
+}
@@ -0,0 +1,118 @@
+package address
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+)
+
+func TestCalculateCreate2AddressesFromHash(t *testing.T) {
+	deployer := MustFromHex("0x0000000000000000000000000000000000000000")
+	codeHash := keccak256.Hash([]byte{0x00})
+	salts := [][32]byte{{}, {31: 1}, {0: 0xff, 31: 0xff}}
+
+	got := CalculateCreate2AddressesFromHash(deployer, salts, codeHash)
+	if len(got) != len(salts) {
+		t.Fatalf("got %d addresses, want %d", len(got), len(salts))
+	}
+	for i, salt := range salts {
+		if want := CalculateCreate2AddressFromHash(deployer, salt, codeHash); got[i] != want {
+			t.Errorf("salt %d: got %s, want %s", i, got[i].Hex(), want.Hex())
+		}
+	}
+	if len(CalculateCreate2AddressesFromHash(deployer, nil, codeHash)) != 0 {
+		t.Error("empty batch should return no addresses")
+	}
+}
+
+func TestMatchers(t *testing.T) {
+	addr := MustFromHex("0xabc0000000000000000000000000000000000def")
+
+	prefix, err := MatchPrefix("0xABC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	suffix, err := MatchSuffix("def")
+	if err != nil {
+		t.Fatal(err)
+	}
+	odd, _ := MatchPrefix("abd")
+	re := MatchRegexp(regexp.MustCompile(`^abc0+def$`))
+
+	tests := []struct {
+		name  string
+		match func(Address) bool
+		want  bool
+	}{
+		{"prefix", prefix, true},
+		{"suffix", suffix, true},
+		{"wrong nibble", odd, false},
+		{"regexp", re, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.match(addr); got != tt.want {
+				t.Errorf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := MatchPrefix("0xzz"); !errors.Is(err, ffi.ErrInvalidHex) {
+		t.Errorf("invalid hex error = %v", err)
+	}
+	if _, err := MatchSuffix(strings.Repeat("0", 41)); !errors.Is(err, ffi.ErrInvalidLength) {
+		t.Errorf("long pattern error = %v", err)
+	}
+}
+
+func TestSaltMinerMine(t *testing.T) {
+	match, _ := MatchPrefix("0xbe")
+	m := SaltMiner{
+		Deployer:     MustFromHex("0x4e59b44847b379578588920ca78fbf26c0b4956c"),
+		InitCodeHash: keccak256.Hash([]byte{0x60, 0x00}),
+		Salt:         [32]byte{0: 0xaa, 23: 0xbb},
+		Match:        match,
+		Workers:      4,
+		BatchSize:    64,
+	}
+
+	salt, addr, err := m.Mine(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !match(addr) {
+		t.Errorf("mined address %s does not match", addr.Hex())
+	}
+	if want := CalculateCreate2AddressFromHash(m.Deployer, salt, m.InitCodeHash); addr != want {
+		t.Errorf("address = %s, want %s for salt %x", addr.Hex(), want.Hex(), salt)
+	}
+	if salt[0] != 0xaa || salt[23] != 0xbb {
+		t.Errorf("salt %x does not keep the fixed prefix", salt)
+	}
+}
+
+func TestSaltMinerCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := SaltMiner{
+		Match:     func(Address) bool { return false },
+		Workers:   2,
+		BatchSize: 16,
+		Progress: func(tried uint64) {
+			if tried >= 1000 {
+				cancel()
+			}
+		},
+	}
+
+	if _, _, err := m.Mine(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Mine() error = %v, want %v", err, context.Canceled)
+	}
+	if _, _, err := (SaltMiner{}).Mine(context.Background()); !errors.Is(err, ffi.ErrInvalidInput) {
+		t.Errorf("Mine() without matcher error = %v, want %v", err, ffi.ErrInvalidInput)
+	}
+}
@@ -0,0 +1,123 @@
+// Package base58 provides Bitcoin-alphabet Base58 and Base58Check encoding.
+//
+// Base58Check appends the first four bytes of the double SHA-256 of the
+// payload as a checksum, and is used by Bitcoin addresses, extended keys
+// and various DID methods.
+package base58
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/sha256"
+)
+
+// Alphabet is the Bitcoin Base58 alphabet.
+const Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// ChecksumSize is the length of the Base58Check checksum in bytes.
+const ChecksumSize = 4
+
+// Errors
+var (
+	ErrInvalidChar     = errors.New("base58: invalid character")
+	ErrTooShort        = errors.New("base58: input too short for checksum")
+	ErrInvalidChecksum = errors.New("base58: invalid checksum")
+)
+
+var decodeMap = func() [256]int8 {
+	var m [256]int8
+	for i := range m {
+		m[i] = -1
+	}
+	for i := 0; i < len(Alphabet); i++ {
+		m[Alphabet[i]] = int8(i)
+	}
+	return m
+}()
+
+var radix = big.NewInt(58)
+
+// Encode encodes data as Base58. Each leading zero byte becomes a '1'.
+func Encode(data []byte) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	n := new(big.Int).SetBytes(data[zeros:])
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, Alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		out = append(out, Alphabet[0])
+	}
+
+	// Digits were produced least significant first
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// Decode decodes a Base58 string. Each leading '1' becomes a zero byte.
+func Decode(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == Alphabet[0] {
+		zeros++
+	}
+
+	n := new(big.Int)
+	for i := zeros; i < len(s); i++ {
+		d := decodeMap[s[i]]
+		if d < 0 {
+			return nil, ErrInvalidChar
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+
+	out := make([]byte, zeros, zeros+len(s))
+	return append(out, n.Bytes()...), nil
+}
+
+// CheckEncode encodes version || payload with a Base58Check checksum.
+func CheckEncode(version byte, payload []byte) string {
+	data := make([]byte, 0, 1+len(payload)+ChecksumSize)
+	data = append(data, version)
+	data = append(data, payload...)
+	sum := checksum(data)
+	return Encode(append(data, sum[:]...))
+}
+
+// CheckDecode decodes a Base58Check string and verifies its checksum,
+// returning the version byte and payload.
+func CheckDecode(s string) (version byte, payload []byte, err error) {
+	data, err := Decode(s)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) < 1+ChecksumSize {
+		return 0, nil, ErrTooShort
+	}
+
+	body := data[:len(data)-ChecksumSize]
+	sum := checksum(body)
+	if !bytes.Equal(sum[:], data[len(body):]) {
+		return 0, nil, ErrInvalidChecksum
+	}
+	return body[0], body[1:], nil
+}
+
+func checksum(data []byte) [ChecksumSize]byte {
+	first := sha256.Hash(data)
+	second := sha256.Hash(first[:])
+
+	var sum [ChecksumSize]byte
+	copy(sum[:], second[:ChecksumSize])
+	return sum
+}
@@ -0,0 +1,85 @@
+package base58
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want string
+	}{
+		{"", ""},
+		{"00", "1"},
+		{"0000", "11"},
+		{"61", "2g"},
+		{"48656c6c6f20576f726c6421", "2NEpo7TZRRrLZSi2U"},
+		{"0000287fb4cd", "11233QC4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			data, _ := hex.DecodeString(tt.hex)
+			if got := Encode(data); got != tt.want {
+				t.Errorf("Encode(%s) = %s, want %s", tt.hex, got, tt.want)
+			}
+			got, err := Decode(tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Errorf("Decode(%s) = %x, want %s", tt.want, got, tt.hex)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	// 0, O, I and l are not in the alphabet
+	for _, s := range []string{"0", "O", "I", "l", "2g+"} {
+		if _, err := Decode(s); !errors.Is(err, ErrInvalidChar) {
+			t.Errorf("Decode(%q) error = %v, want ErrInvalidChar", s, err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	// Genesis block coinbase address
+	payload, _ := hex.DecodeString("62e907b15cbf27d5425399ebf6f0fb50ebb88f18")
+	const addr = "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+
+	if got := CheckEncode(0x00, payload); got != addr {
+		t.Errorf("CheckEncode() = %s, want %s", got, addr)
+	}
+
+	version, got, err := CheckDecode(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 0x00 || !bytes.Equal(got, payload) {
+		t.Errorf("CheckDecode() = %#x, %x", version, got)
+	}
+}
+
+func TestCheckDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"bad checksum", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", ErrInvalidChecksum},
+		{"too short", "1111", ErrTooShort},
+		{"invalid char", "1A1zP1eP5QGefi2DMPTfTL5SLmv7Divf0a", ErrInvalidChar},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := CheckDecode(tt.input); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package base58
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzDecode(f *testing.F) {
+	f.Add("1111")
+	f.Add("3yZe7d")
+	f.Fuzz(func(t *testing.T, s string) {
+		b, err := Decode(s)
+		if err != nil {
+			return
+		}
+		if back, err := Decode(Encode(b)); err != nil || !bytes.Equal(back, b) {
+			t.Errorf("round trip of %q = %x, %v", s, back, err)
+		}
+		CheckDecode(s)
+	})
+}
@@ -0,0 +1,45 @@
+// Package base64 provides standard and URL-safe Base64 encoding.
+//
+// The URL-safe variant (RFC 4648 section 5) is used by JWTs, JWKs and
+// DID documents. It is emitted without padding; decoding accepts input
+// with or without padding.
+package base64
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// Errors
+var (
+	ErrInvalidInput = errors.New("base64: invalid input")
+)
+
+// Encode encodes data as padded standard Base64.
+func Encode(data []byte) string {
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// Decode decodes padded or unpadded standard Base64.
+func Decode(s string) ([]byte, error) {
+	return decode(base64.RawStdEncoding, s)
+}
+
+// EncodeURL encodes data as unpadded URL-safe Base64.
+func EncodeURL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeURL decodes padded or unpadded URL-safe Base64.
+func DecodeURL(s string) ([]byte, error) {
+	return decode(base64.RawURLEncoding, s)
+}
+
+func decode(enc *base64.Encoding, s string) ([]byte, error) {
+	b, err := enc.Strict().DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, ErrInvalidInput
+	}
+	return b, nil
+}
@@ -0,0 +1,68 @@
+package base64
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestURL(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{[]byte{}, ""},
+		{[]byte("f"), "Zg"},
+		{[]byte("foob"), "Zm9vYg"},
+		{[]byte{0xfb, 0xff, 0xbf}, "-_-_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := EncodeURL(tt.data); got != tt.want {
+				t.Errorf("EncodeURL() = %s, want %s", got, tt.want)
+			}
+			got, err := DecodeURL(tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tt.data) {
+				t.Errorf("DecodeURL() = %x, want %x", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestStd(t *testing.T) {
+	if got := Encode([]byte{0xfb, 0xff, 0xbf, 0x66}); got != "+/+/Zg==" {
+		t.Errorf("Encode() = %s", got)
+	}
+	for _, s := range []string{"+/+/Zg==", "+/+/Zg"} {
+		got, err := Decode(s)
+		if err != nil || !bytes.Equal(got, []byte{0xfb, 0xff, 0xbf, 0x66}) {
+			t.Errorf("Decode(%s) = %x, %v", s, got, err)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(string) ([]byte, error)
+		in   string
+	}{
+		{"std alphabet in url", DecodeURL, "+/+/"},
+		{"url alphabet in std", Decode, "-_-_"},
+		{"bad length", DecodeURL, "Z"},
+		// Trailing bits must be zero
+		{"non-canonical", DecodeURL, "Zh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.f(tt.in); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
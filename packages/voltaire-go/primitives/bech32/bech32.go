@@ -0,0 +1,286 @@
+// Package bech32 provides Bech32 (BIP-173) and Bech32m (BIP-350) encoding.
+//
+// Encode and Decode work on 8-bit data, converting to and from the 5-bit
+// groups carried in the string. SegWit addresses, whose witness version
+// is a bare 5-bit value, have dedicated helpers.
+package bech32
+
+import (
+	"errors"
+	"strings"
+)
+
+// Encoding selects the checksum constant.
+type Encoding int
+
+const (
+	// Bech32 is the original BIP-173 checksum.
+	Bech32 Encoding = iota + 1
+	// Bech32m is the BIP-350 checksum, used for SegWit v1+ addresses.
+	Bech32m
+)
+
+// MaxLength is the maximum length of an encoded string.
+const MaxLength = 90
+
+const (
+	charset       = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	checksumSize  = 6
+	bech32Const   = 1
+	bech32mConst  = 0x2bc830a3
+	maxWitnessVer = 16
+)
+
+// Errors
+var (
+	ErrInvalidLength   = errors.New("bech32: invalid length")
+	ErrMixedCase       = errors.New("bech32: mixed case")
+	ErrInvalidChar     = errors.New("bech32: invalid character")
+	ErrInvalidHRP      = errors.New("bech32: invalid human-readable part")
+	ErrNoSeparator     = errors.New("bech32: missing separator")
+	ErrInvalidChecksum = errors.New("bech32: invalid checksum")
+	ErrInvalidPadding  = errors.New("bech32: invalid padding")
+	ErrInvalidEncoding = errors.New("bech32: invalid encoding")
+	ErrInvalidWitness  = errors.New("bech32: invalid witness program")
+)
+
+var charsetRev = func() [128]int8 {
+	var m [128]int8
+	for i := range m {
+		m[i] = -1
+	}
+	for i := 0; i < len(charset); i++ {
+		m[charset[i]] = int8(i)
+	}
+	return m
+}()
+
+// Encode encodes 8-bit data under the human-readable part hrp.
+func Encode(hrp string, data []byte, enc Encoding) (string, error) {
+	values, err := ConvertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return encode(hrp, values, enc)
+}
+
+// Decode decodes a Bech32 or Bech32m string, returning the lowercase
+// human-readable part, the 8-bit data and the checksum variant.
+func Decode(s string) (hrp string, data []byte, enc Encoding, err error) {
+	hrp, values, enc, err := decode(s)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	data, err = ConvertBits(values, 5, 8, false)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	return hrp, data, enc, nil
+}
+
+// EncodeSegwit encodes a SegWit address (BIP-173, BIP-350). Version 0
+// uses Bech32; versions 1 to 16 use Bech32m.
+func EncodeSegwit(hrp string, version byte, program []byte) (string, error) {
+	if err := checkWitness(version, program); err != nil {
+		return "", err
+	}
+	enc := Bech32m
+	if version == 0 {
+		enc = Bech32
+	}
+
+	values, err := ConvertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return encode(hrp, append([]byte{version}, values...), enc)
+}
+
+// DecodeSegwit decodes a SegWit address for the expected hrp, returning
+// the witness version and program. The checksum variant must match the
+// version.
+func DecodeSegwit(expectedHRP, s string) (version byte, program []byte, err error) {
+	hrp, values, enc, err := decode(s)
+	if err != nil {
+		return 0, nil, err
+	}
+	if hrp != strings.ToLower(expectedHRP) {
+		return 0, nil, ErrInvalidHRP
+	}
+	if len(values) == 0 {
+		return 0, nil, ErrInvalidWitness
+	}
+
+	version = values[0]
+	if (version == 0) != (enc == Bech32) {
+		return 0, nil, ErrInvalidEncoding
+	}
+	program, err = ConvertBits(values[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := checkWitness(version, program); err != nil {
+		return 0, nil, err
+	}
+	return version, program, nil
+}
+
+// ConvertBits regroups data from fromBits-bit to toBits-bit values. When
+// pad is true the final group is zero-padded; otherwise leftover bits must
+// be zero and fewer than fromBits.
+func ConvertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1)<<toBits - 1
+	out := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+
+	for _, b := range data {
+		if uint32(b)>>fromBits != 0 {
+			return nil, ErrInvalidChar
+		}
+		acc = acc<<fromBits | uint32(b)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, ErrInvalidPadding
+	}
+	return out, nil
+}
+
+func encode(hrp string, values []byte, enc Encoding) (string, error) {
+	if err := checkHRP(hrp); err != nil {
+		return "", err
+	}
+	if len(hrp)+1+len(values)+checksumSize > MaxLength {
+		return "", ErrInvalidLength
+	}
+	c, err := constant(enc)
+	if err != nil {
+		return "", err
+	}
+
+	hrp = strings.ToLower(hrp)
+	var sb strings.Builder
+	sb.Grow(len(hrp) + 1 + len(values) + checksumSize)
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range values {
+		sb.WriteByte(charset[v])
+	}
+
+	mod := polymod(append(hrpExpand(hrp), append(values, make([]byte, checksumSize)...)...)) ^ c
+	for i := 0; i < checksumSize; i++ {
+		sb.WriteByte(charset[mod>>(5*(5-i))&31])
+	}
+	return sb.String(), nil
+}
+
+func decode(s string) (string, []byte, Encoding, error) {
+	if len(s) > MaxLength {
+		return "", nil, 0, ErrInvalidLength
+	}
+	lower := strings.ToLower(s)
+	if lower != s && strings.ToUpper(s) != s {
+		return "", nil, 0, ErrMixedCase
+	}
+
+	pos := strings.LastIndexByte(lower, '1')
+	if pos < 0 {
+		return "", nil, 0, ErrNoSeparator
+	}
+	hrp := lower[:pos]
+	if err := checkHRP(hrp); err != nil {
+		return "", nil, 0, err
+	}
+	if len(lower)-pos-1 < checksumSize {
+		return "", nil, 0, ErrInvalidLength
+	}
+
+	values := make([]byte, 0, len(lower)-pos-1)
+	for i := pos + 1; i < len(lower); i++ {
+		c := lower[i]
+		if c >= 128 || charsetRev[c] < 0 {
+			return "", nil, 0, ErrInvalidChar
+		}
+		values = append(values, byte(charsetRev[c]))
+	}
+
+	var enc Encoding
+	switch polymod(append(hrpExpand(hrp), values...)) {
+	case bech32Const:
+		enc = Bech32
+	case bech32mConst:
+		enc = Bech32m
+	default:
+		return "", nil, 0, ErrInvalidChecksum
+	}
+	return hrp, values[:len(values)-checksumSize], enc, nil
+}
+
+func checkHRP(hrp string) error {
+	if len(hrp) == 0 || len(hrp) > MaxLength-1-checksumSize {
+		return ErrInvalidHRP
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return ErrInvalidHRP
+		}
+	}
+	return nil
+}
+
+func checkWitness(version byte, program []byte) error {
+	if version > maxWitnessVer || len(program) < 2 || len(program) > 40 {
+		return ErrInvalidWitness
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return ErrInvalidWitness
+	}
+	return nil
+}
+
+func constant(enc Encoding) (uint32, error) {
+	switch enc {
+	case Bech32:
+		return bech32Const, nil
+	case Bech32m:
+		return bech32mConst, nil
+	}
+	return 0, ErrInvalidEncoding
+}
+
+func polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if top>>i&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func hrpExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
@@ -0,0 +1,157 @@
+package bech32
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidChecksums(t *testing.T) {
+	tests := []struct {
+		input string
+		enc   Encoding
+	}{
+		// BIP-173
+		{"A12UEL5L", Bech32},
+		{"a12uel5l", Bech32},
+		{"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", Bech32},
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", Bech32},
+		// BIP-350
+		{"A1LQFN3A", Bech32m},
+		{"a1lqfn3a", Bech32m},
+		{"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", Bech32m},
+		{"split1checkupstagehandshakeupstreamerranterredcaperredlc445v", Bech32m},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			hrp, values, enc, err := decode(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if enc != tt.enc {
+				t.Errorf("encoding = %d, want %d", enc, tt.enc)
+			}
+			got, err := encode(hrp, values, enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != strings.ToLower(tt.input) {
+				t.Errorf("re-encoded = %s, want %s", got, strings.ToLower(tt.input))
+			}
+		})
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"no separator", "pzry9x0s0muk", ErrNoSeparator},
+		{"empty hrp", "1pzry9x0s0muk", ErrInvalidHRP},
+		{"invalid data char", "x1b4n0q5v", ErrInvalidChar},
+		{"checksum too short", "li1dgmt3", ErrInvalidLength},
+		{"mixed case", "A1LQfN3A", ErrMixedCase},
+		{"bad checksum", "a12uel5m", ErrInvalidChecksum},
+		{"too long", "an84characterslonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11d6pts4", ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, err := Decode(tt.input); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	data, _ := hex.DecodeString("751e76e8199196d454941c45d1b3a323f1433bd6")
+
+	for _, enc := range []Encoding{Bech32, Bech32m} {
+		s, err := Encode("cosmos", data, enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hrp, got, gotEnc, err := Decode(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hrp != "cosmos" || gotEnc != enc || !bytes.Equal(got, data) {
+			t.Errorf("round trip of %s = %s, %x, %d", s, hrp, got, gotEnc)
+		}
+	}
+
+	if _, err := Encode("a", nil, 0); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("unknown encoding error = %v", err)
+	}
+}
+
+func TestSegwit(t *testing.T) {
+	tests := []struct {
+		addr    string
+		version byte
+		program string
+	}{
+		// BIP-173 P2WPKH
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", 0, "751e76e8199196d454941c45d1b3a323f1433bd6"},
+		// BIP-350 P2TR
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", 1, "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			program, _ := hex.DecodeString(tt.program)
+			got, err := EncodeSegwit("bc", tt.version, program)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.addr {
+				t.Errorf("EncodeSegwit() = %s, want %s", got, tt.addr)
+			}
+
+			version, gotProgram, err := DecodeSegwit("bc", tt.addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if version != tt.version || !bytes.Equal(gotProgram, program) {
+				t.Errorf("DecodeSegwit() = %d, %x", version, gotProgram)
+			}
+		})
+	}
+}
+
+func TestSegwitInvalid(t *testing.T) {
+	program, _ := hex.DecodeString("751e76e8199196d454941c45d1b3a323f1433bd6")
+	values, _ := ConvertBits(program, 8, 5, true)
+	v0m, _ := encode("bc", append([]byte{0}, values...), Bech32m)
+	v1, _ := encode("bc", append([]byte{1}, values...), Bech32)
+	testnet, _ := EncodeSegwit("tb", 0, program)
+
+	tests := []struct {
+		name    string
+		addr    string
+		wantErr error
+	}{
+		// BIP-350: v0 must use Bech32, v1+ must use Bech32m
+		{"v0 bech32m", v0m, ErrInvalidEncoding},
+		{"v1 bech32", v1, ErrInvalidEncoding},
+		{"wrong hrp", testnet, ErrInvalidHRP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := DecodeSegwit("bc", tt.addr); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := EncodeSegwit("bc", 0, make([]byte, 21)); !errors.Is(err, ErrInvalidWitness) {
+		t.Errorf("v0 with 21-byte program error = %v", err)
+	}
+}
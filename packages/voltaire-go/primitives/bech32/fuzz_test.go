@@ -0,0 +1,12 @@
+package bech32
+
+import "testing"
+
+func FuzzDecode(f *testing.F) {
+	f.Add("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4")
+	f.Add("a12uel5l")
+	f.Fuzz(func(t *testing.T, s string) {
+		Decode(s)
+		DecodeSegwit("bc", s)
+	})
+}
@@ -0,0 +1,225 @@
+// Package blob provides EIP-4844 blobs, KZG commitments and versioned
+// hashes.
+//
+// A blob is 4096 field elements of the BLS12-381 scalar field, each
+// serialized as 32 big-endian bytes. Blob transactions reference blobs by
+// versioned hash: a version byte followed by the last 31 bytes of the
+// SHA-256 hash of the blob's KZG commitment.
+package blob
+
+import (
+	"bytes"
+	"errors"
+	"os"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/sha256"
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/hex"
+)
+
+// Sizes
+const (
+	FieldElementsPerBlob = 4096
+	BytesPerFieldElement = 32
+	Size                 = FieldElementsPerBlob * BytesPerFieldElement // 131072
+	CommitmentSize       = 48
+	ProofSize            = 48
+)
+
+// VersionKZG is the versioned hash version for KZG commitments.
+const VersionKZG = 0x01
+
+// Errors
+var (
+	ErrInvalidLength       = errors.New("blob: invalid length")
+	ErrInvalidFieldElement = errors.New("blob: field element not in the BLS12-381 scalar field")
+	ErrInvalidVersion      = errors.New("blob: unsupported versioned hash version")
+)
+
+// blsModulus is the BLS12-381 scalar field modulus, big-endian.
+var blsModulus = [BytesPerFieldElement]byte{
+	0x73, 0xed, 0xa7, 0x53, 0x29, 0x9d, 0x7d, 0x48,
+	0x33, 0x39, 0xd8, 0x08, 0x09, 0xa1, 0xd8, 0x05,
+	0x53, 0xbd, 0xa4, 0x02, 0xff, 0xfe, 0x5b, 0xfe,
+	0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x01,
+}
+
+// Blob is the data carried by a blob transaction.
+type Blob [Size]byte
+
+// Commitment is a KZG commitment to a blob: a compressed G1 point.
+type Commitment [CommitmentSize]byte
+
+// Proof is a KZG proof: a compressed G1 point.
+type Proof [ProofSize]byte
+
+// FromBytes creates a Blob from a byte slice of exactly Size bytes.
+func FromBytes(b []byte) (*Blob, error) {
+	if len(b) != Size {
+		return nil, ErrInvalidLength
+	}
+	var blob Blob
+	copy(blob[:], b)
+	return &blob, nil
+}
+
+// FromHex creates a Blob from 0x-prefixed hex.
+func FromHex(s string) (*Blob, error) {
+	b, err := hex.DecodeData(s)
+	if err != nil {
+		return nil, err
+	}
+	return FromBytes(b)
+}
+
+// Hex returns the 0x-prefixed hex encoding of b.
+func (b Blob) Hex() string {
+	return hex.EncodeData(b[:])
+}
+
+// Validate returns ErrInvalidFieldElement if any field element of b is
+// not below the BLS12-381 scalar field modulus.
+func (b Blob) Validate() error {
+	for i := 0; i < Size; i += BytesPerFieldElement {
+		if bytes.Compare(b[i:i+BytesPerFieldElement], blsModulus[:]) >= 0 {
+			return ErrInvalidFieldElement
+		}
+	}
+	return nil
+}
+
+// LoadTrustedSetup loads a KZG trusted setup from a file in the
+// c-kzg-4844 text format, replacing the one in use. Without a call to a
+// Load function, KZG operations use the mainnet setup embedded in the
+// native library.
+func LoadTrustedSetup(path string) error {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return LoadTrustedSetupText(text)
+}
+
+// LoadTrustedSetupText is like LoadTrustedSetup but takes the file
+// contents, for setups embedded in the program.
+//
+// The setup in use is released before the new one is parsed, so a
+// malformed setup leaves none: KZG operations then fail with
+// voltaire.ErrKZGNotLoaded until another setup loads or FreeTrustedSetup is
+// called, rather than silently switching to the mainnet setup.
+func LoadTrustedSetupText(text []byte) error {
+	return ffi.KZGLoadTrustedSetup(text)
+}
+
+// LoadDefaultTrustedSetup loads the mainnet setup embedded in the native
+// library, replacing the one in use.
+func LoadDefaultTrustedSetup() error {
+	return ffi.KZGLoadDefaultTrustedSetup()
+}
+
+// FreeTrustedSetup releases the trusted setup in use, and clears a failed
+// load. A later KZG operation loads the embedded mainnet setup again.
+func FreeTrustedSetup() error {
+	return ffi.KZGFreeTrustedSetup()
+}
+
+// ToCommitment computes the KZG commitment to b using the loaded trusted
+// setup.
+func (b *Blob) ToCommitment() (Commitment, error) {
+	c, err := ffi.KZGBlobToCommitment((*[ffi.KZGBlobSize]byte)(b))
+	if err != nil {
+		return Commitment{}, err
+	}
+	return Commitment(c), nil
+}
+
+// ComputeProof evaluates the blob polynomial at the field element z. It
+// returns the evaluation y and a proof that p(z) = y.
+func (b *Blob) ComputeProof(z [BytesPerFieldElement]byte) (Proof, [BytesPerFieldElement]byte, error) {
+	proof, y, err := ffi.KZGComputeProof((*[ffi.KZGBlobSize]byte)(b), z)
+	if err != nil {
+		return Proof{}, [BytesPerFieldElement]byte{}, err
+	}
+	return Proof(proof), y, nil
+}
+
+// VerifyProof reports whether proof shows that the blob committed to by c
+// evaluates to y at z.
+func VerifyProof(c Commitment, z, y [BytesPerFieldElement]byte, proof Proof) (bool, error) {
+	return ffi.KZGVerifyProof(c, z, y, proof)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b Blob) MarshalText() ([]byte, error) {
+	return []byte(b.Hex()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *Blob) UnmarshalText(text []byte) error {
+	blob, err := FromHex(string(text))
+	if err != nil {
+		return err
+	}
+	*b = *blob
+	return nil
+}
+
+// Hex returns the 0x-prefixed hex encoding of c.
+func (c Commitment) Hex() string {
+	return hex.EncodeData(c[:])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Commitment) MarshalText() ([]byte, error) {
+	return []byte(c.Hex()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Commitment) UnmarshalText(text []byte) error {
+	return unmarshalFixed(c[:], text)
+}
+
+// VersionedHash returns the versioned hash of c:
+// VersionKZG || sha256(c)[1:].
+func (c Commitment) VersionedHash() hash.Hash {
+	h := sha256.Hash(c[:])
+	h[0] = VersionKZG
+	return h
+}
+
+// Hex returns the 0x-prefixed hex encoding of p.
+func (p Proof) Hex() string {
+	return hex.EncodeData(p[:])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p Proof) MarshalText() ([]byte, error) {
+	return []byte(p.Hex()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *Proof) UnmarshalText(text []byte) error {
+	return unmarshalFixed(p[:], text)
+}
+
+// ValidateVersionedHash returns ErrInvalidVersion unless h carries the
+// KZG version prefix.
+func ValidateVersionedHash(h hash.Hash) error {
+	if h[0] != VersionKZG {
+		return ErrInvalidVersion
+	}
+	return nil
+}
+
+func unmarshalFixed(dst []byte, text []byte) error {
+	b, err := hex.DecodeData(string(text))
+	if err != nil {
+		return err
+	}
+	if len(b) != len(dst) {
+		return ErrInvalidLength
+	}
+	copy(dst, b)
+	return nil
+}
@@ -0,0 +1,140 @@
+package blob
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+// infinity is the commitment to the zero blob: the compressed G1 point at
+// infinity.
+var infinity = Commitment{0: 0xc0}
+
+func TestVersionedHash(t *testing.T) {
+	const want = "0x010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014"
+	h := infinity.VersionedHash()
+	if got := h.Hex(); got != want {
+		t.Errorf("VersionedHash() = %s, want %s", got, want)
+	}
+	if err := ValidateVersionedHash(h); err != nil {
+		t.Errorf("ValidateVersionedHash() = %v", err)
+	}
+
+	h[0] = 0x02
+	if err := ValidateVersionedHash(h); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("ValidateVersionedHash() = %v, want %v", err, ErrInvalidVersion)
+	}
+	if err := ValidateVersionedHash(hash.Zero); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("ValidateVersionedHash(zero) = %v, want %v", err, ErrInvalidVersion)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var b Blob
+	if err := b.Validate(); err != nil {
+		t.Errorf("zero blob: %v", err)
+	}
+
+	// Modulus - 1 is the largest valid field element
+	copy(b[Size-BytesPerFieldElement:], blsModulus[:])
+	b[Size-1] = 0x00
+	if err := b.Validate(); err != nil {
+		t.Errorf("modulus - 1: %v", err)
+	}
+
+	b[Size-1] = 0x01
+	if err := b.Validate(); !errors.Is(err, ErrInvalidFieldElement) {
+		t.Errorf("modulus: %v, want %v", err, ErrInvalidFieldElement)
+	}
+}
+
+func TestHexJSON(t *testing.T) {
+	var b Blob
+	b[0], b[Size-1] = 0x12, 0x34
+
+	s := b.Hex()
+	if len(s) != 2+2*Size || !strings.HasPrefix(s, "0x12") || !strings.HasSuffix(s, "34") {
+		t.Errorf("Hex() = %s...", s[:8])
+	}
+	decoded, err := FromHex(s)
+	if err != nil || *decoded != b {
+		t.Errorf("FromHex round trip failed: %v", err)
+	}
+
+	type sidecar struct {
+		Blob       Blob       `json:"blob"`
+		Commitment Commitment `json:"commitment"`
+		Proof      Proof      `json:"proof"`
+	}
+	in := sidecar{Blob: b, Commitment: infinity, Proof: Proof{0: 0xc0}}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out sidecar
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Error("JSON round trip mismatch")
+	}
+
+	if _, err := FromHex("0x1234"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("short blob: %v, want %v", err, ErrInvalidLength)
+	}
+	var c Commitment
+	if err := c.UnmarshalText([]byte("0xc0")); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("short commitment: %v, want %v", err, ErrInvalidLength)
+	}
+}
+
+// mainnetSetup is the c-kzg-4844 copy of the mainnet trusted setup.
+const mainnetSetup = "../../../voltaire-zig/lib/c-kzg-4844/src/trusted_setup.txt"
+
+func TestTrustedSetup(t *testing.T) {
+	if err := LoadTrustedSetup("testdata/missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing file: %v, want %v", err, fs.ErrNotExist)
+	}
+	if err := LoadTrustedSetupText([]byte("4096\n65\nnot a point\n")); err == nil {
+		t.Error("malformed setup loaded")
+	}
+
+	// A failed load leaves no setup; operations report it instead of
+	// quietly using the mainnet setup, until the setup is freed
+	if _, err := new(Blob).ToCommitment(); !errors.Is(err, ffi.ErrKZGNotLoaded) {
+		t.Errorf("ToCommitment() after failed load error = %v, want %v", err, ffi.ErrKZGNotLoaded)
+	}
+	if err := FreeTrustedSetup(); err != nil {
+		t.Fatal(err)
+	}
+	if c, err := new(Blob).ToCommitment(); err != nil || c != infinity {
+		t.Errorf("ToCommitment() after free = %s, %v", c.Hex(), err)
+	}
+
+	if _, err := os.Stat(mainnetSetup); err != nil {
+		t.Skip("trusted setup file not available")
+	}
+	if err := LoadTrustedSetup(mainnetSetup); err != nil {
+		t.Fatal(err)
+	}
+	if c, err := new(Blob).ToCommitment(); err != nil || c != infinity {
+		t.Errorf("ToCommitment() = %s, %v", c.Hex(), err)
+	}
+
+	// Freeing falls back to the embedded setup on next use
+	if err := FreeTrustedSetup(); err != nil {
+		t.Fatal(err)
+	}
+	if c, err := new(Blob).ToCommitment(); err != nil || c != infinity {
+		t.Errorf("ToCommitment() after free = %s, %v", c.Hex(), err)
+	}
+	if err := LoadDefaultTrustedSetup(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,282 @@
+// Package blockheader provides the Ethereum block header with RLP encoding
+// and hashing.
+//
+// Fields introduced by later hardforks are pointers; nil means the field is
+// absent from the encoding. They are encoded in order and encoding stops
+// at the first nil field, so a header that sets a later field must also set
+// every earlier one.
+package blockheader
+
+import (
+	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/receipt"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+	"github.com/voltaire-labs/voltaire-go/primitives/trie"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// NonceSize is the size of the proof-of-work nonce in bytes.
+const NonceSize = 8
+
+// Well-known roots.
+var (
+	// EmptyOmmersHash is keccak256(rlp([])), the ommers hash of a block
+	// without ommers.
+	EmptyOmmersHash = hash.Hash{
+		0x1d, 0xcc, 0x4d, 0xe8, 0xde, 0xc7, 0x5d, 0x7a, 0xab, 0x85, 0xb5, 0x67, 0xb6, 0xcc, 0xd4, 0x1a,
+		0xd3, 0x12, 0x45, 0x1b, 0x94, 0x8a, 0x74, 0x13, 0xf0, 0xa1, 0x42, 0xfd, 0x40, 0xd4, 0x93, 0x47,
+	}
+	// EmptyRootHash is keccak256(rlp("")), the root of an empty trie.
+	EmptyRootHash = trie.EmptyRoot
+)
+
+// Errors
+var (
+	ErrNotList         = errors.New("blockheader: RLP is not a list")
+	ErrFieldCount      = errors.New("blockheader: unexpected number of fields")
+	ErrInvalidField    = errors.New("blockheader: invalid field")
+	ErrNonCanonicalInt = errors.New("blockheader: non-canonical integer")
+)
+
+const (
+	legacyFields = 15
+	maxFields    = 21
+)
+
+// Header is an Ethereum block header.
+type Header struct {
+	ParentHash       hash.Hash
+	OmmersHash       hash.Hash
+	Beneficiary      address.Address
+	StateRoot        hash.Hash
+	TransactionsRoot hash.Hash
+	ReceiptsRoot     hash.Hash
+	LogsBloom        receipt.Bloom
+	Difficulty       u256.U256
+	Number           uint64
+	GasLimit         uint64
+	GasUsed          uint64
+	Timestamp        uint64
+	ExtraData        []byte
+	MixHash          hash.Hash
+	Nonce            [NonceSize]byte
+
+	// London (EIP-1559)
+	BaseFeePerGas *u256.U256
+	// Shanghai (EIP-4895)
+	WithdrawalsRoot *hash.Hash
+	// Cancun (EIP-4844, EIP-4788)
+	BlobGasUsed           *uint64
+	ExcessBlobGas         *uint64
+	ParentBeaconBlockRoot *hash.Hash
+	// Prague (EIP-7685)
+	RequestsHash *hash.Hash
+}
+
+// EncodeRLP returns the RLP encoding of the header.
+func (h Header) EncodeRLP() []byte {
+	items := []interface{}{
+		h.ParentHash[:],
+		h.OmmersHash[:],
+		h.Beneficiary[:],
+		h.StateRoot[:],
+		h.TransactionsRoot[:],
+		h.ReceiptsRoot[:],
+		h.LogsBloom[:],
+		uintBytes(h.Difficulty),
+		h.Number,
+		h.GasLimit,
+		h.GasUsed,
+		h.Timestamp,
+		h.ExtraData,
+		h.MixHash[:],
+		h.Nonce[:],
+	}
+
+	for _, field := range h.optionalFields() {
+		if field == nil {
+			break
+		}
+		items = append(items, field)
+	}
+
+	// Cannot fail: all items are supported types
+	encoded, _ := rlp.EncodeList(items)
+	return encoded
+}
+
+// Hash returns the block hash: keccak256 of the RLP-encoded header.
+func (h Header) Hash() hash.Hash {
+	return keccak256.Hash(h.EncodeRLP())
+}
+
+// DecodeRLP decodes an RLP-encoded header.
+func DecodeRLP(data []byte) (Header, error) {
+	decoded, err := rlp.DecodeBytes(data)
+	if err != nil {
+		return Header{}, err
+	}
+	fields, ok := decoded.([]interface{})
+	if !ok {
+		return Header{}, ErrNotList
+	}
+	if len(fields) < legacyFields || len(fields) > maxFields {
+		return Header{}, ErrFieldCount
+	}
+
+	d := decoder{fields: fields}
+	var h Header
+	d.fixed(h.ParentHash[:])
+	d.fixed(h.OmmersHash[:])
+	d.fixed(h.Beneficiary[:])
+	d.fixed(h.StateRoot[:])
+	d.fixed(h.TransactionsRoot[:])
+	d.fixed(h.ReceiptsRoot[:])
+	d.fixed(h.LogsBloom[:])
+	h.Difficulty = d.u256()
+	h.Number = d.uint64()
+	h.GasLimit = d.uint64()
+	h.GasUsed = d.uint64()
+	h.Timestamp = d.uint64()
+	h.ExtraData = d.bytes()
+	d.fixed(h.MixHash[:])
+	d.fixed(h.Nonce[:])
+
+	if d.more() {
+		v := d.u256()
+		h.BaseFeePerGas = &v
+	}
+	if d.more() {
+		h.WithdrawalsRoot = d.hash()
+	}
+	if d.more() {
+		v := d.uint64()
+		h.BlobGasUsed = &v
+	}
+	if d.more() {
+		v := d.uint64()
+		h.ExcessBlobGas = &v
+	}
+	if d.more() {
+		h.ParentBeaconBlockRoot = d.hash()
+	}
+	if d.more() {
+		h.RequestsHash = d.hash()
+	}
+
+	if d.err != nil {
+		return Header{}, d.err
+	}
+	return h, nil
+}
+
+// optionalFields returns the encodings of the hardfork fields in order,
+// with nil for absent fields.
+func (h Header) optionalFields() []interface{} {
+	fields := make([]interface{}, 6)
+	if h.BaseFeePerGas != nil {
+		fields[0] = uintBytes(*h.BaseFeePerGas)
+	}
+	if h.WithdrawalsRoot != nil {
+		fields[1] = h.WithdrawalsRoot[:]
+	}
+	if h.BlobGasUsed != nil {
+		fields[2] = *h.BlobGasUsed
+	}
+	if h.ExcessBlobGas != nil {
+		fields[3] = *h.ExcessBlobGas
+	}
+	if h.ParentBeaconBlockRoot != nil {
+		fields[4] = h.ParentBeaconBlockRoot[:]
+	}
+	if h.RequestsHash != nil {
+		fields[5] = h.RequestsHash[:]
+	}
+	return fields
+}
+
+// uintBytes returns the minimal big-endian encoding of v (empty for zero).
+func uintBytes(v u256.U256) []byte {
+	if v.IsZero() {
+		return []byte{}
+	}
+	return v.TrimmedBytes()
+}
+
+// decoder reads header fields in order, keeping the first error.
+type decoder struct {
+	fields []interface{}
+	pos    int
+	err    error
+}
+
+func (d *decoder) more() bool {
+	return d.pos < len(d.fields)
+}
+
+func (d *decoder) next() []byte {
+	if d.err != nil {
+		return nil
+	}
+	b, ok := d.fields[d.pos].([]byte)
+	d.pos++
+	if !ok {
+		d.err = ErrInvalidField
+	}
+	return b
+}
+
+func (d *decoder) bytes() []byte {
+	return d.next()
+}
+
+func (d *decoder) fixed(dst []byte) {
+	b := d.next()
+	if d.err == nil && len(b) != len(dst) {
+		d.err = ErrInvalidField
+	}
+	copy(dst, b)
+}
+
+func (d *decoder) hash() *hash.Hash {
+	var h hash.Hash
+	d.fixed(h[:])
+	return &h
+}
+
+func (d *decoder) uint64() uint64 {
+	b := d.next()
+	if d.err != nil {
+		return 0
+	}
+	v, err := rlp.Uint64FromBytes(b)
+	switch err {
+	case nil:
+	case rlp.ErrNonCanonical:
+		d.err = ErrNonCanonicalInt
+	default:
+		d.err = ErrInvalidField
+	}
+	return v
+}
+
+func (d *decoder) u256() u256.U256 {
+	b := d.next()
+	if d.err != nil {
+		return u256.U256{}
+	}
+	if len(b) > u256.Size {
+		d.err = ErrInvalidField
+		return u256.U256{}
+	}
+	if len(b) > 0 && b[0] == 0 {
+		d.err = ErrNonCanonicalInt
+		return u256.U256{}
+	}
+	v, _ := u256.FromBytes(b)
+	return v
+}
@@ -0,0 +1,167 @@
+package blockheader
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/receipt"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// Mainnet genesis header
+const genesisRLP = "f90214a00000000000000000000000000000000000000000000000000000000000000000" +
+	"a01dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347" +
+	"940000000000000000000000000000000000000000" +
+	"a0d7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544" +
+	"a056e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421" +
+	"a056e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421" +
+	"b90100" + "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000" +
+	"00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000" +
+	"00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000" +
+	"00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000" +
+	"850400000000808213888080" +
+	"a011bbe8db4e347b4e8c937c1c8370e4b5ed33adb3db69cbdb7a38e1e50b1b82fa" +
+	"a00000000000000000000000000000000000000000000000000000000000000000" +
+	"880000000000000042"
+
+const genesisHash = "0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+
+func genesis() Header {
+	return Header{
+		OmmersHash:       EmptyOmmersHash,
+		StateRoot:        hash.MustFromHex("0xd7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544"),
+		TransactionsRoot: EmptyRootHash,
+		ReceiptsRoot:     EmptyRootHash,
+		Difficulty:       u256.FromUint64(17179869184),
+		GasLimit:         5000,
+		ExtraData:        hash.MustFromHex("0x11bbe8db4e347b4e8c937c1c8370e4b5ed33adb3db69cbdb7a38e1e50b1b82fa").Bytes(),
+		Nonce:            [NonceSize]byte{0, 0, 0, 0, 0, 0, 0, 0x42},
+	}
+}
+
+func TestGenesisHash(t *testing.T) {
+	h := genesis()
+
+	if got := hex.EncodeToString(h.EncodeRLP()); got != genesisRLP {
+		t.Errorf("EncodeRLP() =\n%s\nwant\n%s", got, genesisRLP)
+	}
+	if got := h.Hash(); got != hash.MustFromHex(genesisHash) {
+		t.Errorf("Hash() = %s, want %s", got.Hex(), genesisHash)
+	}
+}
+
+func TestDecodeGenesis(t *testing.T) {
+	data, _ := hex.DecodeString(genesisRLP)
+	h, err := DecodeRLP(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(h, genesis()) {
+		t.Errorf("DecodeRLP() = %+v, want %+v", h, genesis())
+	}
+	if h.BaseFeePerGas != nil || h.WithdrawalsRoot != nil {
+		t.Error("legacy header should have no hardfork fields")
+	}
+}
+
+func TestRoundTripCancun(t *testing.T) {
+	baseFee := u256.FromUint64(7)
+	withdrawals := EmptyRootHash
+	blobGasUsed := uint64(131072)
+	excessBlobGas := uint64(0)
+	beaconRoot := hash.MustFromHex("0x0102030405060708091011121314151617181920212223242526272829303132")
+
+	h := Header{
+		ParentHash:            hash.MustFromHex("0xaa00000000000000000000000000000000000000000000000000000000000001"),
+		OmmersHash:            EmptyOmmersHash,
+		Beneficiary:           address.MustFromHex("0x4838b106fce9647bdf1e7877bf73ce8b0bad5f97"),
+		StateRoot:             EmptyRootHash,
+		TransactionsRoot:      EmptyRootHash,
+		ReceiptsRoot:          EmptyRootHash,
+		Number:                19426587,
+		GasLimit:              30000000,
+		GasUsed:               12345678,
+		Timestamp:             1710338135,
+		ExtraData:             []byte("beaverbuild.org"),
+		BaseFeePerGas:         &baseFee,
+		WithdrawalsRoot:       &withdrawals,
+		BlobGasUsed:           &blobGasUsed,
+		ExcessBlobGas:         &excessBlobGas,
+		ParentBeaconBlockRoot: &beaconRoot,
+	}
+	h.LogsBloom = receipt.CreateBloom([]receipt.Log{{Address: h.Beneficiary}})
+
+	encoded := h.EncodeRLP()
+	decoded, err := DecodeRLP(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, h) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", decoded, h)
+	}
+	if decoded.Hash() != h.Hash() {
+		t.Error("hash changed after round trip")
+	}
+	if !decoded.LogsBloom.Test(h.Beneficiary[:]) {
+		t.Error("decoded bloom does not contain the log address")
+	}
+
+	items, _ := rlp.DecodeBytes(encoded)
+	if n := len(items.([]interface{})); n != 20 {
+		t.Errorf("encoded %d fields, want 20", n)
+	}
+}
+
+func TestEncodeStopsAtFirstNil(t *testing.T) {
+	h := genesis()
+	beaconRoot := hash.Hash{1}
+	h.ParentBeaconBlockRoot = &beaconRoot
+
+	if !bytes.Equal(h.EncodeRLP(), genesis().EncodeRLP()) {
+		t.Error("fields after a missing BaseFeePerGas should not be encoded")
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	valid, _ := hex.DecodeString(genesisRLP)
+	items, _ := rlp.DecodeBytes(valid)
+	fields := items.([]interface{})
+
+	withField := func(i int, v interface{}) []byte {
+		f := append([]interface{}(nil), fields...)
+		f[i] = v
+		b, _ := rlp.EncodeList(f)
+		return b
+	}
+	short, _ := rlp.EncodeList(fields[:14])
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{"not a list", []byte{0x80}, ErrNotList},
+		{"too few fields", short, ErrFieldCount},
+		{"short hash", withField(0, make([]byte, 31)), ErrInvalidField},
+		{"list in place of bytes", withField(3, []interface{}{}), ErrInvalidField},
+		{"leading zero number", withField(8, []byte{0x00, 0x01}), ErrNonCanonicalInt},
+		{"leading zero difficulty", withField(7, []byte{0x00, 0x01}), ErrNonCanonicalInt},
+		{"number overflow", withField(8, bytes.Repeat([]byte{1}, 9)), ErrInvalidField},
+		{"bad nonce", withField(14, []byte(strings.Repeat("x", 9))), ErrInvalidField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeRLP(tt.data); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package blockheader
+
+import "testing"
+
+func FuzzDecodeRLP(f *testing.F) {
+	f.Add([]byte{0xc0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		DecodeRLP(data)
+	})
+}
@@ -0,0 +1,391 @@
+package bytecode
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/metadata"
+)
+
+// MaxStackDepth is the EVM stack limit.
+const MaxStackDepth = 1024
+
+// maxStatesPerBlock bounds how many distinct abstract stacks are explored
+// per block. Exceeding it marks the analysis incomplete.
+const maxStatesPerBlock = 64
+
+// FindingKind classifies an analysis finding.
+type FindingKind int
+
+const (
+	// Unreachable marks a block that no execution path can reach.
+	Unreachable FindingKind = iota
+	// StackUnderflow marks an instruction that pops more items than some
+	// path leaves on the stack.
+	StackUnderflow
+	// StackOverflow marks an instruction that grows the stack past 1024.
+	StackOverflow
+	// InvalidJump marks a jump whose static target is not a JUMPDEST.
+	InvalidJump
+	// UnresolvedJump marks a jump whose target is not a known constant.
+	UnresolvedJump
+	// InconsistentStackHeight marks a block entered with different stack
+	// heights along different paths. Legacy code does this legitimately
+	// when a subroutine is called at several depths, so it is advisory.
+	InconsistentStackHeight
+)
+
+// String returns the kind name.
+func (k FindingKind) String() string {
+	switch k {
+	case Unreachable:
+		return "unreachable"
+	case StackUnderflow:
+		return "stack-underflow"
+	case StackOverflow:
+		return "stack-overflow"
+	case InvalidJump:
+		return "invalid-jump"
+	case UnresolvedJump:
+		return "unresolved-jump"
+	case InconsistentStackHeight:
+		return "inconsistent-stack-height"
+	default:
+		return fmt.Sprintf("FindingKind(%d)", int(k))
+	}
+}
+
+// Finding is a single issue reported by Analyze.
+type Finding struct {
+	Kind    FindingKind
+	PC      int
+	Message string
+}
+
+// String returns the finding as "pc: kind: message".
+func (f Finding) String() string {
+	return fmt.Sprintf("%04x: %s: %s", f.PC, f.Kind, f.Message)
+}
+
+// Block is a basic block: a straight-line run of instructions entered
+// only at its first instruction.
+type Block struct {
+	// Start is the PC of the first instruction.
+	Start int
+	// End is the PC one past the last byte of the block.
+	End int
+	// Instructions are the decoded instructions of the block.
+	Instructions []Instruction
+	// Successors are indices into Analysis.Blocks, in ascending order.
+	Successors []int
+	// Reachable is true if some explored path enters the block.
+	Reachable bool
+	// EntryHeights are the distinct stack heights observed on entry, in
+	// ascending order.
+	EntryHeights []int
+}
+
+// Analysis is the result of Analyze.
+type Analysis struct {
+	Blocks   []Block
+	Findings []Finding
+	// MaxStackHeight is the deepest stack seen on any explored path.
+	MaxStackHeight int
+	// Complete is false when some jump target could not be resolved or
+	// the exploration budget ran out. Unreachable JUMPDEST blocks are not
+	// reported in that case, since an unresolved jump may target them.
+	Complete bool
+}
+
+// Valid returns true if no path underflows or overflows the stack and
+// every static jump lands on a JUMPDEST.
+func (a Analysis) Valid() bool {
+	for _, f := range a.Findings {
+		switch f.Kind {
+		case StackUnderflow, StackOverflow, InvalidJump:
+			return false
+		}
+	}
+	return true
+}
+
+// Unreachable returns the blocks that no explored path reaches.
+func (a Analysis) Unreachable() []Block {
+	var out []Block
+	for _, b := range a.Blocks {
+		if !b.Reachable {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// FindingsOf returns the findings of the given kind.
+func (a Analysis) FindingsOf(kind FindingKind) []Finding {
+	var out []Finding
+	for _, f := range a.Findings {
+		if f.Kind == kind {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Analyze splits code into basic blocks, resolves constant jump targets,
+// and walks every path from PC 0 tracking stack height.
+//
+// A trailing solc metadata trailer is excluded from the analysis. Jump
+// targets are resolved by tracking PUSHed constants through DUP, SWAP and
+// POP, which covers the return-address pattern of Solidity internal
+// calls.
+func Analyze(code []byte) Analysis {
+	if body, _, err := metadata.Split(code); err == nil {
+		code = body
+	}
+
+	blocks := buildBlocks(Instructions(code))
+	w := walker{
+		blocks:    blocks,
+		jumpDests: JumpDests(code),
+		blockAt:   make(map[int]int, len(blocks)),
+		seen:      make([]map[string]bool, len(blocks)),
+		heights:   make([]map[int]bool, len(blocks)),
+		succ:      make([]map[int]bool, len(blocks)),
+		reported:  make(map[findingKey]bool),
+		complete:  true,
+	}
+	for i, b := range blocks {
+		w.blockAt[b.Start] = i
+		w.seen[i] = make(map[string]bool)
+		w.heights[i] = make(map[int]bool)
+		w.succ[i] = make(map[int]bool)
+	}
+
+	if len(blocks) > 0 {
+		w.run()
+	}
+
+	for i := range blocks {
+		b := &blocks[i]
+		b.Reachable = len(w.seen[i]) > 0
+		b.Successors = sortedKeys(w.succ[i])
+		b.EntryHeights = sortedKeys(w.heights[i])
+
+		if !b.Reachable && (w.complete || b.Instructions[0].Op != JUMPDEST) {
+			w.report(Unreachable, b.Start, fmt.Sprintf("block %04x-%04x is never executed", b.Start, b.End))
+		}
+		if len(b.EntryHeights) > 1 {
+			w.report(InconsistentStackHeight, b.Start, fmt.Sprintf("entered with stack heights %v", b.EntryHeights))
+		}
+	}
+
+	sort.SliceStable(w.findings, func(i, j int) bool {
+		if w.findings[i].PC != w.findings[j].PC {
+			return w.findings[i].PC < w.findings[j].PC
+		}
+		return w.findings[i].Kind < w.findings[j].Kind
+	})
+
+	return Analysis{
+		Blocks:         blocks,
+		Findings:       w.findings,
+		MaxStackHeight: w.maxHeight,
+		Complete:       w.complete,
+	}
+}
+
+// buildBlocks splits instructions at JUMPDESTs and after control flow.
+func buildBlocks(instrs []Instruction) []Block {
+	var blocks []Block
+	for i, in := range instrs {
+		if i == 0 || in.Op == JUMPDEST || endsBlock(instrs[i-1].Op) {
+			blocks = append(blocks, Block{Start: in.PC})
+		}
+		b := &blocks[len(blocks)-1]
+		b.Instructions = append(b.Instructions, in)
+		b.End = in.PC + in.Size()
+	}
+	return blocks
+}
+
+// endsBlock returns true if op transfers control away from the next PC.
+func endsBlock(op byte) bool {
+	return op == JUMP || op == JUMPI || IsTerminator(op)
+}
+
+// value is an abstract stack slot: a known constant or unknown.
+type value struct {
+	known bool
+	v     uint64
+}
+
+type findingKey struct {
+	kind FindingKind
+	pc   int
+}
+
+type pending struct {
+	block int
+	stack []value
+}
+
+// walker explores (block, abstract stack) states depth-first.
+type walker struct {
+	blocks    []Block
+	jumpDests map[int]bool
+	blockAt   map[int]int
+
+	seen    []map[string]bool
+	heights []map[int]bool
+	succ    []map[int]bool
+
+	work      []pending
+	findings  []Finding
+	reported  map[findingKey]bool
+	maxHeight int
+	complete  bool
+}
+
+func (w *walker) run() {
+	w.work = append(w.work, pending{block: 0})
+	for len(w.work) > 0 {
+		p := w.work[len(w.work)-1]
+		w.work = w.work[:len(w.work)-1]
+
+		key := stackKey(p.stack)
+		if w.seen[p.block][key] {
+			continue
+		}
+		if len(w.seen[p.block]) >= maxStatesPerBlock {
+			w.complete = false
+			continue
+		}
+		w.seen[p.block][key] = true
+		w.heights[p.block][len(p.stack)] = true
+
+		w.exec(p.block, append([]value(nil), p.stack...))
+	}
+}
+
+// exec runs one block on stack and queues its successors.
+func (w *walker) exec(bi int, stack []value) {
+	b := w.blocks[bi]
+	for _, in := range b.Instructions {
+		pop, push := StackEffect(in.Op)
+		if len(stack) < pop {
+			w.report(StackUnderflow, in.PC, fmt.Sprintf("%s needs %d items, stack has %d", in.Name(), pop, len(stack)))
+			return
+		}
+
+		top := len(stack) - 1
+		switch {
+		case in.Op == PUSH0:
+			stack = append(stack, value{known: true})
+		case IsPush(in.Op):
+			stack = append(stack, immediateValue(in))
+		case in.Op >= DUP1 && in.Op <= DUP16:
+			stack = append(stack, stack[len(stack)-pop])
+		case in.Op >= SWAP1 && in.Op <= SWAP16:
+			other := len(stack) - pop
+			stack[top], stack[other] = stack[other], stack[top]
+		case in.Op == JUMP:
+			target := stack[top]
+			stack = stack[:top]
+			w.jump(bi, in.PC, target, stack)
+			return
+		case in.Op == JUMPI:
+			target := stack[top]
+			stack = stack[:top-1]
+			w.jump(bi, in.PC, target, stack)
+		default:
+			stack = stack[:len(stack)-pop]
+			for i := 0; i < push; i++ {
+				stack = append(stack, value{})
+			}
+		}
+
+		if len(stack) > MaxStackDepth {
+			w.report(StackOverflow, in.PC, fmt.Sprintf("%s grows stack to %d", in.Name(), len(stack)))
+			return
+		}
+		if len(stack) > w.maxHeight {
+			w.maxHeight = len(stack)
+		}
+		if IsTerminator(in.Op) {
+			return
+		}
+	}
+
+	// Fall through into the next block; running off the end is a STOP
+	if next := bi + 1; next < len(w.blocks) {
+		w.enqueue(bi, next, stack)
+	}
+}
+
+// jump resolves a jump target and queues the destination block.
+func (w *walker) jump(from, pc int, target value, stack []value) {
+	if !target.known {
+		w.complete = false
+		w.report(UnresolvedJump, pc, "jump target is not a constant")
+		return
+	}
+	if target.v > uint64(^uint(0)>>1) || !w.jumpDests[int(target.v)] {
+		w.report(InvalidJump, pc, fmt.Sprintf("target 0x%x is not a JUMPDEST", target.v))
+		return
+	}
+	w.enqueue(from, w.blockAt[int(target.v)], stack)
+}
+
+func (w *walker) enqueue(from, to int, stack []value) {
+	w.succ[from][to] = true
+	w.work = append(w.work, pending{block: to, stack: append([]value(nil), stack...)})
+}
+
+func (w *walker) report(kind FindingKind, pc int, msg string) {
+	k := findingKey{kind: kind, pc: pc}
+	if w.reported[k] {
+		return
+	}
+	w.reported[k] = true
+	w.findings = append(w.findings, Finding{Kind: kind, PC: pc, Message: msg})
+}
+
+// immediateValue returns the PUSH value if it fits in 64 bits.
+// A PUSH truncated by the end of code is right-padded with zeros.
+func immediateValue(in Instruction) value {
+	size := PushSize(in.Op)
+	var v uint64
+	for i := 0; i < size; i++ {
+		var b byte
+		if i < len(in.Immediate) {
+			b = in.Immediate[i]
+		}
+		if v>>56 != 0 {
+			return value{}
+		}
+		v = v<<8 | uint64(b)
+	}
+	return value{known: true, v: v}
+}
+
+func stackKey(stack []value) string {
+	var sb strings.Builder
+	for _, v := range stack {
+		if v.known {
+			fmt.Fprintf(&sb, "%x,", v.v)
+		} else {
+			sb.WriteString("?,")
+		}
+	}
+	return sb.String()
+}
+
+func sortedKeys(m map[int]bool) []int {
+	out := make([]int, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Ints(out)
+	return out
+}
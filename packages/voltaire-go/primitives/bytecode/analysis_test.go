@@ -0,0 +1,216 @@
+package bytecode
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeStraightLine(t *testing.T) {
+	// PUSH1 0x80 PUSH1 0x40 MSTORE STOP
+	a := Analyze(mustFromHex(t, "608060405200"))
+
+	if !a.Valid() || !a.Complete {
+		t.Fatalf("expected valid, complete analysis, got %v", a.Findings)
+	}
+	if len(a.Findings) != 0 {
+		t.Errorf("unexpected findings: %v", a.Findings)
+	}
+	if len(a.Blocks) != 1 {
+		t.Fatalf("got %d blocks, want 1", len(a.Blocks))
+	}
+	if a.MaxStackHeight != 2 {
+		t.Errorf("MaxStackHeight = %d, want 2", a.MaxStackHeight)
+	}
+}
+
+func TestAnalyzeUnreachable(t *testing.T) {
+	// 00: PUSH1 0x06 JUMP
+	// 03: PUSH1 0x01 STOP      <- dead code after JUMP
+	// 06: JUMPDEST STOP
+	// 08: JUMPDEST STOP        <- never jumped to
+	a := Analyze(mustFromHex(t, "600656600100"+"5b00"+"5b00"))
+
+	if !a.Complete {
+		t.Fatal("expected complete analysis")
+	}
+
+	var pcs []int
+	for _, f := range a.FindingsOf(Unreachable) {
+		pcs = append(pcs, f.PC)
+	}
+	if want := []int{3, 8}; !reflect.DeepEqual(pcs, want) {
+		t.Errorf("unreachable at %v, want %v", pcs, want)
+	}
+
+	if got := len(a.Unreachable()); got != 2 {
+		t.Errorf("Unreachable() returned %d blocks, want 2", got)
+	}
+	if !a.Blocks[2].Reachable {
+		t.Error("jump target block should be reachable")
+	}
+	if want := []int{2}; !reflect.DeepEqual(a.Blocks[0].Successors, want) {
+		t.Errorf("Successors = %v, want %v", a.Blocks[0].Successors, want)
+	}
+}
+
+func TestAnalyzeUnderflow(t *testing.T) {
+	// PUSH1 0x01 ADD STOP
+	a := Analyze(mustFromHex(t, "60010100"))
+
+	if a.Valid() {
+		t.Fatal("expected invalid analysis")
+	}
+	f := a.FindingsOf(StackUnderflow)
+	if len(f) != 1 || f[0].PC != 2 {
+		t.Errorf("underflow findings = %v, want one at pc 2", f)
+	}
+}
+
+func TestAnalyzeUnderflowOnOnePath(t *testing.T) {
+	// 00: PUSH1 0x01 CALLDATASIZE PUSH1 0x08 JUMPI
+	// 06: POP STOP
+	// 08: JUMPDEST POP POP STOP   <- entered with one item, pops two
+	a := Analyze(mustFromHex(t, "600136600857"+"5000"+"5b505000"))
+
+	f := a.FindingsOf(StackUnderflow)
+	if len(f) != 1 || f[0].PC != 10 {
+		t.Errorf("underflow findings = %v, want one at pc 10", f)
+	}
+}
+
+func TestAnalyzeInvalidJump(t *testing.T) {
+	// PUSH1 0x04 JUMP STOP STOP  <- 0x04 is not a JUMPDEST
+	a := Analyze(mustFromHex(t, "6004560000"))
+
+	f := a.FindingsOf(InvalidJump)
+	if len(f) != 1 || f[0].PC != 2 {
+		t.Errorf("invalid jump findings = %v, want one at pc 2", f)
+	}
+	if a.Valid() {
+		t.Error("expected invalid analysis")
+	}
+}
+
+func TestAnalyzeJumpIntoPushData(t *testing.T) {
+	// PUSH1 0x03 JUMP PUSH1 0x5b  <- 0x03 is PUSH data, not a JUMPDEST
+	a := Analyze(mustFromHex(t, "600356605b"))
+
+	if len(a.FindingsOf(InvalidJump)) != 1 {
+		t.Errorf("expected invalid jump, got %v", a.Findings)
+	}
+}
+
+func TestAnalyzeReturnAddressThroughSwap(t *testing.T) {
+	// Solidity-style internal call: push return address, jump to the
+	// subroutine, which swaps the address back on top and jumps to it.
+	//
+	// 00: PUSH1 0x0a PUSH1 0x2a PUSH1 0x07 JUMP
+	// 07: JUMPDEST SWAP1 JUMP
+	// 0a: JUMPDEST POP STOP
+	a := Analyze(mustFromHex(t, "600a602a600756"+"5b9056"+"5b5000"))
+
+	if !a.Complete {
+		t.Fatalf("expected complete analysis, got %v", a.Findings)
+	}
+	if len(a.Findings) != 0 {
+		t.Errorf("unexpected findings: %v", a.Findings)
+	}
+	for i, b := range a.Blocks {
+		if !b.Reachable {
+			t.Errorf("block %d unreachable", i)
+		}
+	}
+}
+
+func TestAnalyzeUnresolvedJump(t *testing.T) {
+	// 00: CALLDATASIZE JUMP
+	// 02: JUMPDEST STOP   <- could be the target; not reported
+	// 04: STOP            <- not a JUMPDEST, so provably unreachable
+	a := Analyze(mustFromHex(t, "3656"+"5b00"+"00"))
+
+	if a.Complete {
+		t.Error("expected incomplete analysis")
+	}
+	if len(a.FindingsOf(UnresolvedJump)) != 1 {
+		t.Errorf("expected unresolved jump, got %v", a.Findings)
+	}
+
+	var pcs []int
+	for _, f := range a.FindingsOf(Unreachable) {
+		pcs = append(pcs, f.PC)
+	}
+	if want := []int{4}; !reflect.DeepEqual(pcs, want) {
+		t.Errorf("unreachable at %v, want %v", pcs, want)
+	}
+}
+
+func TestAnalyzeInconsistentHeight(t *testing.T) {
+	// 00: CALLDATASIZE PUSH1 0x08 JUMPI   <- jumps with 0 items
+	// 04: PUSH1 0x01 PUSH0 POP            <- falls through with 1 item
+	// 08: JUMPDEST STOP
+	a := Analyze(mustFromHex(t, "36600857"+"60015f50"+"5b00"))
+
+	f := a.FindingsOf(InconsistentStackHeight)
+	if len(f) != 1 || f[0].PC != 8 {
+		t.Fatalf("inconsistent height findings = %v, want one at pc 8", f)
+	}
+	if want := []int{0, 1}; !reflect.DeepEqual(a.Blocks[2].EntryHeights, want) {
+		t.Errorf("EntryHeights = %v, want %v", a.Blocks[2].EntryHeights, want)
+	}
+	if !a.Valid() {
+		t.Error("inconsistent heights alone should not invalidate the analysis")
+	}
+}
+
+func TestAnalyzeOverflow(t *testing.T) {
+	// 1025 x PUSH0
+	a := Analyze(mustFromHex(t, strings.Repeat("5f", MaxStackDepth+1)))
+
+	f := a.FindingsOf(StackOverflow)
+	if len(f) != 1 || f[0].PC != MaxStackDepth {
+		t.Errorf("overflow findings = %v, want one at pc %d", f, MaxStackDepth)
+	}
+	if a.MaxStackHeight != MaxStackDepth {
+		t.Errorf("MaxStackHeight = %d, want %d", a.MaxStackHeight, MaxStackDepth)
+	}
+}
+
+func TestAnalyzeUnboundedGrowth(t *testing.T) {
+	// 00: JUMPDEST PUSH0 PUSH1 0x00 JUMP  <- grows the stack each iteration
+	a := Analyze(mustFromHex(t, "5b5f600056"))
+
+	if a.Complete {
+		t.Error("unbounded loop should exhaust the exploration budget")
+	}
+	if len(a.FindingsOf(StackUnderflow)) != 0 {
+		t.Errorf("unexpected underflow: %v", a.Findings)
+	}
+}
+
+func TestAnalyzeIgnoresMetadata(t *testing.T) {
+	// PUSH1 0x80 STOP, then a solc trailer {"solc": 0x000813} || 0x000a
+	a := Analyze(mustFromHex(t, "608000"+"a164736f6c6343000813"+"000a"))
+
+	if len(a.Findings) != 0 {
+		t.Errorf("metadata trailer should not produce findings: %v", a.Findings)
+	}
+	if len(a.Blocks) != 1 {
+		t.Errorf("got %d blocks, want 1", len(a.Blocks))
+	}
+}
+
+func TestAnalyzeEmpty(t *testing.T) {
+	a := Analyze(nil)
+	if !a.Valid() || !a.Complete || len(a.Blocks) != 0 {
+		t.Errorf("empty code: %+v", a)
+	}
+}
+
+func TestFindingString(t *testing.T) {
+	f := Finding{Kind: InvalidJump, PC: 0x2a, Message: "target 0x4 is not a JUMPDEST"}
+	want := "002a: invalid-jump: target 0x4 is not a JUMPDEST"
+	if got := f.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+// Package bytecode provides EVM bytecode parsing and static analysis.
+//
+// Instructions are decoded linearly: PUSH immediates are skipped so that
+// data bytes are never mistaken for opcodes, and JUMPDEST bytes inside
+// immediates are not valid jump targets.
+package bytecode
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors
+var (
+	ErrInvalidHex = errors.New("bytecode: invalid hex string")
+)
+
+// Instruction is a single decoded opcode and its immediate data.
+type Instruction struct {
+	// PC is the byte offset of the opcode.
+	PC int
+	// Op is the opcode byte.
+	Op byte
+	// Immediate holds PUSH data. It is shorter than PushSize(Op) when the
+	// code ends in the middle of a PUSH; the EVM pads the rest with zeros.
+	Immediate []byte
+}
+
+// Size returns the encoded size of the instruction in bytes.
+func (in Instruction) Size() int {
+	return 1 + PushSize(in.Op)
+}
+
+// Name returns the mnemonic of the instruction's opcode.
+func (in Instruction) Name() string {
+	return Name(in.Op)
+}
+
+// String returns the instruction in "PUSH1 0x80" form.
+func (in Instruction) String() string {
+	if !IsPush(in.Op) {
+		return in.Name()
+	}
+	return in.Name() + " 0x" + hex.EncodeToString(in.Immediate)
+}
+
+// FromHex decodes a hex string into raw bytecode.
+// Accepts both "0x" prefixed and raw hex strings.
+func FromHex(s string) ([]byte, error) {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidHex
+	}
+	return b, nil
+}
+
+// Instructions decodes code into its instruction sequence.
+func Instructions(code []byte) []Instruction {
+	var out []Instruction
+	for pc := 0; pc < len(code); {
+		op := code[pc]
+		in := Instruction{PC: pc, Op: op}
+		if n := PushSize(op); n > 0 {
+			end := pc + 1 + n
+			if end > len(code) {
+				end = len(code)
+			}
+			in.Immediate = code[pc+1 : end]
+		}
+		out = append(out, in)
+		pc += in.Size()
+	}
+	return out
+}
+
+// JumpDests returns the set of valid jump destinations in code.
+func JumpDests(code []byte) map[int]bool {
+	dests := make(map[int]bool)
+	for pc := 0; pc < len(code); pc += 1 + PushSize(code[pc]) {
+		if code[pc] == JUMPDEST {
+			dests[pc] = true
+		}
+	}
+	return dests
+}
+
+// IsValidJumpDest returns true if pc is a JUMPDEST outside PUSH data.
+func IsValidJumpDest(code []byte, pc int) bool {
+	if pc < 0 || pc >= len(code) || code[pc] != JUMPDEST {
+		return false
+	}
+	return JumpDests(code)[pc]
+}
+
+// Disassemble returns a human-readable listing with one instruction per
+// line, prefixed by its program counter.
+func Disassemble(code []byte) string {
+	var sb strings.Builder
+	for _, in := range Instructions(code) {
+		fmt.Fprintf(&sb, "%04x: %s\n", in.PC, in)
+	}
+	return sb.String()
+}
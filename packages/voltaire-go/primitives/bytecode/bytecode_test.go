@@ -0,0 +1,189 @@
+package bytecode
+
+import (
+	"bytes"
+	"testing"
+)
+
+func mustFromHex(t *testing.T, s string) []byte {
+	t.Helper()
+	code, err := FromHex(s)
+	if err != nil {
+		t.Fatalf("FromHex(%q): %v", s, err)
+	}
+	return code
+}
+
+func TestFromHex(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{name: "with prefix", input: "0x6080", want: []byte{0x60, 0x80}},
+		{name: "without prefix", input: "6080", want: []byte{0x60, 0x80}},
+		{name: "empty", input: "0x", want: []byte{}},
+		{name: "odd length", input: "0x608", wantErr: true},
+		{name: "invalid char", input: "0xzz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromHex(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("got %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstructions(t *testing.T) {
+	// PUSH1 0x80 PUSH1 0x40 MSTORE PUSH2 0x5b5b JUMPDEST
+	code := mustFromHex(t, "6080604052615b5b5b")
+
+	instrs := Instructions(code)
+	want := []struct {
+		pc  int
+		op  byte
+		imm []byte
+	}{
+		{0, 0x60, []byte{0x80}},
+		{2, 0x60, []byte{0x40}},
+		{4, 0x52, nil},
+		{5, 0x61, []byte{0x5b, 0x5b}},
+		{8, 0x5b, nil},
+	}
+
+	if len(instrs) != len(want) {
+		t.Fatalf("got %d instructions, want %d", len(instrs), len(want))
+	}
+	for i, w := range want {
+		in := instrs[i]
+		if in.PC != w.pc || in.Op != w.op || !bytes.Equal(in.Immediate, w.imm) {
+			t.Errorf("instr %d = {%d %02x %x}, want {%d %02x %x}", i, in.PC, in.Op, in.Immediate, w.pc, w.op, w.imm)
+		}
+	}
+}
+
+func TestInstructionsTruncatedPush(t *testing.T) {
+	// PUSH4 with only two bytes of data
+	instrs := Instructions([]byte{0x63, 0xaa, 0xbb})
+	if len(instrs) != 1 {
+		t.Fatalf("got %d instructions, want 1", len(instrs))
+	}
+	if !bytes.Equal(instrs[0].Immediate, []byte{0xaa, 0xbb}) {
+		t.Errorf("Immediate = %x, want aabb", instrs[0].Immediate)
+	}
+}
+
+func TestJumpDests(t *testing.T) {
+	// JUMPDEST PUSH1 0x5b JUMPDEST
+	code := []byte{0x5b, 0x60, 0x5b, 0x5b}
+
+	dests := JumpDests(code)
+	if !dests[0] || !dests[3] {
+		t.Errorf("expected 0 and 3 to be jump destinations, got %v", dests)
+	}
+	if dests[2] {
+		t.Error("JUMPDEST inside PUSH data must not be a jump destination")
+	}
+
+	if !IsValidJumpDest(code, 3) {
+		t.Error("IsValidJumpDest(3) = false, want true")
+	}
+	if IsValidJumpDest(code, 2) {
+		t.Error("IsValidJumpDest(2) = true, want false")
+	}
+	if IsValidJumpDest(code, 10) {
+		t.Error("IsValidJumpDest(10) = true, want false")
+	}
+}
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		op   byte
+		want string
+	}{
+		{0x00, "STOP"},
+		{0x5f, "PUSH0"},
+		{0x60, "PUSH1"},
+		{0x7f, "PUSH32"},
+		{0x80, "DUP1"},
+		{0x9f, "SWAP16"},
+		{0xa4, "LOG4"},
+		{0x5e, "MCOPY"},
+		{0x0c, "UNKNOWN_0x0c"},
+	}
+
+	for _, tt := range tests {
+		if got := Name(tt.op); got != tt.want {
+			t.Errorf("Name(0x%02x) = %s, want %s", tt.op, got, tt.want)
+		}
+	}
+}
+
+func TestStackEffect(t *testing.T) {
+	tests := []struct {
+		op       byte
+		pop, psh int
+	}{
+		{0x01, 2, 1}, // ADD
+		{0x82, 3, 4}, // DUP3
+		{0x91, 3, 3}, // SWAP2
+		{0xf1, 7, 1}, // CALL
+		{0xa2, 4, 0}, // LOG2
+		{0x0c, 0, 0}, // undefined
+		{0x5f, 0, 1}, // PUSH0
+		{0x56, 1, 0}, // JUMP
+		{0x57, 2, 0}, // JUMPI
+		{0xff, 1, 0}, // SELFDESTRUCT
+		{0x3c, 4, 0}, // EXTCODECOPY
+		{0xfa, 6, 1}, // STATICCALL
+		{0x5e, 3, 0}, // MCOPY
+		{0x49, 1, 1}, // BLOBHASH
+		{0x4a, 0, 1}, // BLOBBASEFEE
+		{0x5c, 1, 1}, // TLOAD
+		{0x5d, 2, 0}, // TSTORE
+		{0x20, 2, 1}, // KECCAK256
+		{0xf5, 4, 1}, // CREATE2
+		{0xfd, 2, 0}, // REVERT
+	}
+
+	for _, tt := range tests {
+		pop, push := StackEffect(tt.op)
+		if pop != tt.pop || push != tt.psh {
+			t.Errorf("StackEffect(%s) = (%d, %d), want (%d, %d)", Name(tt.op), pop, push, tt.pop, tt.psh)
+		}
+	}
+}
+
+func TestIsTerminator(t *testing.T) {
+	for _, op := range []byte{0x00, 0xf3, 0xfd, 0xfe, 0xff, 0x0c} {
+		if !IsTerminator(op) {
+			t.Errorf("IsTerminator(%s) = false, want true", Name(op))
+		}
+	}
+	for _, op := range []byte{0x01, 0x56, 0x57, 0x5b} {
+		if IsTerminator(op) {
+			t.Errorf("IsTerminator(%s) = true, want false", Name(op))
+		}
+	}
+}
+
+func TestDisassemble(t *testing.T) {
+	got := Disassemble([]byte{0x60, 0x80, 0x60, 0x40, 0x52, 0x00})
+	want := "0000: PUSH1 0x80\n0002: PUSH1 0x40\n0004: MSTORE\n0005: STOP\n"
+	if got != want {
+		t.Errorf("Disassemble() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,220 @@
+package bytecode
+
+import "fmt"
+
+// Opcode values referenced by the analysis passes.
+const (
+	STOP           byte = 0x00
+	EQ             byte = 0x14
+	KECCAK256      byte = 0x20
+	ADDRESS        byte = 0x30
+	ORIGIN         byte = 0x32
+	CALLER         byte = 0x33
+	CALLVALUE      byte = 0x34
+	CALLDATASIZE   byte = 0x36
+	CALLDATACOPY   byte = 0x37
+	CODESIZE       byte = 0x38
+	CODECOPY       byte = 0x39
+	EXTCODESIZE    byte = 0x3b
+	EXTCODECOPY    byte = 0x3c
+	RETURNDATACOPY byte = 0x3e
+	EXTCODEHASH    byte = 0x3f
+	CHAINID        byte = 0x46
+	MSTORE         byte = 0x52
+	MSTORE8        byte = 0x53
+	SLOAD          byte = 0x54
+	SSTORE         byte = 0x55
+	JUMP           byte = 0x56
+	JUMPI          byte = 0x57
+	JUMPDEST       byte = 0x5b
+	MCOPY          byte = 0x5e
+	PUSH0          byte = 0x5f
+	PUSH1          byte = 0x60
+	PUSH4          byte = 0x63
+	PUSH32         byte = 0x7f
+	DUP1           byte = 0x80
+	DUP16          byte = 0x8f
+	SWAP1          byte = 0x90
+	SWAP16         byte = 0x9f
+	CREATE         byte = 0xf0
+	CALL           byte = 0xf1
+	CALLCODE       byte = 0xf2
+	RETURN         byte = 0xf3
+	DELEGATECALL   byte = 0xf4
+	CREATE2        byte = 0xf5
+	STATICCALL     byte = 0xfa
+	REVERT         byte = 0xfd
+	INVALID        byte = 0xfe
+	SELFDESTRUCT   byte = 0xff
+)
+
+// opInfo describes the static properties of an opcode.
+type opInfo struct {
+	name    string
+	pop     int
+	push    int
+	defined bool
+}
+
+// opcodes is the opcode table for the latest supported hardfork (Cancun).
+var opcodes = func() [256]opInfo {
+	var t [256]opInfo
+	set := func(op byte, name string, pop, push int) {
+		t[op] = opInfo{name: name, pop: pop, push: push, defined: true}
+	}
+
+	// 0x00: Stop and arithmetic
+	set(0x00, "STOP", 0, 0)
+	set(0x01, "ADD", 2, 1)
+	set(0x02, "MUL", 2, 1)
+	set(0x03, "SUB", 2, 1)
+	set(0x04, "DIV", 2, 1)
+	set(0x05, "SDIV", 2, 1)
+	set(0x06, "MOD", 2, 1)
+	set(0x07, "SMOD", 2, 1)
+	set(0x08, "ADDMOD", 3, 1)
+	set(0x09, "MULMOD", 3, 1)
+	set(0x0a, "EXP", 2, 1)
+	set(0x0b, "SIGNEXTEND", 2, 1)
+
+	// 0x10: Comparison and bitwise
+	set(0x10, "LT", 2, 1)
+	set(0x11, "GT", 2, 1)
+	set(0x12, "SLT", 2, 1)
+	set(0x13, "SGT", 2, 1)
+	set(0x14, "EQ", 2, 1)
+	set(0x15, "ISZERO", 1, 1)
+	set(0x16, "AND", 2, 1)
+	set(0x17, "OR", 2, 1)
+	set(0x18, "XOR", 2, 1)
+	set(0x19, "NOT", 1, 1)
+	set(0x1a, "BYTE", 2, 1)
+	set(0x1b, "SHL", 2, 1)
+	set(0x1c, "SHR", 2, 1)
+	set(0x1d, "SAR", 2, 1)
+
+	// 0x20: Hashing
+	set(0x20, "KECCAK256", 2, 1)
+
+	// 0x30: Environment
+	set(0x30, "ADDRESS", 0, 1)
+	set(0x31, "BALANCE", 1, 1)
+	set(0x32, "ORIGIN", 0, 1)
+	set(0x33, "CALLER", 0, 1)
+	set(0x34, "CALLVALUE", 0, 1)
+	set(0x35, "CALLDATALOAD", 1, 1)
+	set(0x36, "CALLDATASIZE", 0, 1)
+	set(0x37, "CALLDATACOPY", 3, 0)
+	set(0x38, "CODESIZE", 0, 1)
+	set(0x39, "CODECOPY", 3, 0)
+	set(0x3a, "GASPRICE", 0, 1)
+	set(0x3b, "EXTCODESIZE", 1, 1)
+	set(0x3c, "EXTCODECOPY", 4, 0)
+	set(0x3d, "RETURNDATASIZE", 0, 1)
+	set(0x3e, "RETURNDATACOPY", 3, 0)
+	set(0x3f, "EXTCODEHASH", 1, 1)
+
+	// 0x40: Block information
+	set(0x40, "BLOCKHASH", 1, 1)
+	set(0x41, "COINBASE", 0, 1)
+	set(0x42, "TIMESTAMP", 0, 1)
+	set(0x43, "NUMBER", 0, 1)
+	set(0x44, "PREVRANDAO", 0, 1)
+	set(0x45, "GASLIMIT", 0, 1)
+	set(0x46, "CHAINID", 0, 1)
+	set(0x47, "SELFBALANCE", 0, 1)
+	set(0x48, "BASEFEE", 0, 1)
+	set(0x49, "BLOBHASH", 1, 1)
+	set(0x4a, "BLOBBASEFEE", 0, 1)
+
+	// 0x50: Stack, memory, storage and flow
+	set(0x50, "POP", 1, 0)
+	set(0x51, "MLOAD", 1, 1)
+	set(0x52, "MSTORE", 2, 0)
+	set(0x53, "MSTORE8", 2, 0)
+	set(0x54, "SLOAD", 1, 1)
+	set(0x55, "SSTORE", 2, 0)
+	set(0x56, "JUMP", 1, 0)
+	set(0x57, "JUMPI", 2, 0)
+	set(0x58, "PC", 0, 1)
+	set(0x59, "MSIZE", 0, 1)
+	set(0x5a, "GAS", 0, 1)
+	set(0x5b, "JUMPDEST", 0, 0)
+	set(0x5c, "TLOAD", 1, 1)
+	set(0x5d, "TSTORE", 2, 0)
+	set(0x5e, "MCOPY", 3, 0)
+	set(0x5f, "PUSH0", 0, 1)
+
+	// 0x60-0x7f: PUSH1-PUSH32
+	for i := 1; i <= 32; i++ {
+		set(PUSH1+byte(i-1), fmt.Sprintf("PUSH%d", i), 0, 1)
+	}
+	// 0x80-0x8f: DUP1-DUP16
+	for i := 1; i <= 16; i++ {
+		set(DUP1+byte(i-1), fmt.Sprintf("DUP%d", i), i, i+1)
+	}
+	// 0x90-0x9f: SWAP1-SWAP16
+	for i := 1; i <= 16; i++ {
+		set(SWAP1+byte(i-1), fmt.Sprintf("SWAP%d", i), i+1, i+1)
+	}
+	// 0xa0-0xa4: LOG0-LOG4
+	for i := 0; i <= 4; i++ {
+		set(0xa0+byte(i), fmt.Sprintf("LOG%d", i), 2+i, 0)
+	}
+
+	// 0xf0: System
+	set(0xf0, "CREATE", 3, 1)
+	set(0xf1, "CALL", 7, 1)
+	set(0xf2, "CALLCODE", 7, 1)
+	set(0xf3, "RETURN", 2, 0)
+	set(0xf4, "DELEGATECALL", 6, 1)
+	set(0xf5, "CREATE2", 4, 1)
+	set(0xfa, "STATICCALL", 6, 1)
+	set(0xfd, "REVERT", 2, 0)
+	set(0xfe, "INVALID", 0, 0)
+	set(0xff, "SELFDESTRUCT", 1, 0)
+
+	return t
+}()
+
+// Name returns the mnemonic of op, or "UNKNOWN_0x.." for undefined opcodes.
+func Name(op byte) string {
+	if !opcodes[op].defined {
+		return fmt.Sprintf("UNKNOWN_0x%02x", op)
+	}
+	return opcodes[op].name
+}
+
+// IsDefined returns true if op is assigned in the latest hardfork.
+func IsDefined(op byte) bool {
+	return opcodes[op].defined
+}
+
+// StackEffect returns the number of items op pops and pushes.
+// Undefined opcodes report (0, 0).
+func StackEffect(op byte) (pop, push int) {
+	return opcodes[op].pop, opcodes[op].push
+}
+
+// IsPush returns true for PUSH1-PUSH32 (PUSH0 carries no immediate).
+func IsPush(op byte) bool {
+	return op >= PUSH1 && op <= PUSH32
+}
+
+// PushSize returns the immediate size of a PUSH opcode, or 0 otherwise.
+func PushSize(op byte) int {
+	if !IsPush(op) {
+		return 0
+	}
+	return int(op-PUSH1) + 1
+}
+
+// IsTerminator returns true if op ends execution of the current context.
+// Undefined opcodes behave like INVALID and are terminators too.
+func IsTerminator(op byte) bool {
+	switch op {
+	case STOP, RETURN, REVERT, INVALID, SELFDESTRUCT:
+		return true
+	}
+	return !opcodes[op].defined
+}
@@ -0,0 +1,223 @@
+package bytecode
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/metadata"
+)
+
+// Gas costs used to estimate savings (Cancun, EIP-2929 warm access).
+const (
+	warmAccessGas = 100
+	keccakGas     = 30
+	keccakWordGas = 6
+	dupGas        = 3
+)
+
+// SuggestionKind classifies a gas-saving suggestion.
+type SuggestionKind int
+
+const (
+	// RepeatedSLOAD marks an SLOAD of a slot already loaded in the same
+	// block with no SSTORE or call in between.
+	RepeatedSLOAD SuggestionKind = iota
+	// RepeatedKeccak marks a KECCAK256 over a memory range already hashed
+	// in the same block with no memory write or call in between.
+	RepeatedKeccak
+	// RepeatedExtCode marks an EXTCODESIZE or EXTCODEHASH of an address
+	// already queried the same way in the same block with no call in
+	// between.
+	RepeatedExtCode
+)
+
+// String returns the kind name.
+func (k SuggestionKind) String() string {
+	switch k {
+	case RepeatedSLOAD:
+		return "repeated-sload"
+	case RepeatedKeccak:
+		return "repeated-keccak"
+	case RepeatedExtCode:
+		return "repeated-extcode"
+	default:
+		return fmt.Sprintf("SuggestionKind(%d)", int(k))
+	}
+}
+
+// Suggestion is a single pattern reported by Suggest.
+type Suggestion struct {
+	Kind SuggestionKind
+	// PC is the redundant instruction.
+	PC int
+	// FirstPC is the earlier instruction whose result could be reused.
+	FirstPC int
+	// GasSaved estimates the gas saved by keeping the earlier result on
+	// the stack and duplicating it instead. Extra stack shuffling is not
+	// counted.
+	GasSaved int
+	Message  string
+}
+
+// String returns the suggestion as "pc: kind: message (saves ~n gas)".
+func (s Suggestion) String() string {
+	return fmt.Sprintf("%04x: %s: %s (saves ~%d gas)", s.PC, s.Kind, s.Message, s.GasSaved)
+}
+
+// Suggest runs a peephole pass over code and reports instructions that
+// recompute a value already available earlier in the same basic block.
+//
+// Stack values are tracked symbolically through PUSH, DUP and SWAP, so
+// operands count as equal when they are the same constant or the same
+// stack value. Environment values that are fixed for a call, such as
+// CALLER and ADDRESS, are equal to themselves. Calls and creates end
+// every match, since they may change storage, memory and code. A
+// trailing solc metadata trailer is ignored.
+func Suggest(code []byte) []Suggestion {
+	if body, _, err := metadata.Split(code); err == nil {
+		code = body
+	}
+
+	var out []Suggestion
+	for _, b := range buildBlocks(Instructions(code)) {
+		p := peephole{
+			sloads:  make(map[string]int),
+			hashes:  make(map[string]int),
+			extcode: make(map[string]int),
+		}
+		for _, in := range b.Instructions {
+			p.step(in)
+		}
+		out = append(out, p.out...)
+	}
+	return out
+}
+
+// peephole scans one basic block. Stack entries are value keys: "0x.."
+// for constants, "env:NAME" for call-wide environment values, and "?n"
+// for anything else.
+type peephole struct {
+	stack []string
+	next  int
+
+	sloads  map[string]int // slot -> PC
+	hashes  map[string]int // offset,size -> PC
+	extcode map[string]int // opcode,address -> PC
+
+	out []Suggestion
+}
+
+func (p *peephole) step(in Instruction) {
+	switch in.Op {
+	case SLOAD:
+		slot := p.arg(0)
+		if first, ok := p.sloads[slot]; ok {
+			p.suggest(RepeatedSLOAD, in.PC, first, warmAccessGas-dupGas,
+				fmt.Sprintf("SLOAD of slot %s repeats %04x", describe(slot), first))
+		} else {
+			p.sloads[slot] = in.PC
+		}
+	case KECCAK256:
+		offset, size := p.arg(0), p.arg(1)
+		key := offset + "," + size
+		if first, ok := p.hashes[key]; ok {
+			saved := keccakGas - dupGas
+			if n, ok := constant(size); ok {
+				saved += keccakWordGas * int((n+31)/32)
+			}
+			p.suggest(RepeatedKeccak, in.PC, first, saved,
+				fmt.Sprintf("KECCAK256 of memory [%s, +%s] repeats %04x", describe(offset), describe(size), first))
+		} else {
+			p.hashes[key] = in.PC
+		}
+	case EXTCODESIZE, EXTCODEHASH:
+		addr := p.arg(0)
+		key := in.Name() + "," + addr
+		if first, ok := p.extcode[key]; ok {
+			p.suggest(RepeatedExtCode, in.PC, first, warmAccessGas-dupGas,
+				fmt.Sprintf("%s of address %s repeats %04x", in.Name(), describe(addr), first))
+		} else {
+			p.extcode[key] = in.PC
+		}
+	case SSTORE:
+		clear(p.sloads)
+	case MSTORE, MSTORE8, CALLDATACOPY, CODECOPY, EXTCODECOPY, RETURNDATACOPY, MCOPY:
+		clear(p.hashes)
+	case CREATE, CALL, CALLCODE, DELEGATECALL, CREATE2, STATICCALL:
+		clear(p.sloads)
+		clear(p.hashes)
+		clear(p.extcode)
+	}
+
+	pop, push := StackEffect(in.Op)
+	switch {
+	case in.Op == PUSH0:
+		p.stack = append(p.stack, "0x0")
+	case IsPush(in.Op):
+		padded := make([]byte, PushSize(in.Op))
+		copy(padded, in.Immediate)
+		p.stack = append(p.stack, "0x"+new(big.Int).SetBytes(padded).Text(16))
+	case in.Op >= DUP1 && in.Op <= DUP16:
+		p.stack = append(p.stack, p.arg(pop-1))
+	case in.Op >= SWAP1 && in.Op <= SWAP16:
+		other := p.arg(pop - 1)
+		top := len(p.stack) - 1
+		p.stack[top], p.stack[top-pop+1] = other, p.stack[top]
+	case isEnv(in.Op):
+		p.stack = append(p.stack, "env:"+in.Name())
+	default:
+		if pop > 0 {
+			p.arg(pop - 1)
+		}
+		p.stack = p.stack[:len(p.stack)-pop]
+		for i := 0; i < push; i++ {
+			p.next++
+			p.stack = append(p.stack, "?"+strconv.Itoa(p.next))
+		}
+	}
+}
+
+// arg returns the stack value i items below the top. Values the block
+// received on entry are unknown and get fresh keys as they are reached.
+func (p *peephole) arg(i int) string {
+	for len(p.stack) <= i {
+		p.next++
+		p.stack = append([]string{"?" + strconv.Itoa(p.next)}, p.stack...)
+	}
+	return p.stack[len(p.stack)-1-i]
+}
+
+func (p *peephole) suggest(kind SuggestionKind, pc, first, saved int, msg string) {
+	p.out = append(p.out, Suggestion{Kind: kind, PC: pc, FirstPC: first, GasSaved: saved, Message: msg})
+}
+
+// isEnv returns true for opcodes whose result is fixed for the duration
+// of a call.
+func isEnv(op byte) bool {
+	switch op {
+	case ADDRESS, ORIGIN, CALLER, CALLVALUE, CALLDATASIZE, CODESIZE, CHAINID:
+		return true
+	}
+	return false
+}
+
+// constant returns the value of a constant key if it fits in 64 bits.
+func constant(key string) (uint64, bool) {
+	if len(key) < 2 || key[:2] != "0x" {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(key[2:], 16, 64)
+	return n, err == nil
+}
+
+// describe renders a value key for messages.
+func describe(key string) string {
+	switch {
+	case len(key) > 4 && key[:4] == "env:":
+		return key[4:]
+	case key[0] == '?':
+		return "(computed)"
+	}
+	return key
+}
@@ -0,0 +1,130 @@
+package bytecode
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	addr := "73" + strings.Repeat("ab", 20) // PUSH20 0xabab..
+
+	type hit struct {
+		kind    SuggestionKind
+		pc      int
+		firstPC int
+		saved   int
+	}
+	tests := []struct {
+		name string
+		code string
+		want []hit
+	}{
+		{
+			// PUSH0 SLOAD PUSH0 SLOAD STOP
+			name: "repeated constant slot",
+			code: "5f54" + "5f54" + "00",
+			want: []hit{{RepeatedSLOAD, 3, 1, 97}},
+		},
+		{
+			// PUSH1 0x00 SLOAD PUSH0 SLOAD  <- same slot, different encoding
+			name: "slot pushed two ways",
+			code: "600054" + "5f54",
+			want: []hit{{RepeatedSLOAD, 4, 2, 97}},
+		},
+		{
+			// PUSH0 SLOAD PUSH1 0x01 SLOAD
+			name: "different slots",
+			code: "5f54" + "600154",
+		},
+		{
+			// PUSH0 SLOAD PUSH1 0x01 PUSH0 SSTORE PUSH0 SLOAD
+			name: "store in between",
+			code: "5f54" + "60015f55" + "5f54",
+		},
+		{
+			// PUSH0 SLOAD JUMPDEST PUSH0 SLOAD  <- second load may be a jump target
+			name: "separate blocks",
+			code: "5f54" + "5b5f54",
+		},
+		{
+			// DUP1 SLOAD SWAP1 SLOAD  <- same unknown slot from the stack
+			name: "repeated stack slot",
+			code: "8054" + "9054",
+			want: []hit{{RepeatedSLOAD, 3, 1, 97}},
+		},
+		{
+			// PUSH1 0x40 PUSH0 KECCAK256, twice: 30 + 2 words * 6 - DUP
+			name: "repeated keccak",
+			code: "60405f20" + "60405f20",
+			want: []hit{{RepeatedKeccak, 7, 3, 39}},
+		},
+		{
+			// PUSH1 0x40 PUSH0 KECCAK256 PUSH0 PUSH0 MSTORE PUSH1 0x40 PUSH0 KECCAK256
+			name: "memory write in between",
+			code: "60405f20" + "5f5f52" + "60405f20",
+		},
+		{
+			// PUSH1 0x40 PUSH0 KECCAK256 PUSH1 0x20 PUSH0 KECCAK256
+			name: "different ranges",
+			code: "60405f20" + "60205f20",
+		},
+		{
+			// CALLER EXTCODESIZE CALLER EXTCODESIZE
+			name: "repeated extcodesize",
+			code: "333b" + "333b",
+			want: []hit{{RepeatedExtCode, 3, 1, 97}},
+		},
+		{
+			// PUSH20 addr DUP1 EXTCODEHASH SWAP1 EXTCODEHASH
+			name: "repeated extcodehash",
+			code: addr + "803f" + "903f",
+			want: []hit{{RepeatedExtCode, 24, 22, 97}},
+		},
+		{
+			// CALLER EXTCODESIZE CALLER EXTCODEHASH
+			name: "size then hash",
+			code: "333b" + "333f",
+		},
+		{
+			// CALLER EXTCODESIZE, 7 x PUSH0 CALL POP, CALLER EXTCODESIZE
+			name: "call in between",
+			code: "333b" + strings.Repeat("5f", 7) + "f150" + "333b",
+		},
+		{
+			// PUSH0 SLOAD PUSH0 SLOAD, then a solc trailer {"solc": 0x000813} || 0x000a
+			name: "metadata ignored",
+			code: "5f54" + "5f54" + "a164736f6c6343000813" + "000a",
+			want: []hit{{RepeatedSLOAD, 3, 1, 97}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []hit
+			for _, s := range Suggest(mustFromHex(t, tt.code)) {
+				got = append(got, hit{s.Kind, s.PC, s.FirstPC, s.GasSaved})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestionString(t *testing.T) {
+	s := Suggest(mustFromHex(t, "333b"+"333b"))
+	if len(s) != 1 {
+		t.Fatalf("got %d suggestions, want 1", len(s))
+	}
+	want := "0003: repeated-extcode: EXTCODESIZE of address CALLER repeats 0001 (saves ~97 gas)"
+	if got := s[0].String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	s = Suggest(mustFromHex(t, "5f54"+"5f54"))
+	want = "0003: repeated-sload: SLOAD of slot 0x0 repeats 0001 (saves ~97 gas)"
+	if got := s[0].String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
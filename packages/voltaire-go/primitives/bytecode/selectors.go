@@ -0,0 +1,92 @@
+package bytecode
+
+// DispatchEntry is one case of a function dispatcher: calls whose
+// selector matches Selector continue at Target.
+type DispatchEntry struct {
+	Selector [4]byte
+	// Target is the PC of the function body, or -1 if the jump following
+	// the comparison could not be resolved.
+	Target int
+}
+
+// DispatchTable recovers the function-selector dispatch table from runtime
+// code by matching the comparison patterns emitted by solc and vyper:
+//
+//	DUP1 PUSH4 sel EQ PUSH2 dest JUMPI
+//	PUSH4 sel DUP2 EQ PUSH2 dest JUMPI
+//
+// Selectors with leading zero bytes are pushed with PUSH1 to PUSH3 and
+// are left-padded to four bytes. Those shorter forms are only accepted
+// when the comparison is followed by a resolvable JUMPI.
+//
+// This is a heuristic: it needs no ABI but may include constants that are
+// compared against something other than the selector, and it misses
+// dispatchers that use jump tables. Entries are returned in code order,
+// without duplicates.
+func DispatchTable(code []byte) []DispatchEntry {
+	instrs := Instructions(code)
+	dests := JumpDests(code)
+	seen := make(map[[4]byte]bool)
+	var out []DispatchEntry
+
+	for i, in := range instrs {
+		// solc pushes selectors with leading zero bytes using a shorter PUSH
+		if in.Op < PUSH1 || in.Op > PUSH4 || len(in.Immediate) != PushSize(in.Op) {
+			continue
+		}
+
+		// Allow a DUP between the constant and the comparison
+		j := i + 1
+		if j < len(instrs) && isDup(instrs[j].Op) {
+			j++
+		}
+		if j >= len(instrs) || instrs[j].Op != EQ {
+			continue
+		}
+
+		// Small constants are compared for many reasons besides dispatch,
+		// so short pushes only count when the comparison branches
+		target := branchTarget(instrs, dests, j+1)
+		if in.Op != PUSH4 && target < 0 {
+			continue
+		}
+
+		var sel [4]byte
+		copy(sel[4-len(in.Immediate):], in.Immediate)
+		if seen[sel] {
+			continue
+		}
+		seen[sel] = true
+
+		out = append(out, DispatchEntry{Selector: sel, Target: target})
+	}
+
+	return out
+}
+
+// Selectors returns the function selectors found by DispatchTable.
+func Selectors(code []byte) [][4]byte {
+	table := DispatchTable(code)
+	out := make([][4]byte, len(table))
+	for i, e := range table {
+		out[i] = e.Selector
+	}
+	return out
+}
+
+// branchTarget returns the destination of a "PUSHn dest JUMPI" sequence
+// starting at instrs[i], or -1 if there is none.
+func branchTarget(instrs []Instruction, dests map[int]bool, i int) int {
+	if i+1 >= len(instrs) || !IsPush(instrs[i].Op) || instrs[i+1].Op != JUMPI {
+		return -1
+	}
+	v := immediateValue(instrs[i])
+	if !v.known || v.v > uint64(^uint(0)>>1) || !dests[int(v.v)] {
+		return -1
+	}
+	return int(v.v)
+}
+
+func isDup(op byte) bool {
+	return op >= DUP1 && op <= DUP16
+}
@@ -0,0 +1,96 @@
+package bytecode
+
+import (
+	"reflect"
+	"testing"
+)
+
+// erc20Dispatcher is a trimmed solc-style dispatcher for transfer and
+// balanceOf, using both comparison orderings.
+const erc20Dispatcher = "" +
+	"6080604052" + // 00: PUSH1 0x80 PUSH1 0x40 MSTORE
+	"600436106027" + "57" + // 05: PUSH1 0x04 CALLDATASIZE LT PUSH1 0x27 JUMPI
+	"60003560e01c" + // 0c: PUSH1 0x00 CALLDATALOAD PUSH1 0xe0 SHR
+	"8063a9059cbb14602b57" + // 12: DUP1 PUSH4 transfer EQ PUSH1 0x2b JUMPI
+	"6370a082318114602d57" + // 1c: PUSH4 balanceOf DUP2 EQ PUSH1 0x2d JUMPI
+	"00" + // 26: STOP
+	"5b5f80fd" + // 27: JUMPDEST PUSH0 DUP1 REVERT
+	"5b00" + // 2b: JUMPDEST STOP
+	"5b00" // 2d: JUMPDEST STOP
+
+func TestDispatchTable(t *testing.T) {
+	got := DispatchTable(mustFromHex(t, erc20Dispatcher))
+	want := []DispatchEntry{
+		{Selector: [4]byte{0xa9, 0x05, 0x9c, 0xbb}, Target: 0x2b},
+		{Selector: [4]byte{0x70, 0xa0, 0x82, 0x31}, Target: 0x2d},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DispatchTable() = %v, want %v", got, want)
+	}
+}
+
+func TestDispatchTableUnresolvedTarget(t *testing.T) {
+	// DUP1 PUSH4 sel EQ ISZERO  <- no PUSH/JUMPI after the comparison
+	got := DispatchTable(mustFromHex(t, "8063deadbeef1415"))
+	want := []DispatchEntry{{Selector: [4]byte{0xde, 0xad, 0xbe, 0xef}, Target: -1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DispatchTable() = %v, want %v", got, want)
+	}
+}
+
+func TestDispatchTableShortPush(t *testing.T) {
+	// 00: DUP1 PUSH3 0x123456 EQ PUSH1 0x0a JUMPI  <- selector 0x00123456
+	// 09: STOP
+	// 0a: JUMPDEST STOP
+	got := DispatchTable(mustFromHex(t, "806212345614600a57"+"00"+"5b00"))
+	want := []DispatchEntry{{Selector: [4]byte{0x00, 0x12, 0x34, 0x56}, Target: 0x0a}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DispatchTable() = %v, want %v", got, want)
+	}
+}
+
+func TestSelectors(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want [][4]byte
+	}{
+		{
+			name: "dispatcher",
+			code: erc20Dispatcher,
+			want: [][4]byte{{0xa9, 0x05, 0x9c, 0xbb}, {0x70, 0xa0, 0x82, 0x31}},
+		},
+		{
+			// DUP1 PUSH4 x GT: binary-search pivot, not a selector
+			name: "pivot is ignored",
+			code: "8063a9059cbb11",
+			want: [][4]byte{},
+		},
+		{
+			name: "duplicates removed",
+			code: "8063a9059cbb14" + "8063a9059cbb14",
+			want: [][4]byte{{0xa9, 0x05, 0x9c, 0xbb}},
+		},
+		{
+			// DUP1 PUSH1 0x01 EQ ISZERO: short constant without a branch
+			name: "short push without jump",
+			code: "8060011415",
+			want: [][4]byte{},
+		},
+		{
+			// PUSH4 truncated by end of code
+			name: "truncated push",
+			code: "63a905",
+			want: [][4]byte{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Selectors(mustFromHex(t, tt.code))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Selectors() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ package bytes
 import (
 	"crypto/subtle"
 	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
 )
 
 // Errors returned by byte operations.
@@ -129,6 +131,13 @@ func Equal(a, b []byte) bool {
 	return subtle.ConstantTimeCompare(a, b) == 1
 }
 
+// ConstantTimeEqual compares two byte slices in constant time using the
+// native library's hardened comparison. Only the lengths may leak: slices
+// of different lengths compare unequal immediately.
+func ConstantTimeEqual(a, b []byte) bool {
+	return ffi.ConstantTimeEqual(a, b)
+}
+
 // IsZero returns true if all bytes are zero.
 // Returns true for nil or empty slices.
 func IsZero(b []byte) bool {
@@ -493,6 +493,9 @@ func TestEqual(t *testing.T) {
 			if gotReverse != tt.want {
 				t.Errorf("reverse: got %v, want %v", gotReverse, tt.want)
 			}
+			if got := ConstantTimeEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("ConstantTimeEqual: got %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
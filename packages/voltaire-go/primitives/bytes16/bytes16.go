@@ -0,0 +1,126 @@
+// Package bytes16 provides a fixed 16-byte array type.
+package bytes16
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Size is the size of Bytes16 in bytes.
+const Size = 16
+
+// Errors
+var (
+	ErrInvalidHex    = errors.New("bytes16: invalid hex string")
+	ErrInvalidLength = errors.New("bytes16: invalid length (expected 16 bytes)")
+)
+
+// Bytes16 represents a fixed 16-byte array.
+type Bytes16 [Size]byte
+
+// Zero is the zero value (all zeros).
+var Zero Bytes16
+
+// FromHex creates a Bytes16 from a hex string.
+// Accepts both "0x" prefixed and raw hex strings.
+func FromHex(s string) (Bytes16, error) {
+	// Strip 0x prefix
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+
+	// Must be exactly 32 hex chars
+	if len(s) != Size*2 {
+		return Bytes16{}, ErrInvalidLength
+	}
+
+	var b Bytes16
+	_, err := hex.Decode(b[:], []byte(s))
+	if err != nil {
+		return Bytes16{}, ErrInvalidHex
+	}
+
+	return b, nil
+}
+
+// FromBytes creates a Bytes16 from a byte slice.
+// Returns an error if the slice is not exactly 16 bytes.
+func FromBytes(data []byte) (Bytes16, error) {
+	if len(data) != Size {
+		return Bytes16{}, ErrInvalidLength
+	}
+
+	var b Bytes16
+	copy(b[:], data)
+	return b, nil
+}
+
+// MustFromHex creates a Bytes16 from a hex string, panicking on error.
+func MustFromHex(s string) Bytes16 {
+	b, err := FromHex(s)
+	if err != nil {
+		panic(fmt.Sprintf("bytes16.MustFromHex: %v", err))
+	}
+	return b
+}
+
+// Hex returns the lowercase hex representation with 0x prefix.
+func (b Bytes16) Hex() string {
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+// Bytes returns the bytes as a slice.
+func (b Bytes16) Bytes() []byte {
+	return b[:]
+}
+
+// IsZero returns true if all bytes are zero.
+func (b Bytes16) IsZero() bool {
+	return b == Zero
+}
+
+// Equal returns true if the values are equal.
+func (b Bytes16) Equal(other Bytes16) bool {
+	return b == other
+}
+
+// Compare compares two Bytes16 lexicographically.
+// Returns -1 if b < other, 0 if b == other, 1 if b > other.
+func (b Bytes16) Compare(other Bytes16) int {
+	return bytes.Compare(b[:], other[:])
+}
+
+// String returns the hex representation.
+func (b Bytes16) String() string {
+	return b.Hex()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b Bytes16) MarshalText() ([]byte, error) {
+	return []byte(b.Hex()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *Bytes16) UnmarshalText(text []byte) error {
+	parsed, err := FromHex(string(text))
+	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b Bytes16) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + b.Hex() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bytes16) UnmarshalJSON(data []byte) error {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return ErrInvalidHex
+	}
+	return b.UnmarshalText(data[1 : len(data)-1])
+}
@@ -0,0 +1,86 @@
+package bytes16
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestFromHex(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Bytes16
+		wantErr error
+	}{
+		{"with prefix", "0x000102030405060708090a0b0c0d0e0f", Bytes16{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}, nil},
+		{"without prefix", "000102030405060708090a0b0c0d0e0f", Bytes16{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}, nil},
+		{"uppercase", "0X000102030405060708090A0B0C0D0E0F", Bytes16{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}, nil},
+		{"too short", "0x000102030405060708090a0b0c0d0e", Bytes16{}, ErrInvalidLength},
+		{"too long", "0x000102030405060708090a0b0c0d0e0f00", Bytes16{}, ErrInvalidLength},
+		{"invalid char", "0x000102030405060708090a0b0c0d0e0g", Bytes16{}, ErrInvalidHex},
+		{"empty", "", Bytes16{}, ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromHex(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	if _, err := FromBytes(make([]byte, Size-1)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("error = %v, want ErrInvalidLength", err)
+	}
+	want := Bytes16{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+	b, err := FromBytes(want[:])
+	if err != nil || b != want {
+		t.Errorf("FromBytes() = %v, %v", b, err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := Bytes16{Size - 1: 1}
+	b := Bytes16{Size - 1: 2}
+
+	if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Error("Compare() returned wrong result")
+	}
+	if !a.Equal(a) || a.Equal(b) {
+		t.Error("Equal() returned wrong result")
+	}
+	if !Zero.IsZero() || a.IsZero() {
+		t.Error("IsZero() returned wrong result")
+	}
+}
+
+func TestJSONRoundtrip(t *testing.T) {
+	original := MustFromHex("0x000102030405060708090a0b0c0d0e0f")
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"0x000102030405060708090a0b0c0d0e0f"` {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+
+	var got Bytes16
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != original {
+		t.Errorf("got %s, want %s", got, original)
+	}
+
+	if err := json.Unmarshal([]byte(`"0x000102030405060708090a0b0c0d0e"`), &got); err == nil {
+		t.Error("expected error for short value")
+	}
+}
@@ -0,0 +1,155 @@
+// Package bytes4 provides a fixed 4-byte array type, used for function
+// selectors and error selectors.
+package bytes4
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+)
+
+// Size is the size of Bytes4 in bytes.
+const Size = 4
+
+// Errors
+var (
+	ErrInvalidHex    = errors.New("bytes4: invalid hex string")
+	ErrInvalidLength = errors.New("bytes4: invalid length (expected 4 bytes)")
+)
+
+// Bytes4 represents a fixed 4-byte array.
+type Bytes4 [Size]byte
+
+// Zero is the zero value (all zeros).
+var Zero Bytes4
+
+// FromHex creates a Bytes4 from a hex string.
+// Accepts both "0x" prefixed and raw hex strings.
+func FromHex(s string) (Bytes4, error) {
+	// Strip 0x prefix
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+
+	// Must be exactly 8 hex chars
+	if len(s) != Size*2 {
+		return Bytes4{}, ErrInvalidLength
+	}
+
+	var b Bytes4
+	_, err := hex.Decode(b[:], []byte(s))
+	if err != nil {
+		return Bytes4{}, ErrInvalidHex
+	}
+
+	return b, nil
+}
+
+// FromBytes creates a Bytes4 from a byte slice.
+// Returns an error if the slice is not exactly 4 bytes.
+func FromBytes(data []byte) (Bytes4, error) {
+	if len(data) != Size {
+		return Bytes4{}, ErrInvalidLength
+	}
+
+	var b Bytes4
+	copy(b[:], data)
+	return b, nil
+}
+
+// Selector returns the selector of a function or error signature: the
+// first 4 bytes of keccak256(signature), e.g. Selector("transfer(address,uint256)").
+// The signature must be canonical (no spaces or parameter names).
+func Selector(signature string) Bytes4 {
+	h := keccak256.HashString(signature)
+	var b Bytes4
+	copy(b[:], h[:Size])
+	return b
+}
+
+// FromCalldata returns the selector at the start of calldata.
+// Returns an error if calldata is shorter than 4 bytes.
+func FromCalldata(calldata []byte) (Bytes4, error) {
+	if len(calldata) < Size {
+		return Bytes4{}, ErrInvalidLength
+	}
+	var b Bytes4
+	copy(b[:], calldata[:Size])
+	return b, nil
+}
+
+// Matches returns true if calldata starts with the selector.
+func (b Bytes4) Matches(calldata []byte) bool {
+	return len(calldata) >= Size && bytes.Equal(calldata[:Size], b[:])
+}
+
+// MustFromHex creates a Bytes4 from a hex string, panicking on error.
+func MustFromHex(s string) Bytes4 {
+	b, err := FromHex(s)
+	if err != nil {
+		panic(fmt.Sprintf("bytes4.MustFromHex: %v", err))
+	}
+	return b
+}
+
+// Hex returns the lowercase hex representation with 0x prefix.
+func (b Bytes4) Hex() string {
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+// Bytes returns the bytes as a slice.
+func (b Bytes4) Bytes() []byte {
+	return b[:]
+}
+
+// IsZero returns true if all bytes are zero.
+func (b Bytes4) IsZero() bool {
+	return b == Zero
+}
+
+// Equal returns true if the values are equal.
+func (b Bytes4) Equal(other Bytes4) bool {
+	return b == other
+}
+
+// Compare compares two Bytes4 lexicographically.
+// Returns -1 if b < other, 0 if b == other, 1 if b > other.
+func (b Bytes4) Compare(other Bytes4) int {
+	return bytes.Compare(b[:], other[:])
+}
+
+// String returns the hex representation.
+func (b Bytes4) String() string {
+	return b.Hex()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b Bytes4) MarshalText() ([]byte, error) {
+	return []byte(b.Hex()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *Bytes4) UnmarshalText(text []byte) error {
+	parsed, err := FromHex(string(text))
+	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b Bytes4) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + b.Hex() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bytes4) UnmarshalJSON(data []byte) error {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return ErrInvalidHex
+	}
+	return b.UnmarshalText(data[1 : len(data)-1])
+}
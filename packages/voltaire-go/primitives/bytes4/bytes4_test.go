@@ -0,0 +1,125 @@
+package bytes4
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestFromHex(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Bytes4
+		wantErr error
+	}{
+		{"with prefix", "0xa9059cbb", Bytes4{0xa9, 0x05, 0x9c, 0xbb}, nil},
+		{"without prefix", "a9059cbb", Bytes4{0xa9, 0x05, 0x9c, 0xbb}, nil},
+		{"uppercase", "0XA9059CBB", Bytes4{0xa9, 0x05, 0x9c, 0xbb}, nil},
+		{"too short", "0xa9059c", Bytes4{}, ErrInvalidLength},
+		{"too long", "0xa9059cbb00", Bytes4{}, ErrInvalidLength},
+		{"invalid char", "0xa9059cbg", Bytes4{}, ErrInvalidHex},
+		{"empty", "", Bytes4{}, ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromHex(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	if _, err := FromBytes([]byte{1, 2, 3}); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("error = %v, want ErrInvalidLength", err)
+	}
+	b, err := FromBytes([]byte{1, 2, 3, 4})
+	if err != nil || b != (Bytes4{1, 2, 3, 4}) {
+		t.Errorf("FromBytes() = %v, %v", b, err)
+	}
+}
+
+func TestSelector(t *testing.T) {
+	tests := []struct {
+		signature string
+		want      string
+	}{
+		{"transfer(address,uint256)", "0xa9059cbb"},
+		{"balanceOf(address)", "0x70a08231"},
+		{"approve(address,uint256)", "0x095ea7b3"},
+		{"Error(string)", "0x08c379a0"},
+		{"Panic(uint256)", "0x4e487b71"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.signature, func(t *testing.T) {
+			if got := Selector(tt.signature).Hex(); got != tt.want {
+				t.Errorf("Selector() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromCalldata(t *testing.T) {
+	calldata := append(MustFromHex("0xa9059cbb").Bytes(), make([]byte, 64)...)
+
+	got, err := FromCalldata(calldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != Selector("transfer(address,uint256)") {
+		t.Errorf("FromCalldata() = %s", got)
+	}
+	if _, err := FromCalldata([]byte{0xa9, 0x05}); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("short calldata error = %v", err)
+	}
+
+	if !got.Matches(calldata) || got.Matches(calldata[:3]) || Selector("balanceOf(address)").Matches(calldata) {
+		t.Error("Matches() returned wrong result")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := Bytes4{0, 0, 0, 1}
+	b := Bytes4{0, 0, 0, 2}
+
+	if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Error("Compare() returned wrong result")
+	}
+	if !a.Equal(a) || a.Equal(b) {
+		t.Error("Equal() returned wrong result")
+	}
+	if !Zero.IsZero() || a.IsZero() {
+		t.Error("IsZero() returned wrong result")
+	}
+}
+
+func TestJSONRoundtrip(t *testing.T) {
+	original := Selector("transfer(address,uint256)")
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"0xa9059cbb"` {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+
+	var got Bytes4
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != original {
+		t.Errorf("got %s, want %s", got, original)
+	}
+
+	if err := json.Unmarshal([]byte(`"0xa905"`), &got); err == nil {
+		t.Error("expected error for short value")
+	}
+}
@@ -0,0 +1,126 @@
+// Package bytes8 provides a fixed 8-byte array type.
+package bytes8
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Size is the size of Bytes8 in bytes.
+const Size = 8
+
+// Errors
+var (
+	ErrInvalidHex    = errors.New("bytes8: invalid hex string")
+	ErrInvalidLength = errors.New("bytes8: invalid length (expected 8 bytes)")
+)
+
+// Bytes8 represents a fixed 8-byte array.
+type Bytes8 [Size]byte
+
+// Zero is the zero value (all zeros).
+var Zero Bytes8
+
+// FromHex creates a Bytes8 from a hex string.
+// Accepts both "0x" prefixed and raw hex strings.
+func FromHex(s string) (Bytes8, error) {
+	// Strip 0x prefix
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+
+	// Must be exactly 16 hex chars
+	if len(s) != Size*2 {
+		return Bytes8{}, ErrInvalidLength
+	}
+
+	var b Bytes8
+	_, err := hex.Decode(b[:], []byte(s))
+	if err != nil {
+		return Bytes8{}, ErrInvalidHex
+	}
+
+	return b, nil
+}
+
+// FromBytes creates a Bytes8 from a byte slice.
+// Returns an error if the slice is not exactly 8 bytes.
+func FromBytes(data []byte) (Bytes8, error) {
+	if len(data) != Size {
+		return Bytes8{}, ErrInvalidLength
+	}
+
+	var b Bytes8
+	copy(b[:], data)
+	return b, nil
+}
+
+// MustFromHex creates a Bytes8 from a hex string, panicking on error.
+func MustFromHex(s string) Bytes8 {
+	b, err := FromHex(s)
+	if err != nil {
+		panic(fmt.Sprintf("bytes8.MustFromHex: %v", err))
+	}
+	return b
+}
+
+// Hex returns the lowercase hex representation with 0x prefix.
+func (b Bytes8) Hex() string {
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+// Bytes returns the bytes as a slice.
+func (b Bytes8) Bytes() []byte {
+	return b[:]
+}
+
+// IsZero returns true if all bytes are zero.
+func (b Bytes8) IsZero() bool {
+	return b == Zero
+}
+
+// Equal returns true if the values are equal.
+func (b Bytes8) Equal(other Bytes8) bool {
+	return b == other
+}
+
+// Compare compares two Bytes8 lexicographically.
+// Returns -1 if b < other, 0 if b == other, 1 if b > other.
+func (b Bytes8) Compare(other Bytes8) int {
+	return bytes.Compare(b[:], other[:])
+}
+
+// String returns the hex representation.
+func (b Bytes8) String() string {
+	return b.Hex()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b Bytes8) MarshalText() ([]byte, error) {
+	return []byte(b.Hex()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *Bytes8) UnmarshalText(text []byte) error {
+	parsed, err := FromHex(string(text))
+	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b Bytes8) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + b.Hex() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bytes8) UnmarshalJSON(data []byte) error {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return ErrInvalidHex
+	}
+	return b.UnmarshalText(data[1 : len(data)-1])
+}
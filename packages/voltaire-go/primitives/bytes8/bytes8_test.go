@@ -0,0 +1,86 @@
+package bytes8
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestFromHex(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Bytes8
+		wantErr error
+	}{
+		{"with prefix", "0x0123456789abcdef", Bytes8{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}, nil},
+		{"without prefix", "0123456789abcdef", Bytes8{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}, nil},
+		{"uppercase", "0X0123456789ABCDEF", Bytes8{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}, nil},
+		{"too short", "0x0123456789abcd", Bytes8{}, ErrInvalidLength},
+		{"too long", "0x0123456789abcdef00", Bytes8{}, ErrInvalidLength},
+		{"invalid char", "0x0123456789abcdeg", Bytes8{}, ErrInvalidHex},
+		{"empty", "", Bytes8{}, ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromHex(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	if _, err := FromBytes(make([]byte, Size-1)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("error = %v, want ErrInvalidLength", err)
+	}
+	want := Bytes8{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}
+	b, err := FromBytes(want[:])
+	if err != nil || b != want {
+		t.Errorf("FromBytes() = %v, %v", b, err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := Bytes8{Size - 1: 1}
+	b := Bytes8{Size - 1: 2}
+
+	if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Error("Compare() returned wrong result")
+	}
+	if !a.Equal(a) || a.Equal(b) {
+		t.Error("Equal() returned wrong result")
+	}
+	if !Zero.IsZero() || a.IsZero() {
+		t.Error("IsZero() returned wrong result")
+	}
+}
+
+func TestJSONRoundtrip(t *testing.T) {
+	original := MustFromHex("0x0123456789abcdef")
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"0x0123456789abcdef"` {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+
+	var got Bytes8
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != original {
+		t.Errorf("got %s, want %s", got, original)
+	}
+
+	if err := json.Unmarshal([]byte(`"0x0123456789abcd"`), &got); err == nil {
+		t.Error("expected error for short value")
+	}
+}
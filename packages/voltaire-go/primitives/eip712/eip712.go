@@ -0,0 +1,118 @@
+// Package eip712 provides the building blocks of EIP-712 typed data hashing.
+//
+// The final digest is keccak256(0x19 || 0x01 || domainSeparator ||
+// hashStruct(message)), where hashStruct(s) = keccak256(typeHash ||
+// encodeData(s)). Each member of encodeData is one 32-byte word: atomic
+// values are ABI-encoded, while strings, bytes, arrays and nested structs
+// are replaced by their hash. Callers with a fixed message type assemble
+// the words directly and pass them to HashStruct.
+package eip712
+
+import (
+	"strings"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// Domain is an EIP-712 domain. Only the fields that are set are part of
+// the EIP712Domain type: an empty Name or Version, a zero ChainID or a nil
+// pointer is omitted.
+type Domain struct {
+	Name              string
+	Version           string
+	ChainID           uint64
+	VerifyingContract *address.Address
+	Salt              *hash.Hash
+}
+
+// EncodeType returns the EIP712Domain type string for the fields set in d.
+func (d Domain) EncodeType() string {
+	var fields []string
+	if d.Name != "" {
+		fields = append(fields, "string name")
+	}
+	if d.Version != "" {
+		fields = append(fields, "string version")
+	}
+	if d.ChainID != 0 {
+		fields = append(fields, "uint256 chainId")
+	}
+	if d.VerifyingContract != nil {
+		fields = append(fields, "address verifyingContract")
+	}
+	if d.Salt != nil {
+		fields = append(fields, "bytes32 salt")
+	}
+	return "EIP712Domain(" + strings.Join(fields, ",") + ")"
+}
+
+// Separator returns the domain separator: hashStruct(domain).
+func (d Domain) Separator() hash.Hash {
+	var words [][32]byte
+	if d.Name != "" {
+		words = append(words, [32]byte(keccak256.HashString(d.Name)))
+	}
+	if d.Version != "" {
+		words = append(words, [32]byte(keccak256.HashString(d.Version)))
+	}
+	if d.ChainID != 0 {
+		words = append(words, Uint64Word(d.ChainID))
+	}
+	if d.VerifyingContract != nil {
+		words = append(words, AddressWord(*d.VerifyingContract))
+	}
+	if d.Salt != nil {
+		words = append(words, [32]byte(*d.Salt))
+	}
+	return HashStruct(TypeHash(d.EncodeType()), words...)
+}
+
+// TypeHash returns keccak256 of an encoded type string such as
+// "Mail(Person from,Person to,string contents)Person(string name,address wallet)".
+func TypeHash(encodedType string) hash.Hash {
+	return keccak256.HashString(encodedType)
+}
+
+// HashStruct returns keccak256(typeHash || words...).
+func HashStruct(typeHash hash.Hash, words ...[32]byte) hash.Hash {
+	data := make([]byte, 0, 32*(len(words)+1))
+	data = append(data, typeHash[:]...)
+	for _, w := range words {
+		data = append(data, w[:]...)
+	}
+	return keccak256.Hash(data)
+}
+
+// Digest returns keccak256(0x19 || 0x01 || domainSeparator || structHash),
+// the hash that is signed.
+func Digest(domainSeparator, structHash hash.Hash) hash.Hash {
+	data := make([]byte, 0, 2+2*hash.Size)
+	data = append(data, 0x19, 0x01)
+	data = append(data, domainSeparator[:]...)
+	data = append(data, structHash[:]...)
+	return keccak256.Hash(data)
+}
+
+// AddressWord left-pads an address to a 32-byte word.
+func AddressWord(a address.Address) [32]byte {
+	var w [32]byte
+	copy(w[12:], a[:])
+	return w
+}
+
+// Uint64Word encodes n as a uint256 word.
+func Uint64Word(n uint64) [32]byte {
+	return [32]byte(u256.FromUint64(n))
+}
+
+// BoolWord encodes b as a word holding 0 or 1.
+func BoolWord(b bool) [32]byte {
+	var w [32]byte
+	if b {
+		w[31] = 1
+	}
+	return w
+}
@@ -0,0 +1,77 @@
+package eip712
+
+import (
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+// The Ether Mail example from EIP-712.
+func TestEtherMail(t *testing.T) {
+	verifier := address.MustFromHex("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC")
+	domain := Domain{Name: "Ether Mail", Version: "1", ChainID: 1, VerifyingContract: &verifier}
+
+	if got := domain.EncodeType(); got != "EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)" {
+		t.Errorf("EncodeType() = %s", got)
+	}
+	separator := domain.Separator()
+	if want := "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"; separator.Hex() != want {
+		t.Errorf("Separator() = %s, want %s", separator.Hex(), want)
+	}
+
+	personType := TypeHash("Person(string name,address wallet)")
+	person := func(name, wallet string) [32]byte {
+		return [32]byte(HashStruct(personType,
+			[32]byte(keccak256.HashString(name)),
+			AddressWord(address.MustFromHex(wallet)),
+		))
+	}
+
+	mail := HashStruct(
+		TypeHash("Mail(Person from,Person to,string contents)Person(string name,address wallet)"),
+		person("Cow", "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"),
+		person("Bob", "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"),
+		[32]byte(keccak256.HashString("Hello, Bob!")),
+	)
+	if want := "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"; mail.Hex() != want {
+		t.Errorf("HashStruct(mail) = %s, want %s", mail.Hex(), want)
+	}
+
+	if got, want := Digest(separator, mail).Hex(), "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"; got != want {
+		t.Errorf("Digest() = %s, want %s", got, want)
+	}
+}
+
+func TestDomainOptionalFields(t *testing.T) {
+	safe := address.MustFromHex("0x1111111111111111111111111111111111111111")
+	salt := hash.Hash{31: 1}
+
+	tests := []struct {
+		domain Domain
+		want   string
+	}{
+		{Domain{}, "EIP712Domain()"},
+		{Domain{ChainID: 1, VerifyingContract: &safe}, "EIP712Domain(uint256 chainId,address verifyingContract)"},
+		{Domain{Name: "x", Salt: &salt}, "EIP712Domain(string name,bytes32 salt)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.domain.EncodeType(); got != tt.want {
+			t.Errorf("EncodeType() = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	if w := BoolWord(true); w[31] != 1 || w != [32]byte(Uint64Word(1)) {
+		t.Errorf("BoolWord(true) = %x", w)
+	}
+	if BoolWord(false) != ([32]byte{}) {
+		t.Error("BoolWord(false) should be zero")
+	}
+	if w := Uint64Word(0x0102); w[30] != 1 || w[31] != 2 {
+		t.Errorf("Uint64Word = %x", w)
+	}
+}
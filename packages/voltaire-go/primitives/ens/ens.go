@@ -0,0 +1,109 @@
+// Package ens provides ENS name hashing and normalization.
+//
+// Namehash and LabelHash compute the node and label hashes used by the ENS
+// registry and resolvers. Names must be normalized before hashing; two
+// spellings of the same name only hash to the same node once normalized.
+//
+// Normalize and Beautify implement ENSIP-15 in full through the native
+// library: UTS-46 mapping, NFC, emoji sequences, script mixtures and
+// confusables.
+package ens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+// Errors
+//
+// The native normalizer does not report why a name was rejected. Empty
+// labels, misplaced underscores and label extensions are recognized and
+// reported as such; every other rejection (disallowed or confusable
+// characters, illegal script mixtures, misplaced combining marks) is
+// ErrDisallowedCharacter.
+var (
+	ErrEmptyLabel          = errors.New("ens: empty label")
+	ErrDisallowedCharacter = errors.New("ens: disallowed character")
+	ErrInvalidUnderscore   = errors.New("ens: underscore allowed only at start of label")
+	ErrInvalidLabelExt     = errors.New("ens: label has hyphens in third and fourth position")
+)
+
+// LabelHash returns keccak256(label) for a single normalized label.
+func LabelHash(label string) hash.Hash {
+	return keccak256.HashString(label)
+}
+
+// Namehash computes the EIP-137 node hash of a normalized name:
+//
+//	namehash("")          = 0x00...00
+//	namehash(label.rest)  = keccak256(namehash(rest) || keccak256(label))
+//
+// The name is hashed as given; call Normalize first for user input.
+func Namehash(name string) hash.Hash {
+	var node hash.Hash
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		label := LabelHash(labels[i])
+		node = keccak256.Sum(node[:], label[:])
+	}
+	return node
+}
+
+// Normalize applies ENSIP-15 normalization, returning the canonical form
+// to hash. The empty name (the root) is returned unchanged.
+func Normalize(name string) (string, error) {
+	return transform(name, ffi.ENSNormalize)
+}
+
+// Beautify normalizes name for display: like Normalize, but emoji keep
+// their presentation selectors. Hash the output of Normalize, not this.
+func Beautify(name string) (string, error) {
+	return transform(name, ffi.ENSBeautify)
+}
+
+// NormalizedNamehash normalizes name and returns its node hash.
+func NormalizedNamehash(name string) (hash.Hash, error) {
+	normalized, err := Normalize(name)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	return Namehash(normalized), nil
+}
+
+func transform(name string, native func(string) (string, error)) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	out, err := native(name)
+	if errors.Is(err, ffi.ErrInvalidInput) {
+		return "", rejection(name)
+	}
+	return out, err
+}
+
+// rejection explains why the native normalizer rejected name, as far as
+// the label structure allows.
+func rejection(name string) error {
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return fmt.Errorf("%w in %q", ErrEmptyLabel, name)
+		}
+		if trimmed := strings.TrimLeft(label, "_"); strings.Contains(trimmed, "_") {
+			return fmt.Errorf("%w in label %q", ErrInvalidUnderscore, label)
+		}
+		// The rule is about the third and fourth code points, not bytes
+		if r := []rune(label); len(r) >= 4 && r[2] == '-' && r[3] == '-' {
+			return fmt.Errorf("%w in label %q", ErrInvalidLabelExt, label)
+		}
+	}
+	return fmt.Errorf("%w in %q", ErrDisallowedCharacter, name)
+}
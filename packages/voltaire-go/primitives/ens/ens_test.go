@@ -0,0 +1,193 @@
+package ens
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+func TestNamehash(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"", "0x0000000000000000000000000000000000000000000000000000000000000000"},
+		{"eth", "0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae"},
+		{"foo.eth", "0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f"},
+		{"vitalik.eth", "0xee6c4522aab0003e8d14cd40a6af439055fd2577951148c14b6cea9a53475835"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Namehash(tt.name)
+			if got != hash.MustFromHex(tt.want) {
+				t.Errorf("Namehash(%q) = %s, want %s", tt.name, got.Hex(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelHash(t *testing.T) {
+	want := hash.MustFromHex("0x4f5b812789fc606be1b3b16908db13fc7a9adf7ca72641f84d75b47069d3d7f0")
+	if got := LabelHash("eth"); got != want {
+		t.Errorf("LabelHash(eth) = %s, want %s", got.Hex(), want.Hex())
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"vitalik.eth", "vitalik.eth", nil},
+		{"VitaLik.ETH", "vitalik.eth", nil},
+		{"my-name123.eth", "my-name123.eth", nil},
+		{"_dmarc.example.eth", "_dmarc.example.eth", nil},
+		{"__a.eth", "__a.eth", nil},
+		{"", "", nil},
+		{"a_b.eth", "", ErrInvalidUnderscore},
+		{"ab--cd.eth", "", ErrInvalidLabelExt},
+		{"xn--ls8h.eth", "", ErrInvalidLabelExt},
+		{"a--b.eth", "a--b.eth", nil},
+		{"foo..eth", "", ErrEmptyLabel},
+		{"foo.eth.", "", ErrEmptyLabel},
+		{"foo bar.eth", "", ErrDisallowedCharacter},
+		{"foo!.eth", "", ErrDisallowedCharacter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Normalize(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeUnicode(t *testing.T) {
+	// Cases from the ENSIP-15 normalization suite (ens-normalize tests.json)
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"Nick.ETH", "nick.eth", nil},
+		{"\uff21\uff22\uff23.eth", "abc.eth", nil},
+		{"caf\u00e9.eth", "caf\u00e9.eth", nil},
+		{"cafe\u0301.eth", "caf\u00e9.eth", nil},
+		{"stra\u00dfe.eth", "stra\u00dfe.eth", nil},
+		{"\U0001f4a9.eth", "\U0001f4a9.eth", nil},
+		{"\u2764\ufe0f.eth", "\u2764.eth", nil},
+		{"\U0001f468\u200d\U0001f4bb.eth", "\U0001f468\u200d\U0001f4bb.eth", nil},
+		{"\u0430\u0440\u0440\u04cf\u0435.eth", "", ErrDisallowedCharacter},
+		{"a\u03b1.eth", "", ErrDisallowedCharacter},
+		{"\u0300a.eth", "", ErrDisallowedCharacter},
+		{"a\u200db.eth", "", ErrDisallowedCharacter},
+		{"\u00e9_x.eth", "", ErrInvalidUnderscore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Normalize(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRejection(t *testing.T) {
+	tests := []struct {
+		name string
+		want error
+	}{
+		{"a..eth", ErrEmptyLabel},
+		{"a_b.eth", ErrInvalidUnderscore},
+		{"ab--cd.eth", ErrInvalidLabelExt},
+		{"\u00e9\u00e9--x.eth", ErrInvalidLabelExt},
+		// Bytes 2 and 3 are hyphens, code points 3 and 4 are not
+		{"\u00e9--x\u03b1.eth", ErrDisallowedCharacter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rejection(tt.name); !errors.Is(err, tt.want) {
+				t.Errorf("rejection(%q) = %v, want %v", tt.name, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBeautify(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Vitalik.ETH", "vitalik.eth"},
+		{"\u2764.eth", "\u2764\ufe0f.eth"},
+		{"\u2764\ufe0f.eth", "\u2764\ufe0f.eth"},
+		{"\U0001f4a9.eth", "\U0001f4a9.eth"},
+	}
+
+	for _, tt := range tests {
+		got, err := Beautify(tt.input)
+		if err != nil {
+			t.Fatalf("Beautify(%q) error = %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("Beautify(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+		// Beautified names normalize back to the hashed form
+		if a, b := mustNormalize(t, got), mustNormalize(t, tt.input); a != b {
+			t.Errorf("Normalize(Beautify(%q)) = %q, want %q", tt.input, a, b)
+		}
+	}
+
+	if _, err := Beautify("foo..eth"); !errors.Is(err, ErrEmptyLabel) {
+		t.Errorf("Beautify(foo..eth) error = %v, want %v", err, ErrEmptyLabel)
+	}
+}
+
+func mustNormalize(t *testing.T, name string) string {
+	t.Helper()
+	n, err := Normalize(name)
+	if err != nil {
+		t.Fatalf("Normalize(%q) error = %v", name, err)
+	}
+	return n
+}
+
+func TestNormalizedNamehash(t *testing.T) {
+	got, err := NormalizedNamehash("Vitalik.ETH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != Namehash("vitalik.eth") {
+		t.Errorf("NormalizedNamehash = %s, want namehash of vitalik.eth", got.Hex())
+	}
+
+	if _, err := NormalizedNamehash("foo..eth"); !errors.Is(err, ErrEmptyLabel) {
+		t.Errorf("error = %v, want ErrEmptyLabel", err)
+	}
+}
@@ -3,6 +3,7 @@ package hash
 
 import (
 	"bytes"
+	"database/sql/driver"
 	"fmt"
 
 	"github.com/voltaire-labs/voltaire-go/internal/ffi"
@@ -65,6 +66,12 @@ func (h Hash) Equal(other Hash) bool {
 	return ffi.HashEquals(h, other)
 }
 
+// ConstantTimeEqual reports whether a and b are equal using the native
+// constant-time comparison. Use it for secret values such as MACs.
+func ConstantTimeEqual(a, b Hash) bool {
+	return ffi.HashEquals(a, b)
+}
+
 // Compare compares two hashes lexicographically.
 func (h Hash) Compare(other Hash) int {
 	return bytes.Compare(h[:], other[:])
@@ -96,9 +103,42 @@ func (h Hash) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
+// Accepts 0x-prefixed hex per the JSON-RPC spec; null leaves the value
+// unchanged.
 func (h *Hash) UnmarshalJSON(data []byte) error {
-	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) < 4 || data[0] != '"' || data[len(data)-1] != '"' {
 		return ffi.ErrInvalidInput
 	}
+	if data[1] != '0' || (data[2] != 'x' && data[2] != 'X') {
+		return ffi.ErrInvalidHex
+	}
 	return h.UnmarshalText(data[1 : len(data)-1])
 }
+
+// Value implements driver.Valuer, storing the raw 32 bytes (e.g. a bytea column).
+func (h Hash) Value() (driver.Value, error) {
+	return h.Bytes(), nil
+}
+
+// Scan implements sql.Scanner.
+// Accepts raw 32-byte values and hex strings. NULL is an error rather
+// than the zero hash; scan nullable columns into sql.Null[Hash].
+func (h *Hash) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return fmt.Errorf("%w: NULL, use sql.Null[Hash] for nullable columns", ffi.ErrInvalidInput)
+	case []byte:
+		if len(v) == Size {
+			copy(h[:], v)
+			return nil
+		}
+		return h.UnmarshalText(v)
+	case string:
+		return h.UnmarshalText([]byte(v))
+	default:
+		return ffi.ErrUnsupportedType
+	}
+}
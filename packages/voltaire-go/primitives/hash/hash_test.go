@@ -1,8 +1,12 @@
 package hash
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
 )
 
 func TestFromHex(t *testing.T) {
@@ -58,6 +62,9 @@ func TestEqual(t *testing.T) {
 	if a.Equal(c) {
 		t.Error("different hashes should not be equal")
 	}
+	if !ConstantTimeEqual(a, b) || ConstantTimeEqual(a, c) {
+		t.Error("ConstantTimeEqual mismatch")
+	}
 }
 
 func TestIsZero(t *testing.T) {
@@ -88,3 +95,61 @@ func TestJSONRoundtrip(t *testing.T) {
 		t.Error("roundtrip failed")
 	}
 }
+
+func TestJSONUnmarshalRPC(t *testing.T) {
+	var h Hash
+	if err := json.Unmarshal([]byte(`null`), &h); err != nil {
+		t.Fatalf("Unmarshal(null) error: %v", err)
+	}
+	if !h.IsZero() {
+		t.Error("null should leave the hash unchanged")
+	}
+
+	if err := json.Unmarshal([]byte(`"c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"`), &h); err == nil {
+		t.Error("expected error for missing 0x prefix")
+	}
+}
+
+func TestSQLValueScan(t *testing.T) {
+	h := MustFromHex("0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470")
+
+	v, err := h.Value()
+	if err != nil {
+		t.Fatalf("Value error: %v", err)
+	}
+	raw, ok := v.([]byte)
+	if !ok || len(raw) != Size {
+		t.Fatalf("Value() = %T %v, want 32 raw bytes", v, v)
+	}
+
+	for _, src := range []interface{}{raw, h.Hex(), []byte(h.Hex())} {
+		var got Hash
+		if err := got.Scan(src); err != nil {
+			t.Fatalf("Scan(%v) error: %v", src, err)
+		}
+		if got != h {
+			t.Errorf("Scan(%v) = %s, want %s", src, got, h)
+		}
+	}
+
+	var got Hash
+	if err := got.Scan(nil); err == nil {
+		t.Error("expected error for NULL")
+	}
+}
+
+func TestSQLNull(t *testing.T) {
+	var h Hash
+	if err := h.Scan(nil); !errors.Is(err, ffi.ErrInvalidInput) {
+		t.Errorf("Scan(nil) error = %v, want %v", err, ffi.ErrInvalidInput)
+	}
+
+	var n sql.Null[Hash]
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Null.Scan(nil) = %+v, %v, want invalid", n, err)
+	}
+	want := MustFromHex("0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470")
+	if err := n.Scan(want.Hex()); err != nil || !n.Valid || n.V != want {
+		t.Errorf("Null.Scan(%s) = %+v, %v", want, n, err)
+	}
+}
@@ -0,0 +1,24 @@
+package hex
+
+import (
+	"strings"
+	"testing"
+)
+
+func FuzzDecodeStrict(f *testing.F) {
+	for _, s := range []string{"0x", "0x0", "0x01", "0xabc", "0x00ff", "1"} {
+		f.Add(s)
+	}
+	// Decoding accepts either case, so round trips compare case-insensitively
+	f.Fuzz(func(t *testing.T, s string) {
+		if b, err := DecodeData(s); err == nil && !strings.EqualFold(EncodeData(b), s) {
+			t.Errorf("EncodeData(DecodeData(%q)) = %q", s, EncodeData(b))
+		}
+		if v, err := DecodeBigQuantity(s); err == nil {
+			if enc, err := EncodeBigQuantity(v); err != nil || !strings.EqualFold(enc, s) {
+				t.Errorf("EncodeBigQuantity(DecodeBigQuantity(%q)) = %q, %v", s, enc, err)
+			}
+		}
+		DecodeQuantity(s)
+	})
+}
@@ -0,0 +1,164 @@
+package hex
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+// Strict encoding and decoding of JSON-RPC values.
+//
+// The JSON-RPC spec defines two hex encodings: quantities (integers) are
+// "0x" followed by the shortest hex representation, so zero is "0x0"; data
+// (byte arrays) is "0x" followed by two hex digits per byte. Decode is
+// lenient about both; the functions below reject anything else.
+
+// Errors
+var (
+	ErrEmpty          = errors.New("hex: empty input")
+	ErrMissingPrefix  = errors.New("hex: missing 0x prefix")
+	ErrEmptyQuantity  = errors.New("hex: quantity has no digits")
+	ErrLeadingZero    = errors.New("hex: quantity has leading zero digits")
+	ErrOddLength      = errors.New("hex: data has odd number of digits")
+	ErrInvalidChar    = errors.New("hex: invalid character")
+	ErrUint64Overflow = errors.New("hex: quantity exceeds 64 bits")
+	ErrNegative       = errors.New("hex: quantity is negative")
+)
+
+// EncodeQuantity encodes v as a JSON-RPC quantity.
+func EncodeQuantity(v uint64) string {
+	return "0x" + strconv.FormatUint(v, 16)
+}
+
+// EncodeBigQuantity encodes v as a JSON-RPC quantity. Quantities have no
+// sign, so a negative v returns ErrNegative; nil encodes as zero.
+func EncodeBigQuantity(v *big.Int) (string, error) {
+	if v == nil {
+		return "0x0", nil
+	}
+	if v.Sign() < 0 {
+		return "", ErrNegative
+	}
+	return "0x" + v.Text(16), nil
+}
+
+// DecodeQuantity decodes a JSON-RPC quantity that fits in 64 bits.
+func DecodeQuantity(s string) (uint64, error) {
+	v, err := DecodeBigQuantity(s)
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsUint64() {
+		return 0, ErrUint64Overflow
+	}
+	return v.Uint64(), nil
+}
+
+// DecodeBigQuantity decodes a JSON-RPC quantity of any size.
+func DecodeBigQuantity(s string) (*big.Int, error) {
+	digits, err := checkQuantity(s)
+	if err != nil {
+		return nil, err
+	}
+	v, _ := new(big.Int).SetString(digits, 16)
+	return v, nil
+}
+
+// MustDecodeQuantity decodes a JSON-RPC quantity, panicking on error.
+func MustDecodeQuantity(s string) uint64 {
+	v, err := DecodeQuantity(s)
+	if err != nil {
+		panic("hex.MustDecodeQuantity: " + err.Error())
+	}
+	return v
+}
+
+// EncodeData encodes data as JSON-RPC data. It is the same as Encode.
+func EncodeData(data []byte) string {
+	return Encode(data)
+}
+
+// DecodeData decodes JSON-RPC data. The input must have a 0x prefix and an
+// even number of digits; "0x" decodes to an empty slice.
+func DecodeData(s string) ([]byte, error) {
+	digits, err := checkPrefix(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(digits)%2 != 0 {
+		return nil, ErrOddLength
+	}
+
+	out := make([]byte, len(digits)/2)
+	for i := range out {
+		hi, ok1 := nibble(digits[2*i])
+		lo, ok2 := nibble(digits[2*i+1])
+		if !ok1 || !ok2 {
+			return nil, ErrInvalidChar
+		}
+		out[i] = hi<<4 | lo
+	}
+	return out, nil
+}
+
+// MustDecodeData decodes JSON-RPC data, panicking on error.
+func MustDecodeData(s string) []byte {
+	b, err := DecodeData(s)
+	if err != nil {
+		panic("hex.MustDecodeData: " + err.Error())
+	}
+	return b
+}
+
+// IsQuantity returns true if s is a valid JSON-RPC quantity.
+func IsQuantity(s string) bool {
+	_, err := checkQuantity(s)
+	return err == nil
+}
+
+// IsData returns true if s is valid JSON-RPC data.
+func IsData(s string) bool {
+	_, err := DecodeData(s)
+	return err == nil
+}
+
+func checkPrefix(s string) (string, error) {
+	if s == "" {
+		return "", ErrEmpty
+	}
+	if !HasPrefix(s) {
+		return "", ErrMissingPrefix
+	}
+	return s[2:], nil
+}
+
+func checkQuantity(s string) (string, error) {
+	digits, err := checkPrefix(s)
+	if err != nil {
+		return "", err
+	}
+	if digits == "" {
+		return "", ErrEmptyQuantity
+	}
+	for i := 0; i < len(digits); i++ {
+		if _, ok := nibble(digits[i]); !ok {
+			return "", ErrInvalidChar
+		}
+	}
+	if len(digits) > 1 && digits[0] == '0' {
+		return "", ErrLeadingZero
+	}
+	return digits, nil
+}
+
+func nibble(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
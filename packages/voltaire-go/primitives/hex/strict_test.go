@@ -0,0 +1,125 @@
+package hex
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestEncodeQuantity(t *testing.T) {
+	tests := []struct {
+		input uint64
+		want  string
+	}{
+		{0, "0x0"},
+		{1, "0x1"},
+		{0x41, "0x41"},
+		{0x400, "0x400"},
+		{1<<64 - 1, "0xffffffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		if got := EncodeQuantity(tt.input); got != tt.want {
+			t.Errorf("EncodeQuantity(%d) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+
+	big256, _ := new(big.Int).SetString("10000000000000000000000000000000000000000000000000000000000000000", 16)
+	if got, err := EncodeBigQuantity(big256); err != nil || got != "0x10000000000000000000000000000000000000000000000000000000000000000" {
+		t.Errorf("EncodeBigQuantity(2^256) = %s, %v", got, err)
+	}
+	if got, err := EncodeBigQuantity(nil); err != nil || got != "0x0" {
+		t.Errorf("EncodeBigQuantity(nil) = %s, %v, want 0x0", got, err)
+	}
+	if got, err := EncodeBigQuantity(big.NewInt(-1)); !errors.Is(err, ErrNegative) {
+		t.Errorf("EncodeBigQuantity(-1) = %s, %v, want %v", got, err, ErrNegative)
+	}
+}
+
+func TestDecodeQuantity(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    uint64
+		wantErr error
+	}{
+		{"0x0", 0, nil},
+		{"0x41", 0x41, nil},
+		{"0x400", 0x400, nil},
+		{"0xABcd", 0xabcd, nil},
+		{"0xffffffffffffffff", 1<<64 - 1, nil},
+		{"", 0, ErrEmpty},
+		{"0x", 0, ErrEmptyQuantity},
+		{"41", 0, ErrMissingPrefix},
+		{"0x0400", 0, ErrLeadingZero},
+		{"0x00", 0, ErrLeadingZero},
+		{"0xfg", 0, ErrInvalidChar},
+		{"0x10000000000000000", 0, ErrUint64Overflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := DecodeQuantity(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeData(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []byte
+		wantErr error
+	}{
+		{"0x", []byte{}, nil},
+		{"0x00", []byte{0x00}, nil},
+		{"0x004200", []byte{0x00, 0x42, 0x00}, nil},
+		{"0xDEADbeef", []byte{0xde, 0xad, 0xbe, 0xef}, nil},
+		{"", nil, ErrEmpty},
+		{"0042", nil, ErrMissingPrefix},
+		{"0x0", nil, ErrOddLength},
+		{"0xzz", nil, ErrInvalidChar},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := DecodeData(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("got %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidation(t *testing.T) {
+	if !IsQuantity("0x1") || IsQuantity("0x01") || IsQuantity("0x") {
+		t.Error("IsQuantity returned wrong result")
+	}
+	if !IsData("0x") || !IsData("0x0001") || IsData("0x1") {
+		t.Error("IsData returned wrong result")
+	}
+}
+
+func TestMustDecodeStrictPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"quantity": func() { MustDecodeQuantity("0x01") },
+		"data":     func() { MustDecodeData("0x1") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			f()
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Package labels maps addresses to human-readable names, so known
+// contracts can be printed as "WETH" or "EntryPoint" instead of bare hex.
+//
+// A Registry can be filled by hand, from a JSON object of names to
+// addresses, or from the broadcast files Foundry writes for deployment
+// scripts.
+package labels
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+)
+
+// Errors
+var (
+	ErrEmptyName = errors.New("labels: empty name")
+)
+
+// Registry is a two-way mapping between addresses and names. Each address
+// has at most one name and each name at most one address. It is safe for
+// concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	names map[address.Address]string
+	addrs map[string]address.Address
+}
+
+// New returns an empty registry.
+func New() *Registry {
+	return &Registry{
+		names: make(map[address.Address]string),
+		addrs: make(map[string]address.Address),
+	}
+}
+
+// Set labels addr with name, replacing any previous label of addr and
+// any previous address labelled name.
+func (r *Registry) Set(addr address.Address, name string) error {
+	if name == "" {
+		return ErrEmptyName
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.names[addr]; ok {
+		delete(r.addrs, old)
+	}
+	if old, ok := r.addrs[name]; ok {
+		delete(r.names, old)
+	}
+	r.names[addr] = name
+	r.addrs[name] = addr
+	return nil
+}
+
+// Name returns the label of addr.
+func (r *Registry) Name(addr address.Address) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.names[addr]
+	return name, ok
+}
+
+// Address returns the address labelled name.
+func (r *Registry) Address(name string) (address.Address, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	addr, ok := r.addrs[name]
+	return addr, ok
+}
+
+// Format returns the label of addr, or its checksummed hex if it has none.
+func (r *Registry) Format(addr address.Address) string {
+	if name, ok := r.Name(addr); ok {
+		return name
+	}
+	return addr.ChecksumHex()
+}
+
+// Len returns the number of labelled addresses.
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.names)
+}
+
+// LoadJSON adds the labels of a JSON object mapping names to addresses,
+// such as {"WETH": "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"}.
+func (r *Registry) LoadJSON(rd io.Reader) error {
+	var entries map[string]address.Address
+	if err := json.NewDecoder(rd).Decode(&entries); err != nil {
+		return err
+	}
+	for name, addr := range entries {
+		if err := r.Set(addr, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// broadcast is the subset of a Foundry broadcast file (for example
+// broadcast/Deploy.s.sol/1/run-latest.json) that carries contract names.
+type broadcast struct {
+	Transactions []struct {
+		ContractName    string           `json:"contractName"`
+		ContractAddress *address.Address `json:"contractAddress"`
+	} `json:"transactions"`
+}
+
+// LoadFoundryBroadcast adds a label for every named contract deployed or
+// called in a Foundry broadcast file. Later transactions take precedence.
+func (r *Registry) LoadFoundryBroadcast(rd io.Reader) error {
+	var b broadcast
+	if err := json.NewDecoder(rd).Decode(&b); err != nil {
+		return err
+	}
+	for _, tx := range b.Transactions {
+		if tx.ContractName == "" || tx.ContractAddress == nil {
+			continue
+		}
+		if err := r.Set(*tx.ContractAddress, tx.ContractName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
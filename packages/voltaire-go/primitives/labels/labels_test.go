@@ -0,0 +1,104 @@
+package labels
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+)
+
+var (
+	weth  = address.MustFromHex("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	entry = address.MustFromHex("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+	other = address.MustFromHex("0x1111111111111111111111111111111111111111")
+)
+
+func TestRegistry(t *testing.T) {
+	r := New()
+	if err := r.Set(weth, "WETH"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := r.Format(weth); got != "WETH" {
+		t.Errorf("Format(weth) = %s", got)
+	}
+	if got := r.Format(other); got != other.ChecksumHex() {
+		t.Errorf("Format(unlabelled) = %s, want checksummed hex", got)
+	}
+	if got, ok := r.Address("WETH"); !ok || got != weth {
+		t.Errorf("Address(WETH) = %s, %v", got.Hex(), ok)
+	}
+
+	// Relabelling an address frees its old name
+	r.Set(weth, "WrappedEther")
+	if _, ok := r.Address("WETH"); ok {
+		t.Error("old name still resolves")
+	}
+
+	// Moving a name to another address unlabels the old one
+	r.Set(other, "WrappedEther")
+	if _, ok := r.Name(weth); ok {
+		t.Error("old address still labelled")
+	}
+	if r.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", r.Len())
+	}
+
+	if err := r.Set(weth, ""); !errors.Is(err, ErrEmptyName) {
+		t.Errorf("Set(empty) error = %v, want %v", err, ErrEmptyName)
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	r := New()
+	err := r.LoadJSON(strings.NewReader(`{
+		"WETH": "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+		"EntryPoint": "0x0000000071727De22E5E9d8BAf0edAc6f37da032"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Format(weth) != "WETH" || r.Format(entry) != "EntryPoint" {
+		t.Errorf("labels = %s, %s", r.Format(weth), r.Format(entry))
+	}
+
+	if err := New().LoadJSON(strings.NewReader(`{"Bad": "0x1234"}`)); err == nil {
+		t.Error("expected error for invalid address")
+	}
+}
+
+func TestLoadFoundryBroadcast(t *testing.T) {
+	const run = `{
+		"transactions": [
+			{
+				"hash": "0xabc",
+				"transactionType": "CREATE",
+				"contractName": "Counter",
+				"contractAddress": "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+				"function": null
+			},
+			{
+				"hash": "0xdef",
+				"transactionType": "CALL",
+				"contractName": null,
+				"contractAddress": "0x1111111111111111111111111111111111111111",
+				"function": "increment()"
+			}
+		],
+		"receipts": [],
+		"chain": 31337
+	}`
+
+	r := New()
+	if err := r.LoadFoundryBroadcast(strings.NewReader(run)); err != nil {
+		t.Fatal(err)
+	}
+	counter := address.MustFromHex("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	if got := r.Format(counter); got != "Counter" {
+		t.Errorf("Format(counter) = %s", got)
+	}
+	if r.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 (unnamed call skipped)", r.Len())
+	}
+}
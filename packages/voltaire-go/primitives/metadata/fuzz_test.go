@@ -0,0 +1,13 @@
+package metadata
+
+import "testing"
+
+func FuzzFromBytecode(f *testing.F) {
+	f.Add([]byte{0x00, 0x00})
+	f.Add([]byte{0xa1, 0x64, 's', 'o', 'l', 'c', 0x43, 0x00, 0x08, 0x18, 0x00, 0x0a})
+	f.Fuzz(func(t *testing.T, code []byte) {
+		FromBytecode(code)
+		Decode(code)
+		Strip(code)
+	})
+}
@@ -0,0 +1,255 @@
+// Package metadata parses the Solidity compiler metadata trailer.
+//
+// solc appends a CBOR-encoded map to runtime bytecode, followed by a 2-byte
+// big-endian length of that map:
+//
+//	runtime code || cbor map || uint16 length
+//
+// The map usually holds the IPFS (or legacy Swarm) hash of the metadata JSON
+// and the compiler version. Deployed code can be compared against compiled
+// artifacts by stripping this trailer first, since the hash changes whenever
+// the sources or settings do.
+package metadata
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// LengthSize is the size of the trailing length suffix in bytes.
+const LengthSize = 2
+
+// Errors
+var (
+	ErrNoMetadata  = errors.New("metadata: no CBOR metadata trailer")
+	ErrInvalidCBOR = errors.New("metadata: invalid CBOR encoding")
+)
+
+// Metadata holds the decoded fields of a solc metadata trailer.
+type Metadata struct {
+	// Raw is the CBOR-encoded map, without the length suffix.
+	Raw []byte
+	// IPFS is the multihash of the metadata JSON (0x1220 || sha256).
+	IPFS []byte
+	// Bzzr0 is the legacy Swarm hash used by solc < 0.5.12.
+	Bzzr0 []byte
+	// Bzzr1 is the Swarm hash used by solc 0.5.12 to 0.6.x.
+	Bzzr1 []byte
+	// Solc is the compiler version, e.g. "0.8.19".
+	// Release builds encode three bytes; prereleases encode a full string.
+	Solc string
+	// Experimental is set when experimental features were enabled.
+	Experimental bool
+}
+
+// HasMetadata returns true if code ends with a decodable metadata trailer.
+func HasMetadata(code []byte) bool {
+	_, _, err := Split(code)
+	return err == nil
+}
+
+// Split separates code into the executable part and the CBOR trailer.
+// The returned trailer excludes the 2-byte length suffix. Code without a
+// well-formed trailer returns ErrNoMetadata.
+func Split(code []byte) (body, trailer []byte, err error) {
+	if len(code) < LengthSize+1 {
+		return nil, nil, ErrNoMetadata
+	}
+
+	n := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	if n == 0 {
+		return nil, nil, ErrNoMetadata
+	}
+	// Plain code usually ends in a length that overruns it
+	if n+LengthSize > len(code) {
+		return nil, nil, ErrNoMetadata
+	}
+
+	start := len(code) - LengthSize - n
+	trailer = code[start : len(code)-LengthSize]
+
+	// The trailer must be a single well-formed CBOR map
+	if _, err := Decode(trailer); err != nil {
+		return nil, nil, ErrNoMetadata
+	}
+
+	return code[:start], trailer, nil
+}
+
+// FromBytecode extracts and decodes the metadata trailer from code.
+func FromBytecode(code []byte) (Metadata, error) {
+	_, trailer, err := Split(code)
+	if err != nil {
+		return Metadata{}, err
+	}
+	return Decode(trailer)
+}
+
+// Strip returns code without its metadata trailer.
+// If code has no trailer, a copy of code is returned unchanged.
+func Strip(code []byte) []byte {
+	body, _, err := Split(code)
+	if err != nil {
+		body = code
+	}
+	result := make([]byte, len(body))
+	copy(result, body)
+	return result
+}
+
+// EqualIgnoringMetadata returns true if a and b are identical once their
+// metadata trailers are removed. This is the usual check when verifying
+// deployed code against a compiled artifact.
+func EqualIgnoringMetadata(a, b []byte) bool {
+	return bytes.Equal(Strip(a), Strip(b))
+}
+
+// Decode parses a CBOR-encoded solc metadata map.
+// Unknown keys are skipped.
+func Decode(raw []byte) (Metadata, error) {
+	d := decoder{data: raw}
+
+	major, count, err := d.head()
+	if err != nil {
+		return Metadata{}, err
+	}
+	if major != majorMap {
+		return Metadata{}, ErrInvalidCBOR
+	}
+
+	m := Metadata{Raw: raw}
+	for i := uint64(0); i < count; i++ {
+		keyMajor, keyLen, err := d.head()
+		if err != nil {
+			return Metadata{}, err
+		}
+		if keyMajor != majorText {
+			return Metadata{}, ErrInvalidCBOR
+		}
+		key, err := d.take(keyLen)
+		if err != nil {
+			return Metadata{}, err
+		}
+
+		valMajor, valArg, err := d.head()
+		if err != nil {
+			return Metadata{}, err
+		}
+
+		var val []byte
+		switch valMajor {
+		case majorBytes, majorText:
+			val, err = d.take(valArg)
+			if err != nil {
+				return Metadata{}, err
+			}
+		case majorUint, majorSimple:
+			// Argument already consumed by head
+		default:
+			return Metadata{}, ErrInvalidCBOR
+		}
+
+		switch string(key) {
+		case "ipfs":
+			m.IPFS = val
+		case "bzzr0":
+			m.Bzzr0 = val
+		case "bzzr1":
+			m.Bzzr1 = val
+		case "solc":
+			if valMajor == majorBytes && len(val) == 3 {
+				m.Solc = fmt.Sprintf("%d.%d.%d", val[0], val[1], val[2])
+			} else {
+				m.Solc = string(val)
+			}
+		case "experimental":
+			m.Experimental = valMajor == majorSimple && valArg == simpleTrue
+		}
+	}
+
+	if d.pos != len(raw) {
+		return Metadata{}, ErrInvalidCBOR
+	}
+	return m, nil
+}
+
+// IPFSHex returns the IPFS multihash as hex with 0x prefix, or "" if absent.
+func (m Metadata) IPFSHex() string {
+	if m.IPFS == nil {
+		return ""
+	}
+	return "0x" + hex.EncodeToString(m.IPFS)
+}
+
+// SwarmHex returns the Swarm hash (bzzr1, falling back to bzzr0) as hex
+// with 0x prefix, or "" if absent.
+func (m Metadata) SwarmHex() string {
+	switch {
+	case m.Bzzr1 != nil:
+		return "0x" + hex.EncodeToString(m.Bzzr1)
+	case m.Bzzr0 != nil:
+		return "0x" + hex.EncodeToString(m.Bzzr0)
+	default:
+		return ""
+	}
+}
+
+// CBOR major types used by solc metadata
+const (
+	majorUint   = 0
+	majorBytes  = 2
+	majorText   = 3
+	majorMap    = 5
+	majorSimple = 7
+)
+
+// simpleTrue is the CBOR simple value for true (0xf5).
+const simpleTrue = 21
+
+// decoder is a minimal CBOR reader covering the subset solc emits.
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+// head reads an item header and returns its major type and argument.
+func (d *decoder) head() (major byte, arg uint64, err error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, ErrInvalidCBOR
+	}
+	b := d.data[d.pos]
+	d.pos++
+
+	major = b >> 5
+	info := b & 0x1f
+
+	switch {
+	case info < 24:
+		return major, uint64(info), nil
+	case info <= 27:
+		n := 1 << (info - 24)
+		raw, err := d.take(uint64(n))
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, v := range raw {
+			arg = arg<<8 | uint64(v)
+		}
+		return major, arg, nil
+	default:
+		// Indefinite lengths and reserved values are never emitted by solc
+		return 0, 0, ErrInvalidCBOR
+	}
+}
+
+// take consumes n bytes.
+func (d *decoder) take(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, ErrInvalidCBOR
+	}
+	out := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return out, nil
+}
@@ -0,0 +1,166 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// solc 0.8.19 trailer: {"ipfs": <34 bytes>, "solc": 0x000813} || 0x0033
+const ipfsTrailer = "a26469706673582212" +
+	"20" + "1f6a3a1a2e9e8c0f6ad5a1c1c6b3f1e0d4b2b7b9f4e9a8d6c2b4a5e7f3c1d2e3" +
+	"64736f6c6343000813" + "0033"
+
+// solc 0.5.17 trailer: {"bzzr1": <32 bytes>, "solc": 0x000511} || 0x0032
+const bzzr1Trailer = "a265627a7a72315820" +
+	"0a5b3c7d1e2f4a6b8c9d0e1f2a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d" +
+	"64736f6c6343000511" + "0032"
+
+// Arbitrary runtime code: PUSH1 0x80 PUSH1 0x40 MSTORE STOP INVALID
+const runtimeCode = "6080604052" + "00" + "fe"
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("bad test hex: %v", err)
+	}
+	return b
+}
+
+func TestFromBytecode(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		wantSolc  string
+		wantIPFS  string
+		wantSwarm string
+	}{
+		{
+			name:     "ipfs trailer",
+			code:     runtimeCode + ipfsTrailer,
+			wantSolc: "0.8.19",
+			wantIPFS: "0x12201f6a3a1a2e9e8c0f6ad5a1c1c6b3f1e0d4b2b7b9f4e9a8d6c2b4a5e7f3c1d2e3",
+		},
+		{
+			name:      "bzzr1 trailer",
+			code:      runtimeCode + bzzr1Trailer,
+			wantSolc:  "0.5.17",
+			wantSwarm: "0x0a5b3c7d1e2f4a6b8c9d0e1f2a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := FromBytecode(mustDecodeHex(t, tt.code))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if m.Solc != tt.wantSolc {
+				t.Errorf("Solc = %q, want %q", m.Solc, tt.wantSolc)
+			}
+			if got := m.IPFSHex(); got != tt.wantIPFS {
+				t.Errorf("IPFSHex() = %s, want %s", got, tt.wantIPFS)
+			}
+			if got := m.SwarmHex(); got != tt.wantSwarm {
+				t.Errorf("SwarmHex() = %s, want %s", got, tt.wantSwarm)
+			}
+		})
+	}
+}
+
+func TestFromBytecodeNoMetadata(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+	}{
+		{"empty", ""},
+		{"plain code", runtimeCode},
+		{"length exceeds code", "00ff"},
+		{"plain code ending in a large length", "608060405200"},
+		{"zero length", "60800000"},
+		{"trailer is not a map", "6080" + "4100" + "0002"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := mustDecodeHex(t, tt.code)
+			if _, err := FromBytecode(code); !errors.Is(err, ErrNoMetadata) {
+				t.Errorf("FromBytecode() error = %v, want %v", err, ErrNoMetadata)
+			}
+			if HasMetadata(code) {
+				t.Error("HasMetadata() = true, want false")
+			}
+		})
+	}
+}
+
+func TestDecodePrereleaseAndExperimental(t *testing.T) {
+	// {"solc": "0.4.26-nightly", "experimental": true}
+	raw := mustDecodeHex(t, "a2"+
+		"64736f6c63"+"6e"+hex.EncodeToString([]byte("0.4.26-nightly"))+
+		"6c"+hex.EncodeToString([]byte("experimental"))+"f5")
+
+	m, err := Decode(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Solc != "0.4.26-nightly" {
+		t.Errorf("Solc = %q, want 0.4.26-nightly", m.Solc)
+	}
+	if !m.Experimental {
+		t.Error("Experimental = false, want true")
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"empty", ""},
+		{"not a map", "80"},
+		{"truncated key", "a16469"},
+		{"non-text key", "a1010203"},
+		{"truncated value", "a16469706673582212"},
+		{"trailing bytes", "a000"},
+		{"indefinite length", "bf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(mustDecodeHex(t, tt.raw)); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestStrip(t *testing.T) {
+	code := mustDecodeHex(t, runtimeCode+ipfsTrailer)
+	want := mustDecodeHex(t, runtimeCode)
+
+	got := Strip(code)
+	if !bytes.Equal(got, want) {
+		t.Errorf("Strip() = %x, want %x", got, want)
+	}
+
+	// No trailer: returned unchanged
+	if got := Strip(want); !bytes.Equal(got, want) {
+		t.Errorf("Strip() without trailer = %x, want %x", got, want)
+	}
+}
+
+func TestEqualIgnoringMetadata(t *testing.T) {
+	a := mustDecodeHex(t, runtimeCode+ipfsTrailer)
+	b := mustDecodeHex(t, runtimeCode+bzzr1Trailer)
+	c := mustDecodeHex(t, "6080604052"+ipfsTrailer)
+
+	if !EqualIgnoringMetadata(a, b) {
+		t.Error("same code with different trailers should be equal")
+	}
+	if EqualIgnoringMetadata(a, c) {
+		t.Error("different code should not be equal")
+	}
+}
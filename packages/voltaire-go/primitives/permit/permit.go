@@ -0,0 +1,94 @@
+// Package permit builds the EIP-712 digests for gasless token approvals.
+//
+// ERC-2612 permits approve an exact value until a deadline. DAI-style
+// permits, used by DAI and a few tokens that copied it, approve either
+// unlimited spending or none, and identify the owner as "holder".
+package permit
+
+import (
+	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/eip712"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/signature"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// Type hashes of the two permit variants.
+var (
+	PermitTypeHash    = eip712.TypeHash("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)")
+	DaiPermitTypeHash = eip712.TypeHash("Permit(address holder,address spender,uint256 nonce,uint256 expiry,bool allowed)")
+)
+
+// Errors
+var (
+	ErrInvalidSignatureLength = errors.New("permit: signature must be 64 or 65 bytes")
+	ErrInvalidV               = errors.New("permit: invalid v")
+)
+
+// Permit is an ERC-2612 permit.
+type Permit struct {
+	Owner    address.Address
+	Spender  address.Address
+	Value    u256.U256
+	Nonce    u256.U256
+	Deadline u256.U256
+}
+
+// StructHash returns hashStruct(permit).
+func (p Permit) StructHash() hash.Hash {
+	return eip712.HashStruct(PermitTypeHash,
+		eip712.AddressWord(p.Owner),
+		eip712.AddressWord(p.Spender),
+		[32]byte(p.Value),
+		[32]byte(p.Nonce),
+		[32]byte(p.Deadline),
+	)
+}
+
+// Digest returns the hash the owner signs for the token's domain.
+func (p Permit) Digest(domain eip712.Domain) hash.Hash {
+	return eip712.Digest(domain.Separator(), p.StructHash())
+}
+
+// DaiPermit is a DAI-style permit.
+type DaiPermit struct {
+	Holder  address.Address
+	Spender address.Address
+	Nonce   u256.U256
+	// Expiry of zero means the permit never expires.
+	Expiry  u256.U256
+	Allowed bool
+}
+
+// StructHash returns hashStruct(permit).
+func (p DaiPermit) StructHash() hash.Hash {
+	return eip712.HashStruct(DaiPermitTypeHash,
+		eip712.AddressWord(p.Holder),
+		eip712.AddressWord(p.Spender),
+		[32]byte(p.Nonce),
+		[32]byte(p.Expiry),
+		eip712.BoolWord(p.Allowed),
+	)
+}
+
+// Digest returns the hash the holder signs for the token's domain.
+func (p DaiPermit) Digest(domain eip712.Domain) hash.Hash {
+	return eip712.Digest(domain.Separator(), p.StructHash())
+}
+
+// SplitSignature splits a signature into the v, r and s arguments taken by
+// permit(). It accepts a 65-byte r || s || v signature with v of 0, 1, 27
+// or 28, or a 64-byte EIP-2098 compact signature. v is returned as 27 or
+// 28, as ecrecover expects.
+func SplitSignature(sig []byte) (v uint8, r, s [32]byte, err error) {
+	if len(sig) != signature.Size && len(sig) != signature.CompactSize {
+		return 0, r, s, ErrInvalidSignatureLength
+	}
+	parsed, _ := signature.Parse(sig)
+	if parsed.V > 1 && parsed.V != 27 && parsed.V != 28 {
+		return 0, r, s, ErrInvalidV
+	}
+	return 27 + parsed.YParity(), parsed.R, parsed.S, nil
+}
@@ -0,0 +1,124 @@
+package permit
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/eip712"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+var (
+	owner   = address.MustFromHex("0x1111111111111111111111111111111111111111")
+	spender = address.MustFromHex("0x2222222222222222222222222222222222222222")
+)
+
+func TestTypeHashes(t *testing.T) {
+	// PERMIT_TYPEHASH constants from OpenZeppelin ERC20Permit and DAI
+	if got, want := PermitTypeHash.Hex(), "0x6e71edae12b1b97f4d1f60370fef10105fa2faae0126114a169c64845d6126c9"; got != want {
+		t.Errorf("PermitTypeHash = %s, want %s", got, want)
+	}
+	if got, want := DaiPermitTypeHash.Hex(), "0xea2aa0a1be11a07ed86d755c93467f4f82362b452371d1ba94d1715123511acb"; got != want {
+		t.Errorf("DaiPermitTypeHash = %s, want %s", got, want)
+	}
+}
+
+func TestDaiDomainSeparator(t *testing.T) {
+	dai := address.MustFromHex("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	domain := eip712.Domain{Name: "Dai Stablecoin", Version: "1", ChainID: 1, VerifyingContract: &dai}
+
+	// DOMAIN_SEPARATOR() of mainnet DAI
+	if got, want := domain.Separator().Hex(), "0xdbb8cf42e1ecb028be3f3dbc922e1d878b963f411dc388ced501601c60f7c6f7"; got != want {
+		t.Errorf("Separator() = %s, want %s", got, want)
+	}
+}
+
+func TestPermitDigest(t *testing.T) {
+	token := address.MustFromHex("0x3333333333333333333333333333333333333333")
+	domain := eip712.Domain{Name: "Token", Version: "1", ChainID: 1, VerifyingContract: &token}
+	p := Permit{
+		Owner:    owner,
+		Spender:  spender,
+		Value:    u256.FromUint64(1000),
+		Nonce:    u256.FromUint64(7),
+		Deadline: u256.FromUint64(1_700_000_000),
+	}
+
+	// abi.encode(PERMIT_TYPEHASH, owner, spender, value, nonce, deadline)
+	encoded := bytes.Join([][]byte{
+		PermitTypeHash[:],
+		make([]byte, 12), owner[:],
+		make([]byte, 12), spender[:],
+		p.Value[:], p.Nonce[:], p.Deadline[:],
+	}, nil)
+	structHash := keccak256.Hash(encoded)
+	if p.StructHash() != structHash {
+		t.Errorf("StructHash() = %s, want %s", p.StructHash().Hex(), structHash.Hex())
+	}
+
+	separator := domain.Separator()
+	want := keccak256.Hash(bytes.Join([][]byte{{0x19, 0x01}, separator[:], structHash[:]}, nil))
+	if got := p.Digest(domain); got != want {
+		t.Errorf("Digest() = %s, want %s", got.Hex(), want.Hex())
+	}
+}
+
+func TestDaiPermitDigest(t *testing.T) {
+	p := DaiPermit{Holder: owner, Spender: spender, Nonce: u256.FromUint64(1), Allowed: true}
+
+	encoded := bytes.Join([][]byte{
+		DaiPermitTypeHash[:],
+		make([]byte, 12), owner[:],
+		make([]byte, 12), spender[:],
+		p.Nonce[:], p.Expiry[:],
+		u256.One[:],
+	}, nil)
+	if got, want := p.StructHash(), keccak256.Hash(encoded); got != want {
+		t.Errorf("StructHash() = %s, want %s", got.Hex(), want.Hex())
+	}
+
+	revoke := p
+	revoke.Allowed = false
+	if revoke.StructHash() == p.StructHash() {
+		t.Error("allowed flag must change the struct hash")
+	}
+}
+
+func TestSplitSignature(t *testing.T) {
+	r := bytes.Repeat([]byte{0xaa}, 32)
+	s := bytes.Repeat([]byte{0x11}, 32)
+
+	tests := []struct {
+		name  string
+		sig   []byte
+		wantV uint8
+	}{
+		{"v 0", append(append(append([]byte{}, r...), s...), 0), 27},
+		{"v 1", append(append(append([]byte{}, r...), s...), 1), 28},
+		{"v 28", append(append(append([]byte{}, r...), s...), 28), 28},
+		{"compact even", append(append([]byte{}, r...), s...), 27},
+		{"compact odd", append(append([]byte{}, r...), append([]byte{0x91}, s[1:]...)...), 28},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, gotR, gotS, err := SplitSignature(tt.sig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v != tt.wantV || !bytes.Equal(gotR[:], r) || !bytes.Equal(gotS[:], s) {
+				t.Errorf("SplitSignature() = %d, %x, %x", v, gotR, gotS)
+			}
+		})
+	}
+
+	if _, _, _, err := SplitSignature(make([]byte, 63)); !errors.Is(err, ErrInvalidSignatureLength) {
+		t.Errorf("short signature error = %v", err)
+	}
+	if _, _, _, err := SplitSignature(append(make([]byte, 64), 2)); !errors.Is(err, ErrInvalidV) {
+		t.Errorf("invalid v error = %v", err)
+	}
+}
@@ -0,0 +1,323 @@
+package precompile
+
+import (
+	"bytes"
+	"errors"
+)
+
+// EIP-2537 encoding sizes. Field elements are 48-byte big-endian values
+// left-padded to 64 bytes.
+const (
+	FpSize          = 48
+	EncodedFpSize   = 64
+	EncodedFp2Size  = 2 * EncodedFpSize
+	EncodedG1Size   = 2 * EncodedFpSize
+	EncodedG2Size   = 2 * EncodedFp2Size
+	ScalarSize      = 32
+	G1MSMPairSize   = EncodedG1Size + ScalarSize
+	G2MSMPairSize   = EncodedG2Size + ScalarSize
+	PairingPairSize = EncodedG1Size + EncodedG2Size
+)
+
+// EIP-2537 errors
+var (
+	ErrInvalidFpPadding = errors.New("precompile: field element padding must be zero")
+	ErrFpNotCanonical   = errors.New("precompile: field element not below the BLS12-381 modulus")
+	ErrEmptyInput       = errors.New("precompile: no points")
+	ErrInvalidOutput    = errors.New("precompile: malformed output")
+)
+
+// blsBaseModulus is the BLS12-381 base field modulus, big-endian.
+var blsBaseModulus = [FpSize]byte{
+	0x1a, 0x01, 0x11, 0xea, 0x39, 0x7f, 0xe6, 0x9a, 0x4b, 0x1b, 0xa7, 0xb6,
+	0x43, 0x4b, 0xac, 0xd7, 0x64, 0x77, 0x4b, 0x84, 0xf3, 0x85, 0x12, 0xbf,
+	0x67, 0x30, 0xd2, 0xa0, 0xf6, 0xb0, 0xf6, 0x24, 0x1e, 0xab, 0xff, 0xfe,
+	0xb1, 0x53, 0xff, 0xff, 0xb9, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xaa, 0xab,
+}
+
+// Fp is a BLS12-381 base field element, big-endian.
+type Fp [FpSize]byte
+
+// Fp2 is an element c0 + c1*u of the quadratic extension field.
+type Fp2 struct {
+	C0, C1 Fp
+}
+
+// G1Point is an affine G1 point. The zero value is the point at infinity.
+type G1Point struct {
+	X, Y Fp
+}
+
+// G2Point is an affine G2 point. The zero value is the point at infinity.
+type G2Point struct {
+	X, Y Fp2
+}
+
+// G1MSMPair is one term of a G1 multi-scalar multiplication.
+type G1MSMPair struct {
+	Point  G1Point
+	Scalar [ScalarSize]byte
+}
+
+// G2MSMPair is one term of a G2 multi-scalar multiplication.
+type G2MSMPair struct {
+	Point  G2Point
+	Scalar [ScalarSize]byte
+}
+
+// PairingPair is one (G1, G2) pair of a pairing check.
+type PairingPair struct {
+	G1 G1Point
+	G2 G2Point
+}
+
+// Encode returns the 64-byte encoding of f.
+func (f Fp) Encode() []byte {
+	return appendFp(make([]byte, 0, EncodedFpSize), f)
+}
+
+// Encode returns the 128-byte encoding of f: c0 then c1.
+func (f Fp2) Encode() []byte {
+	return appendFp2(make([]byte, 0, EncodedFp2Size), f)
+}
+
+// Encode returns the 128-byte encoding of p: x then y.
+func (p G1Point) Encode() []byte {
+	return appendG1(make([]byte, 0, EncodedG1Size), p)
+}
+
+// Encode returns the 256-byte encoding of p: x then y.
+func (p G2Point) Encode() []byte {
+	return appendG2(make([]byte, 0, EncodedG2Size), p)
+}
+
+// EncodeG1Add returns the BLS12_G1ADD input for a + b.
+func EncodeG1Add(a, b G1Point) []byte {
+	return appendG1(appendG1(make([]byte, 0, 2*EncodedG1Size), a), b)
+}
+
+// EncodeG2Add returns the BLS12_G2ADD input for a + b.
+func EncodeG2Add(a, b G2Point) []byte {
+	return appendG2(appendG2(make([]byte, 0, 2*EncodedG2Size), a), b)
+}
+
+// EncodeG1MSM returns the BLS12_G1MSM input. A single pair is a plain
+// scalar multiplication.
+func EncodeG1MSM(pairs []G1MSMPair) ([]byte, error) {
+	if len(pairs) == 0 {
+		return nil, ErrEmptyInput
+	}
+	b := make([]byte, 0, len(pairs)*G1MSMPairSize)
+	for _, p := range pairs {
+		b = appendG1(b, p.Point)
+		b = append(b, p.Scalar[:]...)
+	}
+	return b, nil
+}
+
+// EncodeG2MSM returns the BLS12_G2MSM input.
+func EncodeG2MSM(pairs []G2MSMPair) ([]byte, error) {
+	if len(pairs) == 0 {
+		return nil, ErrEmptyInput
+	}
+	b := make([]byte, 0, len(pairs)*G2MSMPairSize)
+	for _, p := range pairs {
+		b = appendG2(b, p.Point)
+		b = append(b, p.Scalar[:]...)
+	}
+	return b, nil
+}
+
+// EncodePairingCheck returns the BLS12_PAIRING_CHECK input.
+func EncodePairingCheck(pairs []PairingPair) ([]byte, error) {
+	if len(pairs) == 0 {
+		return nil, ErrEmptyInput
+	}
+	b := make([]byte, 0, len(pairs)*PairingPairSize)
+	for _, p := range pairs {
+		b = appendG1(b, p.G1)
+		b = appendG2(b, p.G2)
+	}
+	return b, nil
+}
+
+// EncodeMapFpToG1 returns the BLS12_MAP_FP_TO_G1 input.
+func EncodeMapFpToG1(f Fp) []byte {
+	return f.Encode()
+}
+
+// EncodeMapFp2ToG2 returns the BLS12_MAP_FP2_TO_G2 input.
+func EncodeMapFp2ToG2(f Fp2) []byte {
+	return f.Encode()
+}
+
+// DecodeFp decodes a 64-byte field element, checking the zero padding and
+// that the value is below the modulus.
+func DecodeFp(b []byte) (Fp, error) {
+	if len(b) != EncodedFpSize {
+		return Fp{}, ErrInvalidLength
+	}
+	pad := EncodedFpSize - FpSize
+	for _, x := range b[:pad] {
+		if x != 0 {
+			return Fp{}, ErrInvalidFpPadding
+		}
+	}
+	if bytes.Compare(b[pad:], blsBaseModulus[:]) >= 0 {
+		return Fp{}, ErrFpNotCanonical
+	}
+	return Fp(b[pad:]), nil
+}
+
+// DecodeFp2 decodes a 128-byte Fp2 element.
+func DecodeFp2(b []byte) (Fp2, error) {
+	if len(b) != EncodedFp2Size {
+		return Fp2{}, ErrInvalidLength
+	}
+	c0, err := DecodeFp(b[:EncodedFpSize])
+	if err != nil {
+		return Fp2{}, err
+	}
+	c1, err := DecodeFp(b[EncodedFpSize:])
+	if err != nil {
+		return Fp2{}, err
+	}
+	return Fp2{C0: c0, C1: c1}, nil
+}
+
+// DecodeG1Point decodes a 128-byte G1 point, such as the output of
+// BLS12_G1ADD, BLS12_G1MSM or BLS12_MAP_FP_TO_G1. It checks the encoding
+// only, not that the point is on the curve.
+func DecodeG1Point(b []byte) (G1Point, error) {
+	if len(b) != EncodedG1Size {
+		return G1Point{}, ErrInvalidLength
+	}
+	x, err := DecodeFp(b[:EncodedFpSize])
+	if err != nil {
+		return G1Point{}, err
+	}
+	y, err := DecodeFp(b[EncodedFpSize:])
+	if err != nil {
+		return G1Point{}, err
+	}
+	return G1Point{X: x, Y: y}, nil
+}
+
+// DecodeG2Point decodes a 256-byte G2 point, such as the output of
+// BLS12_G2ADD, BLS12_G2MSM or BLS12_MAP_FP2_TO_G2. It checks the encoding
+// only, not that the point is on the curve.
+func DecodeG2Point(b []byte) (G2Point, error) {
+	if len(b) != EncodedG2Size {
+		return G2Point{}, ErrInvalidLength
+	}
+	x, err := DecodeFp2(b[:EncodedFp2Size])
+	if err != nil {
+		return G2Point{}, err
+	}
+	y, err := DecodeFp2(b[EncodedFp2Size:])
+	if err != nil {
+		return G2Point{}, err
+	}
+	return G2Point{X: x, Y: y}, nil
+}
+
+// DecodeG1MSM decodes a BLS12_G1MSM input.
+func DecodeG1MSM(b []byte) ([]G1MSMPair, error) {
+	if len(b) == 0 || len(b)%G1MSMPairSize != 0 {
+		return nil, ErrInvalidLength
+	}
+	pairs := make([]G1MSMPair, len(b)/G1MSMPairSize)
+	for i := range pairs {
+		chunk := b[i*G1MSMPairSize : (i+1)*G1MSMPairSize]
+		p, err := DecodeG1Point(chunk[:EncodedG1Size])
+		if err != nil {
+			return nil, err
+		}
+		pairs[i] = G1MSMPair{Point: p, Scalar: [ScalarSize]byte(chunk[EncodedG1Size:])}
+	}
+	return pairs, nil
+}
+
+// DecodeG2MSM decodes a BLS12_G2MSM input.
+func DecodeG2MSM(b []byte) ([]G2MSMPair, error) {
+	if len(b) == 0 || len(b)%G2MSMPairSize != 0 {
+		return nil, ErrInvalidLength
+	}
+	pairs := make([]G2MSMPair, len(b)/G2MSMPairSize)
+	for i := range pairs {
+		chunk := b[i*G2MSMPairSize : (i+1)*G2MSMPairSize]
+		p, err := DecodeG2Point(chunk[:EncodedG2Size])
+		if err != nil {
+			return nil, err
+		}
+		pairs[i] = G2MSMPair{Point: p, Scalar: [ScalarSize]byte(chunk[EncodedG2Size:])}
+	}
+	return pairs, nil
+}
+
+// DecodePairingCheck decodes a BLS12_PAIRING_CHECK input.
+func DecodePairingCheck(b []byte) ([]PairingPair, error) {
+	if len(b) == 0 || len(b)%PairingPairSize != 0 {
+		return nil, ErrInvalidLength
+	}
+	pairs := make([]PairingPair, len(b)/PairingPairSize)
+	for i := range pairs {
+		chunk := b[i*PairingPairSize : (i+1)*PairingPairSize]
+		g1, err := DecodeG1Point(chunk[:EncodedG1Size])
+		if err != nil {
+			return nil, err
+		}
+		g2, err := DecodeG2Point(chunk[EncodedG1Size:])
+		if err != nil {
+			return nil, err
+		}
+		pairs[i] = PairingPair{G1: g1, G2: g2}
+	}
+	return pairs, nil
+}
+
+// DecodePairingResult decodes the 32-byte BLS12_PAIRING_CHECK output.
+func DecodePairingResult(b []byte) (bool, error) {
+	if len(b) != 32 {
+		return false, ErrInvalidLength
+	}
+	for _, x := range b[:31] {
+		if x != 0 {
+			return false, ErrInvalidOutput
+		}
+	}
+	switch b[31] {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	}
+	return false, ErrInvalidOutput
+}
+
+// IsInfinity reports whether p is the point at infinity.
+func (p G1Point) IsInfinity() bool {
+	return p == G1Point{}
+}
+
+// IsInfinity reports whether p is the point at infinity.
+func (p G2Point) IsInfinity() bool {
+	return p == G2Point{}
+}
+
+func appendFp(b []byte, f Fp) []byte {
+	b = append(b, make([]byte, EncodedFpSize-FpSize)...)
+	return append(b, f[:]...)
+}
+
+func appendFp2(b []byte, f Fp2) []byte {
+	return appendFp(appendFp(b, f.C0), f.C1)
+}
+
+func appendG1(b []byte, p G1Point) []byte {
+	return appendFp(appendFp(b, p.X), p.Y)
+}
+
+func appendG2(b []byte, p G2Point) []byte {
+	return appendFp2(appendFp2(b, p.X), p.Y)
+}
@@ -0,0 +1,157 @@
+package precompile
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// g1Generator is the BLS12-381 G1 generator.
+var g1Generator = G1Point{
+	X: Fp(mustHex("17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")),
+	Y: Fp(mustHex("08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1")),
+}
+
+func fp(b byte) Fp {
+	var f Fp
+	f[FpSize-1] = b
+	return f
+}
+
+func TestG1Encoding(t *testing.T) {
+	enc := g1Generator.Encode()
+	if len(enc) != EncodedG1Size {
+		t.Fatalf("len(Encode()) = %d", len(enc))
+	}
+	want := append(append(make([]byte, 16), g1Generator.X[:]...), append(make([]byte, 16), g1Generator.Y[:]...)...)
+	if !bytes.Equal(enc, want) {
+		t.Errorf("Encode() = %x, want %x", enc, want)
+	}
+
+	decoded, err := DecodeG1Point(enc)
+	if err != nil || decoded != g1Generator {
+		t.Errorf("DecodeG1Point() = %+v, %v", decoded, err)
+	}
+	if !(G1Point{}).IsInfinity() || g1Generator.IsInfinity() {
+		t.Error("IsInfinity() mismatch")
+	}
+	if add := EncodeG1Add(g1Generator, G1Point{}); !bytes.Equal(add[:EncodedG1Size], enc) || !bytes.Equal(add[EncodedG1Size:], make([]byte, EncodedG1Size)) {
+		t.Errorf("EncodeG1Add() = %x", add)
+	}
+}
+
+func TestG2Encoding(t *testing.T) {
+	p := G2Point{X: Fp2{C0: fp(1), C1: fp(2)}, Y: Fp2{C0: fp(3), C1: fp(4)}}
+	enc := p.Encode()
+	if len(enc) != EncodedG2Size {
+		t.Fatalf("len(Encode()) = %d", len(enc))
+	}
+	// Each element is in the last byte of its 64-byte slot, in the order
+	// x.c0, x.c1, y.c0, y.c1
+	for i, want := range []byte{1, 2, 3, 4} {
+		if got := enc[(i+1)*EncodedFpSize-1]; got != want {
+			t.Errorf("slot %d = %d, want %d", i, got, want)
+		}
+	}
+
+	decoded, err := DecodeG2Point(enc)
+	if err != nil || decoded != p {
+		t.Errorf("DecodeG2Point() = %+v, %v", decoded, err)
+	}
+	if got := len(EncodeG2Add(p, p)); got != 2*EncodedG2Size {
+		t.Errorf("len(EncodeG2Add()) = %d", got)
+	}
+	if got := EncodeMapFp2ToG2(p.X); !bytes.Equal(got, enc[:EncodedFp2Size]) {
+		t.Errorf("EncodeMapFp2ToG2() = %x", got)
+	}
+	if got := EncodeMapFpToG1(fp(7)); len(got) != EncodedFpSize || got[EncodedFpSize-1] != 7 {
+		t.Errorf("EncodeMapFpToG1() = %x", got)
+	}
+}
+
+func TestMSMAndPairingRoundTrip(t *testing.T) {
+	g2 := G2Point{X: Fp2{C0: fp(5)}, Y: Fp2{C1: fp(6)}}
+
+	g1Pairs := []G1MSMPair{{Point: g1Generator, Scalar: [ScalarSize]byte{31: 2}}, {Point: G1Point{}}}
+	enc, err := EncodeG1MSM(g1Pairs)
+	if err != nil || len(enc) != 2*G1MSMPairSize {
+		t.Fatalf("EncodeG1MSM() = %d bytes, %v", len(enc), err)
+	}
+	if decoded, err := DecodeG1MSM(enc); err != nil || !reflect.DeepEqual(decoded, g1Pairs) {
+		t.Errorf("DecodeG1MSM() = %+v, %v", decoded, err)
+	}
+
+	g2Pairs := []G2MSMPair{{Point: g2, Scalar: [ScalarSize]byte{0: 0xff}}}
+	enc, err = EncodeG2MSM(g2Pairs)
+	if err != nil || len(enc) != G2MSMPairSize {
+		t.Fatalf("EncodeG2MSM() = %d bytes, %v", len(enc), err)
+	}
+	if decoded, err := DecodeG2MSM(enc); err != nil || !reflect.DeepEqual(decoded, g2Pairs) {
+		t.Errorf("DecodeG2MSM() = %+v, %v", decoded, err)
+	}
+
+	pairs := []PairingPair{{G1: g1Generator, G2: g2}, {}}
+	enc, err = EncodePairingCheck(pairs)
+	if err != nil || len(enc) != 2*PairingPairSize {
+		t.Fatalf("EncodePairingCheck() = %d bytes, %v", len(enc), err)
+	}
+	if decoded, err := DecodePairingCheck(enc); err != nil || !reflect.DeepEqual(decoded, pairs) {
+		t.Errorf("DecodePairingCheck() = %+v, %v", decoded, err)
+	}
+
+	if _, err := EncodeG1MSM(nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("EncodeG1MSM(nil) error = %v, want %v", err, ErrEmptyInput)
+	}
+	if _, err := DecodePairingCheck(enc[1:]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("DecodePairingCheck(short) error = %v, want %v", err, ErrInvalidLength)
+	}
+}
+
+func TestDecodeFp(t *testing.T) {
+	modulus := append(make([]byte, 16), blsBaseModulus[:]...)
+	belowModulus := append([]byte{}, modulus...)
+	belowModulus[EncodedFpSize-1]--
+	badPadding := fp(1).Encode()
+	badPadding[0] = 1
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{"zero", make([]byte, EncodedFpSize), nil},
+		{"modulus - 1", belowModulus, nil},
+		{"modulus", modulus, ErrFpNotCanonical},
+		{"padding", badPadding, ErrInvalidFpPadding},
+		{"short", make([]byte, FpSize), ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeFp(tt.data); !errors.Is(err, tt.wantErr) {
+				t.Errorf("DecodeFp() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodePairingResult(t *testing.T) {
+	tests := []struct {
+		data    []byte
+		want    bool
+		wantErr error
+	}{
+		{make([]byte, 32), false, nil},
+		{append(make([]byte, 31), 1), true, nil},
+		{append(make([]byte, 31), 2), false, ErrInvalidOutput},
+		{make([]byte, 31), false, ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		got, err := DecodePairingResult(tt.data)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("DecodePairingResult(%x) = %v, %v, want %v, %v", tt.data, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package precompile
+
+import (
+	"bytes"
+
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+)
+
+// EIP-196/197 encoding sizes. Field elements are 32-byte big-endian
+// values.
+const (
+	BN254FpSize          = 32
+	BN254G1Size          = 2 * BN254FpSize
+	BN254G2Size          = 4 * BN254FpSize
+	BN254PairingPairSize = BN254G1Size + BN254G2Size
+)
+
+// BN254 gas costs from Istanbul on (EIP-1108).
+const (
+	ECAddGas            = 150
+	ECMulGas            = 6000
+	ECPairingBaseGas    = 45000
+	ECPairingPerPairGas = 34000
+)
+
+// bn254BaseModulus is the BN254 base field modulus, big-endian.
+var bn254BaseModulus = [BN254FpSize]byte{
+	0x30, 0x64, 0x4e, 0x72, 0xe1, 0x31, 0xa0, 0x29,
+	0xb8, 0x50, 0x45, 0xb6, 0x81, 0x81, 0x58, 0x5d,
+	0x97, 0x81, 0x6a, 0x91, 0x68, 0x71, 0xca, 0x8d,
+	0x3c, 0x20, 0x8c, 0x16, 0xd8, 0x7c, 0xfd, 0x47,
+}
+
+// BN254Fp2 is an element c0 + c1*i of the quadratic extension field.
+type BN254Fp2 struct {
+	C0, C1 [BN254FpSize]byte
+}
+
+// BN254G1Point is an affine G1 point. The zero value is the point at
+// infinity.
+type BN254G1Point struct {
+	X, Y [BN254FpSize]byte
+}
+
+// BN254G2Point is an affine G2 point. The zero value is the point at
+// infinity.
+type BN254G2Point struct {
+	X, Y BN254Fp2
+}
+
+// BN254PairingPair is one (G1, G2) pair of a pairing check.
+type BN254PairingPair struct {
+	G1 BN254G1Point
+	G2 BN254G2Point
+}
+
+// Encode returns the 64-byte encoding of p: x then y.
+func (p BN254G1Point) Encode() []byte {
+	return appendBN254G1(make([]byte, 0, BN254G1Size), p)
+}
+
+// Encode returns the 128-byte encoding of p. EIP-197 puts the imaginary
+// part of each coordinate first: x.c1, x.c0, y.c1, y.c0.
+func (p BN254G2Point) Encode() []byte {
+	return appendBN254G2(make([]byte, 0, BN254G2Size), p)
+}
+
+// IsInfinity reports whether p is the point at infinity.
+func (p BN254G1Point) IsInfinity() bool {
+	return p == BN254G1Point{}
+}
+
+// IsInfinity reports whether p is the point at infinity.
+func (p BN254G2Point) IsInfinity() bool {
+	return p == BN254G2Point{}
+}
+
+// EncodeECAdd returns the ECADD input for a + b.
+func EncodeECAdd(a, b BN254G1Point) []byte {
+	return appendBN254G1(appendBN254G1(make([]byte, 0, 2*BN254G1Size), a), b)
+}
+
+// EncodeECMul returns the ECMUL input for scalar * p.
+func EncodeECMul(p BN254G1Point, scalar [32]byte) []byte {
+	return append(appendBN254G1(make([]byte, 0, BN254G1Size+32), p), scalar[:]...)
+}
+
+// EncodeECPairing returns the ECPAIRING input. Unlike the EIP-2537
+// pairing, an empty input is valid and checks to true.
+func EncodeECPairing(pairs []BN254PairingPair) []byte {
+	b := make([]byte, 0, len(pairs)*BN254PairingPairSize)
+	for _, p := range pairs {
+		b = appendBN254G1(b, p.G1)
+		b = appendBN254G2(b, p.G2)
+	}
+	return b
+}
+
+// DecodeBN254G1Point decodes a 64-byte G1 point, such as the output of
+// ECADD or ECMUL. It checks that the coordinates are below the field
+// modulus, not that the point is on the curve.
+func DecodeBN254G1Point(b []byte) (BN254G1Point, error) {
+	if len(b) != BN254G1Size {
+		return BN254G1Point{}, ErrInvalidLength
+	}
+	var p BN254G1Point
+	copy(p.X[:], b[:BN254FpSize])
+	copy(p.Y[:], b[BN254FpSize:])
+	if bytes.Compare(p.X[:], bn254BaseModulus[:]) >= 0 || bytes.Compare(p.Y[:], bn254BaseModulus[:]) >= 0 {
+		return BN254G1Point{}, ErrFpNotCanonical
+	}
+	return p, nil
+}
+
+// ECAdd computes a + b as the ECADD precompile does.
+func ECAdd(a, b BN254G1Point) (BN254G1Point, error) {
+	out, err := ffi.BN254Add([ffi.BN254AddInputSize]byte(EncodeECAdd(a, b)))
+	if err != nil {
+		return BN254G1Point{}, err
+	}
+	return DecodeBN254G1Point(out[:])
+}
+
+// ECMul computes scalar * p as the ECMUL precompile does.
+func ECMul(p BN254G1Point, scalar [32]byte) (BN254G1Point, error) {
+	out, err := ffi.BN254Mul([ffi.BN254MulInputSize]byte(EncodeECMul(p, scalar)))
+	if err != nil {
+		return BN254G1Point{}, err
+	}
+	return DecodeBN254G1Point(out[:])
+}
+
+// ECPairingCheck reports whether the product of the pairings e(G1, G2)
+// is one, as the ECPAIRING precompile does.
+func ECPairingCheck(pairs []BN254PairingPair) (bool, error) {
+	return ffi.BN254Pairing(EncodeECPairing(pairs))
+}
+
+// ECPairingGas returns the ECPAIRING gas cost for n pairs.
+func ECPairingGas(n int) uint64 {
+	return ECPairingBaseGas + ECPairingPerPairGas*uint64(n)
+}
+
+func appendBN254G1(b []byte, p BN254G1Point) []byte {
+	return append(append(b, p.X[:]...), p.Y[:]...)
+}
+
+func appendBN254G2(b []byte, p BN254G2Point) []byte {
+	b = append(append(b, p.X.C1[:]...), p.X.C0[:]...)
+	return append(append(b, p.Y.C1[:]...), p.Y.C0[:]...)
+}
@@ -0,0 +1,107 @@
+package precompile
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func word(s string) [32]byte {
+	return [32]byte(mustHex(s))
+}
+
+// BN254 generators and 2*G1, from EIP-197 and the Ethereum tests.
+var (
+	bn254G1 = BN254G1Point{X: [32]byte{31: 1}, Y: [32]byte{31: 2}}
+	bn254G2 = BN254G2Point{
+		X: BN254Fp2{
+			C0: word("1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed"),
+			C1: word("198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2"),
+		},
+		Y: BN254Fp2{
+			C0: word("12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa"),
+			C1: word("090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b"),
+		},
+	}
+	bn254G1Double = BN254G1Point{
+		X: word("030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd3"),
+		Y: word("15ed738c0e0a7c92e7845f96b2ae9c0a68a6a449e3538fc7ff3ebf7a5a18a2c4"),
+	}
+	// -G1 = (1, p - 2)
+	bn254G1Neg = BN254G1Point{
+		X: [32]byte{31: 1},
+		Y: word("30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd45"),
+	}
+)
+
+func TestBN254Encoding(t *testing.T) {
+	g2 := bn254G2.Encode()
+	want := mustHex("198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2" +
+		"1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" +
+		"090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b" +
+		"12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa")
+	if !bytes.Equal(g2, want) {
+		t.Errorf("G2 Encode() = %x, want %x", g2, want)
+	}
+
+	if got := EncodeECMul(bn254G1, [32]byte{31: 2}); len(got) != 96 || got[31] != 1 || got[63] != 2 || got[95] != 2 {
+		t.Errorf("EncodeECMul() = %x", got)
+	}
+	if got := EncodeECAdd(bn254G1, BN254G1Point{}); !bytes.Equal(got[:BN254G1Size], bn254G1.Encode()) || !bytes.Equal(got[BN254G1Size:], make([]byte, BN254G1Size)) {
+		t.Errorf("EncodeECAdd() = %x", got)
+	}
+	pairs := EncodeECPairing([]BN254PairingPair{{G1: bn254G1, G2: bn254G2}, {}})
+	if len(pairs) != 2*BN254PairingPairSize || !bytes.Equal(pairs[BN254G1Size:BN254PairingPairSize], g2) {
+		t.Errorf("EncodeECPairing() = %x", pairs)
+	}
+	if len(EncodeECPairing(nil)) != 0 {
+		t.Error("EncodeECPairing(nil) not empty")
+	}
+	if !(BN254G1Point{}).IsInfinity() || bn254G2.IsInfinity() {
+		t.Error("IsInfinity() mismatch")
+	}
+}
+
+func TestDecodeBN254G1Point(t *testing.T) {
+	if p, err := DecodeBN254G1Point(bn254G1Double.Encode()); err != nil || p != bn254G1Double {
+		t.Errorf("DecodeBN254G1Point() = %+v, %v", p, err)
+	}
+	if _, err := DecodeBN254G1Point(make([]byte, 63)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("short point error = %v, want %v", err, ErrInvalidLength)
+	}
+	modulus := append(make([]byte, BN254FpSize), bn254BaseModulus[:]...)
+	if _, err := DecodeBN254G1Point(modulus); !errors.Is(err, ErrFpNotCanonical) {
+		t.Errorf("y = p error = %v, want %v", err, ErrFpNotCanonical)
+	}
+	if got := ECPairingGas(2); got != 113000 {
+		t.Errorf("ECPairingGas(2) = %d, want 113000", got)
+	}
+}
+
+func TestBN254Operations(t *testing.T) {
+	if got, err := ECAdd(bn254G1, bn254G1); err != nil || got != bn254G1Double {
+		t.Errorf("ECAdd(G, G) = %+v, %v", got, err)
+	}
+	if got, err := ECAdd(bn254G1, bn254G1Neg); err != nil || !got.IsInfinity() {
+		t.Errorf("ECAdd(G, -G) = %+v, %v", got, err)
+	}
+	if got, err := ECMul(bn254G1, [32]byte{31: 2}); err != nil || got != bn254G1Double {
+		t.Errorf("ECMul(G, 2) = %+v, %v", got, err)
+	}
+	if _, err := ECAdd(BN254G1Point{X: [32]byte{31: 1}, Y: [32]byte{31: 3}}, bn254G1); err == nil {
+		t.Error("ECAdd() accepted a point off the curve")
+	}
+
+	// e(G1, G2) * e(-G1, G2) = 1
+	ok, err := ECPairingCheck([]BN254PairingPair{{G1: bn254G1, G2: bn254G2}, {G1: bn254G1Neg, G2: bn254G2}})
+	if err != nil || !ok {
+		t.Errorf("ECPairingCheck(inverse pairs) = %v, %v", ok, err)
+	}
+	ok, err = ECPairingCheck([]BN254PairingPair{{G1: bn254G1, G2: bn254G2}})
+	if err != nil || ok {
+		t.Errorf("ECPairingCheck(single pair) = %v, %v", ok, err)
+	}
+	if ok, err := ECPairingCheck(nil); err != nil || !ok {
+		t.Errorf("ECPairingCheck(nil) = %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,20 @@
+package precompile
+
+import "testing"
+
+func FuzzParse(f *testing.F) {
+	f.Add(mustHex(eip198Sample))
+	f.Add(make([]byte, PointEvaluationInputSize))
+	f.Add(make([]byte, PairingPairSize))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if in, err := ParseModExpInput(data); err == nil {
+			in.GasEIP198()
+			in.GasEIP2565()
+		}
+		ParsePointEvaluationInput(data)
+		DecodeG1MSM(data)
+		DecodeG2MSM(data)
+		DecodePairingCheck(data)
+		DecodePairingResult(data)
+	})
+}
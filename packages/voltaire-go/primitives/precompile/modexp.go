@@ -0,0 +1,159 @@
+package precompile
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+)
+
+// MaxModExpLength bounds each length field accepted by ParseModExpInput,
+// so that a hostile input cannot force a huge allocation. Real calls are
+// limited far below this by gas.
+const MaxModExpLength = 1 << 20
+
+// ModExpInput is the input of the EIP-198 modular exponentiation
+// precompile. Leading zero bytes are significant: they change the length
+// fields, the gas cost and the output length.
+type ModExpInput struct {
+	Base     []byte
+	Exponent []byte
+	Modulus  []byte
+}
+
+// ModExpFromBig builds an input from big integers using their minimal
+// big-endian encodings. A nil argument is treated as zero.
+func ModExpFromBig(base, exp, mod *big.Int) ModExpInput {
+	return ModExpInput{Base: bigBytes(base), Exponent: bigBytes(exp), Modulus: bigBytes(mod)}
+}
+
+// Encode returns the precompile input: the three lengths as 32-byte
+// big-endian words followed by base, exponent and modulus.
+func (in ModExpInput) Encode() []byte {
+	b := make([]byte, 96, 96+len(in.Base)+len(in.Exponent)+len(in.Modulus))
+	binary.BigEndian.PutUint64(b[24:32], uint64(len(in.Base)))
+	binary.BigEndian.PutUint64(b[56:64], uint64(len(in.Exponent)))
+	binary.BigEndian.PutUint64(b[88:96], uint64(len(in.Modulus)))
+	b = append(b, in.Base...)
+	b = append(b, in.Exponent...)
+	return append(b, in.Modulus...)
+}
+
+// ParseModExpInput decodes a precompile input. Like the precompile, it
+// treats missing bytes as zero. It returns ErrInvalidLength if a length
+// field exceeds MaxModExpLength. The returned fields may alias data.
+func ParseModExpInput(data []byte) (ModExpInput, error) {
+	header := rightPad(data, 96)
+	var lens [3]uint64
+	for i := range lens {
+		word := header[32*i : 32*i+32]
+		for _, b := range word[:24] {
+			if b != 0 {
+				return ModExpInput{}, ErrInvalidLength
+			}
+		}
+		lens[i] = binary.BigEndian.Uint64(word[24:])
+		if lens[i] > MaxModExpLength {
+			return ModExpInput{}, ErrInvalidLength
+		}
+	}
+
+	var rest []byte
+	if len(data) > 96 {
+		rest = data[96:]
+	}
+	rest = rightPad(rest, int(lens[0]+lens[1]+lens[2]))
+	return ModExpInput{
+		Base:     rest[:lens[0]],
+		Exponent: rest[lens[0] : lens[0]+lens[1]],
+		Modulus:  rest[lens[0]+lens[1] : lens[0]+lens[1]+lens[2]],
+	}, nil
+}
+
+// Result computes base^exponent mod modulus, left-padded to the modulus
+// length, as the precompile returns it. A zero modulus gives all zeros.
+func (in ModExpInput) Result() []byte {
+	out := make([]byte, len(in.Modulus))
+	mod := new(big.Int).SetBytes(in.Modulus)
+	if mod.Sign() == 0 {
+		return out
+	}
+	base := new(big.Int).SetBytes(in.Base)
+	exp := new(big.Int).SetBytes(in.Exponent)
+	new(big.Int).Exp(base, exp, mod).FillBytes(out)
+	return out
+}
+
+// GasEIP2565 returns the gas cost from Berlin on (EIP-2565).
+func (in ModExpInput) GasEIP2565() uint64 {
+	words := new(big.Int).SetUint64((uint64(max(len(in.Base), len(in.Modulus))) + 7) / 8)
+	complexity := words.Mul(words, words)
+	gas := complexity.Mul(complexity, new(big.Int).SetUint64(max(in.iterationCount(), 1)))
+	gas.Div(gas, big.NewInt(3))
+	return max(saturate(gas), 200)
+}
+
+// GasEIP198 returns the gas cost before Berlin (EIP-198).
+func (in ModExpInput) GasEIP198() uint64 {
+	x := new(big.Int).SetUint64(uint64(max(len(in.Base), len(in.Modulus))))
+	x2 := new(big.Int).Mul(x, x)
+
+	// mult_complexity(x) is piecewise quadratic in x
+	var complexity *big.Int
+	switch {
+	case x.Cmp(big.NewInt(64)) <= 0:
+		complexity = x2
+	case x.Cmp(big.NewInt(1024)) <= 0:
+		complexity = x2.Div(x2, big.NewInt(4))
+		complexity.Add(complexity, new(big.Int).Mul(x, big.NewInt(96)))
+		complexity.Sub(complexity, big.NewInt(3072))
+	default:
+		complexity = x2.Div(x2, big.NewInt(16))
+		complexity.Add(complexity, new(big.Int).Mul(x, big.NewInt(480)))
+		complexity.Sub(complexity, big.NewInt(199680))
+	}
+
+	gas := complexity.Mul(complexity, new(big.Int).SetUint64(max(in.iterationCount(), 1)))
+	return saturate(gas.Div(gas, big.NewInt(20)))
+}
+
+// iterationCount returns the adjusted exponent length: the bit length of
+// the exponent's first 32 bytes minus one, plus 8 for each further byte.
+func (in ModExpInput) iterationCount() uint64 {
+	head := in.Exponent
+	var extra uint64
+	if len(head) > 32 {
+		extra = 8 * uint64(len(head)-32)
+		head = head[:32]
+	}
+	if bits := new(big.Int).SetBytes(head).BitLen(); bits > 0 {
+		extra += uint64(bits - 1)
+	}
+	return extra
+}
+
+// bigBytes returns the minimal big-endian encoding of x, or nil for a nil
+// x.
+func bigBytes(x *big.Int) []byte {
+	if x == nil {
+		return nil
+	}
+	return x.Bytes()
+}
+
+// saturate converts x to uint64, clamping at math.MaxUint64.
+func saturate(x *big.Int) uint64 {
+	if !x.IsUint64() {
+		return math.MaxUint64
+	}
+	return x.Uint64()
+}
+
+// rightPad returns b extended with zeros to at least n bytes.
+func rightPad(b []byte, n int) []byte {
+	if len(b) >= n {
+		return b
+	}
+	padded := make([]byte, n)
+	copy(padded, b)
+	return padded
+}
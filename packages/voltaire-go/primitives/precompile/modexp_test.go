@@ -0,0 +1,126 @@
+package precompile
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// The secp256k1 field prime p, and p - 2.
+var (
+	fieldPrime   = mustHex("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	primeMinus2  = mustHex("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2d")
+	eip198Sample = "0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"03" +
+		"fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2d" +
+		"fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"
+)
+
+func TestModExpEIP198Example(t *testing.T) {
+	// 3^(p-2) mod p, from EIP-198
+	in := ModExpInput{Base: []byte{3}, Exponent: primeMinus2, Modulus: fieldPrime}
+
+	if got := hex.EncodeToString(in.Encode()); got != eip198Sample {
+		t.Errorf("Encode() = %s, want %s", got, eip198Sample)
+	}
+	parsed, err := ParseModExpInput(mustHex(eip198Sample))
+	if err != nil || !reflect.DeepEqual(parsed, in) {
+		t.Errorf("ParseModExpInput() = %+v, %v", parsed, err)
+	}
+
+	want := new(big.Int).ModInverse(big.NewInt(3), new(big.Int).SetBytes(fieldPrime))
+	if got := new(big.Int).SetBytes(in.Result()); got.Cmp(want) != 0 {
+		t.Errorf("Result() = %x, want %x", got, want)
+	}
+	if got := in.GasEIP198(); got != 13056 {
+		t.Errorf("GasEIP198() = %d, want 13056", got)
+	}
+	if got := in.GasEIP2565(); got != 1360 {
+		t.Errorf("GasEIP2565() = %d, want 1360", got)
+	}
+}
+
+func TestModExpGas(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        ModExpInput
+		eip198    uint64
+		eip2565   uint64
+		resultLen int
+	}{
+		{"zero exponent", ModExpInput{Base: []byte{2}, Exponent: []byte{0}, Modulus: []byte{5}}, 0, 200, 1},
+		{"empty", ModExpInput{}, 0, 200, 0},
+		// 256-byte RSA-style modulus and exponent 65537
+		{"rsa 2048", ModExpInput{Base: make([]byte, 256), Exponent: []byte{0x01, 0x00, 0x01}, Modulus: make([]byte, 256)}, 30310, 5461, 256},
+		// Long exponents add 8 iterations per byte past the first 32
+		{"long exponent", ModExpInput{Base: []byte{1}, Exponent: append([]byte{1}, make([]byte, 33)...), Modulus: []byte{1}}, 13, 200, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.in.GasEIP198(); got != tt.eip198 {
+				t.Errorf("GasEIP198() = %d, want %d", got, tt.eip198)
+			}
+			if got := tt.in.GasEIP2565(); got != tt.eip2565 {
+				t.Errorf("GasEIP2565() = %d, want %d", got, tt.eip2565)
+			}
+			if got := len(tt.in.Result()); got != tt.resultLen {
+				t.Errorf("len(Result()) = %d, want %d", got, tt.resultLen)
+			}
+		})
+	}
+}
+
+func TestParseModExpInput(t *testing.T) {
+	// Missing trailing bytes read as zero
+	full := mustHex(eip198Sample)
+	in, err := ParseModExpInput(full[:len(full)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(in.Modulus[:31], fieldPrime[:31]) || in.Modulus[31] != 0 {
+		t.Errorf("truncated modulus = %x", in.Modulus)
+	}
+
+	empty, err := ParseModExpInput(nil)
+	if err != nil || len(empty.Base)+len(empty.Exponent)+len(empty.Modulus) != 0 {
+		t.Errorf("ParseModExpInput(nil) = %+v, %v", empty, err)
+	}
+
+	huge := append([]byte{}, full[:96]...)
+	huge[0] = 1
+	if _, err := ParseModExpInput(huge); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("huge length error = %v, want %v", err, ErrInvalidLength)
+	}
+	huge = append([]byte{}, full[:96]...)
+	huge[61] = 0x20 // exponent length just over 1 MiB
+	if _, err := ParseModExpInput(huge); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("over-limit length error = %v, want %v", err, ErrInvalidLength)
+	}
+}
+
+func TestModExpFromBig(t *testing.T) {
+	in := ModExpFromBig(big.NewInt(4), big.NewInt(13), big.NewInt(497))
+	if got := new(big.Int).SetBytes(in.Result()); got.Int64() != 445 {
+		t.Errorf("4^13 mod 497 = %d, want 445", got)
+	}
+	if len(in.Result()) != 2 {
+		t.Errorf("len(Result()) = %d, want 2", len(in.Result()))
+	}
+	if in := ModExpFromBig(nil, nil, big.NewInt(7)); len(in.Base)+len(in.Exponent) != 0 || in.Result()[0] != 1 {
+		t.Errorf("ModExpFromBig(nil, nil, 7) = %+v", in)
+	}
+}
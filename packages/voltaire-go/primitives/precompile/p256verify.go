@@ -0,0 +1,57 @@
+package precompile
+
+import "github.com/voltaire-labs/voltaire-go/crypto/p256"
+
+// RIP-7212 P256VERIFY input size and gas.
+const (
+	P256VerifyInputSize = 160
+	P256VerifyGas       = 3450
+)
+
+// P256VerifyInput is the input of the RIP-7212 P256VERIFY precompile.
+type P256VerifyInput struct {
+	Hash [32]byte
+	R    [32]byte
+	S    [32]byte
+	X    [32]byte
+	Y    [32]byte
+}
+
+// Encode returns the 160-byte precompile input: hash || r || s || x || y.
+func (in P256VerifyInput) Encode() []byte {
+	b := make([]byte, 0, P256VerifyInputSize)
+	for _, w := range [][32]byte{in.Hash, in.R, in.S, in.X, in.Y} {
+		b = append(b, w[:]...)
+	}
+	return b
+}
+
+// ParseP256VerifyInput decodes a 160-byte precompile input.
+func ParseP256VerifyInput(data []byte) (P256VerifyInput, error) {
+	if len(data) != P256VerifyInputSize {
+		return P256VerifyInput{}, ErrInvalidLength
+	}
+	return P256VerifyInput{
+		Hash: [32]byte(data[0:32]),
+		R:    [32]byte(data[32:64]),
+		S:    [32]byte(data[64:96]),
+		X:    [32]byte(data[96:128]),
+		Y:    [32]byte(data[128:160]),
+	}, nil
+}
+
+// Verify reports whether the signature is valid.
+func (in P256VerifyInput) Verify() bool {
+	return p256.Verify(in.Hash, in.R, in.S, in.X, in.Y)
+}
+
+// Run returns the precompile output: the 32-byte word 1 for a valid
+// signature and empty output otherwise.
+func (in P256VerifyInput) Run() []byte {
+	if !in.Verify() {
+		return nil
+	}
+	out := make([]byte, 32)
+	out[31] = 1
+	return out
+}
@@ -0,0 +1,46 @@
+package precompile
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestP256Verify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := P256VerifyInput{Hash: [32]byte{0: 0xaa, 31: 0xbb}}
+	r, s, err := ecdsa.Sign(rand.Reader, key, in.Hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.FillBytes(in.R[:])
+	s.FillBytes(in.S[:])
+	key.X.FillBytes(in.X[:])
+	key.Y.FillBytes(in.Y[:])
+
+	enc := in.Encode()
+	if len(enc) != P256VerifyInputSize || !bytes.Equal(enc[:32], in.Hash[:]) || !bytes.Equal(enc[128:], in.Y[:]) {
+		t.Fatalf("Encode() = %x", enc)
+	}
+	parsed, err := ParseP256VerifyInput(enc)
+	if err != nil || parsed != in {
+		t.Fatalf("ParseP256VerifyInput() = %+v, %v", parsed, err)
+	}
+	if _, err := ParseP256VerifyInput(enc[:159]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("short input error = %v, want %v", err, ErrInvalidLength)
+	}
+
+	if out := in.Run(); !bytes.Equal(out, append(make([]byte, 31), 1)) {
+		t.Errorf("Run() = %x", out)
+	}
+	in.Hash[0] ^= 1
+	if out := in.Run(); len(out) != 0 {
+		t.Errorf("Run() with wrong hash = %x, want empty", out)
+	}
+}
@@ -0,0 +1,113 @@
+package precompile
+
+import (
+	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/blob"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+// Point evaluation sizes and gas (EIP-4844).
+const (
+	PointEvaluationInputSize  = 192
+	PointEvaluationOutputSize = 64
+	PointEvaluationGas        = 50000
+)
+
+// Point evaluation errors
+var (
+	ErrVersionedHashMismatch = errors.New("precompile: versioned hash does not match commitment")
+	ErrProofRejected         = errors.New("precompile: KZG proof rejected")
+)
+
+// blsScalarModulus is the BLS12-381 scalar field modulus, big-endian.
+var blsScalarModulus = [32]byte{
+	0x73, 0xed, 0xa7, 0x53, 0x29, 0x9d, 0x7d, 0x48,
+	0x33, 0x39, 0xd8, 0x08, 0x09, 0xa1, 0xd8, 0x05,
+	0x53, 0xbd, 0xa4, 0x02, 0xff, 0xfe, 0x5b, 0xfe,
+	0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x01,
+}
+
+// PointEvaluationInput is the input of the point evaluation precompile:
+// a claim that the blob behind VersionedHash evaluates to Y at Z.
+type PointEvaluationInput struct {
+	VersionedHash hash.Hash
+	Z             [blob.BytesPerFieldElement]byte
+	Y             [blob.BytesPerFieldElement]byte
+	Commitment    blob.Commitment
+	Proof         blob.Proof
+}
+
+// NewPointEvaluationInput commits to b, evaluates it at z and returns the
+// complete precompile input. z must be below the BLS12-381 scalar field
+// modulus.
+func NewPointEvaluationInput(b *blob.Blob, z [blob.BytesPerFieldElement]byte) (PointEvaluationInput, error) {
+	commitment, err := b.ToCommitment()
+	if err != nil {
+		return PointEvaluationInput{}, err
+	}
+	proof, y, err := b.ComputeProof(z)
+	if err != nil {
+		return PointEvaluationInput{}, err
+	}
+	return PointEvaluationInput{
+		VersionedHash: commitment.VersionedHash(),
+		Z:             z,
+		Y:             y,
+		Commitment:    commitment,
+		Proof:         proof,
+	}, nil
+}
+
+// Encode returns the 192-byte precompile input:
+// versioned_hash || z || y || commitment || proof.
+func (in PointEvaluationInput) Encode() []byte {
+	b := make([]byte, 0, PointEvaluationInputSize)
+	b = append(b, in.VersionedHash[:]...)
+	b = append(b, in.Z[:]...)
+	b = append(b, in.Y[:]...)
+	b = append(b, in.Commitment[:]...)
+	return append(b, in.Proof[:]...)
+}
+
+// ParsePointEvaluationInput decodes a 192-byte precompile input.
+func ParsePointEvaluationInput(data []byte) (PointEvaluationInput, error) {
+	if len(data) != PointEvaluationInputSize {
+		return PointEvaluationInput{}, ErrInvalidLength
+	}
+	return PointEvaluationInput{
+		VersionedHash: hash.Hash(data[0:32]),
+		Z:             [32]byte(data[32:64]),
+		Y:             [32]byte(data[64:96]),
+		Commitment:    blob.Commitment(data[96:144]),
+		Proof:         blob.Proof(data[144:192]),
+	}, nil
+}
+
+// Run checks in the way the precompile does and returns its output. It
+// returns ErrVersionedHashMismatch if the versioned hash does not belong
+// to the commitment and ErrProofRejected if the proof does not verify.
+func (in PointEvaluationInput) Run() ([]byte, error) {
+	if in.Commitment.VersionedHash() != in.VersionedHash {
+		return nil, ErrVersionedHashMismatch
+	}
+	ok, err := blob.VerifyProof(in.Commitment, in.Z, in.Y, in.Proof)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrProofRejected
+	}
+	return PointEvaluationOutput(), nil
+}
+
+// PointEvaluationOutput returns the output of a successful call:
+// FIELD_ELEMENTS_PER_BLOB and the BLS12-381 scalar field modulus as
+// 32-byte big-endian words.
+func PointEvaluationOutput() []byte {
+	out := make([]byte, PointEvaluationOutputSize)
+	out[30] = blob.FieldElementsPerBlob >> 8
+	out[31] = blob.FieldElementsPerBlob & 0xff
+	copy(out[32:], blsScalarModulus[:])
+	return out
+}
@@ -0,0 +1,74 @@
+package precompile
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/blob"
+)
+
+func TestPointEvaluationEncoding(t *testing.T) {
+	in := PointEvaluationInput{
+		Z:          [32]byte{31: 2},
+		Y:          [32]byte{31: 3},
+		Commitment: blob.Commitment{0: 0xc0, 47: 4},
+		Proof:      blob.Proof{0: 0xc0, 47: 5},
+	}
+	in.VersionedHash = in.Commitment.VersionedHash()
+
+	enc := in.Encode()
+	if len(enc) != PointEvaluationInputSize {
+		t.Fatalf("len(Encode()) = %d", len(enc))
+	}
+	for _, off := range []struct {
+		at   int
+		want byte
+	}{{0, blob.VersionKZG}, {63, 2}, {95, 3}, {96, 0xc0}, {143, 4}, {144, 0xc0}, {191, 5}} {
+		if enc[off.at] != off.want {
+			t.Errorf("byte %d = %#x, want %#x", off.at, enc[off.at], off.want)
+		}
+	}
+
+	parsed, err := ParsePointEvaluationInput(enc)
+	if err != nil || parsed != in {
+		t.Errorf("ParsePointEvaluationInput() = %+v, %v", parsed, err)
+	}
+	if _, err := ParsePointEvaluationInput(enc[1:]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("short input error = %v, want %v", err, ErrInvalidLength)
+	}
+
+	in.VersionedHash[31] ^= 1
+	if _, err := in.Run(); !errors.Is(err, ErrVersionedHashMismatch) {
+		t.Errorf("Run() error = %v, want %v", err, ErrVersionedHashMismatch)
+	}
+}
+
+func TestPointEvaluationOutput(t *testing.T) {
+	want := mustHex("0000000000000000000000000000000000000000000000000000000000001000" +
+		"73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+	if got := PointEvaluationOutput(); !bytes.Equal(got, want) {
+		t.Errorf("PointEvaluationOutput() = %x, want %x", got, want)
+	}
+}
+
+func TestNewPointEvaluationInput(t *testing.T) {
+	// The zero blob commits to the zero polynomial, whose commitment and
+	// proofs are the point at infinity.
+	infinity := [48]byte{0: 0xc0}
+	in, err := NewPointEvaluationInput(new(blob.Blob), [32]byte{31: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in.Commitment != infinity || in.Proof != infinity || in.Y != [32]byte{} {
+		t.Errorf("NewPointEvaluationInput() = %+v", in)
+	}
+	if out, err := in.Run(); err != nil || !bytes.Equal(out, PointEvaluationOutput()) {
+		t.Errorf("Run() = %x, %v", out, err)
+	}
+
+	in.Y[31] = 1
+	if _, err := in.Run(); !errors.Is(err, ErrProofRejected) {
+		t.Errorf("Run() with wrong y error = %v, want %v", err, ErrProofRejected)
+	}
+}
@@ -0,0 +1,40 @@
+// Package precompile builds and parses the inputs of Ethereum precompiled
+// contracts and computes their gas costs.
+//
+// The helpers produce the exact byte layouts the precompiles expect, so
+// tests and off-chain tooling can share typed code instead of hand-packed
+// hex.
+package precompile
+
+import (
+	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+)
+
+// Precompile addresses.
+var (
+	ModExpAddress          = address.Address{19: 0x05}
+	ECAddAddress           = address.Address{19: 0x06}
+	ECMulAddress           = address.Address{19: 0x07}
+	ECPairingAddress       = address.Address{19: 0x08}
+	Blake2FAddress         = address.Address{19: 0x09}
+	PointEvaluationAddress = address.Address{19: 0x0a}
+
+	// EIP-2537 BLS12-381 precompiles
+	BLS12G1AddAddress        = address.Address{19: 0x0b}
+	BLS12G1MSMAddress        = address.Address{19: 0x0c}
+	BLS12G2AddAddress        = address.Address{19: 0x0d}
+	BLS12G2MSMAddress        = address.Address{19: 0x0e}
+	BLS12PairingCheckAddress = address.Address{19: 0x0f}
+	BLS12MapFpToG1Address    = address.Address{19: 0x10}
+	BLS12MapFp2ToG2Address   = address.Address{19: 0x11}
+
+	// RIP-7212 secp256r1 verification, deployed on several L2s
+	P256VerifyAddress = address.Address{18: 0x01}
+)
+
+// Errors
+var (
+	ErrInvalidLength = errors.New("precompile: invalid input length")
+)
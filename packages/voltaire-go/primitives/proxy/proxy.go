@@ -0,0 +1,116 @@
+// Package proxy recognizes and builds common proxy contract patterns.
+//
+// EIP-1167 minimal proxies hardcode the implementation address in their
+// runtime code. EIP-1967 proxies store the implementation, admin or beacon
+// address at fixed storage slots, derived as keccak256(label) - 1 so that
+// they cannot collide with Solidity's storage layout.
+package proxy
+
+import (
+	"bytes"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+// EIP-1967 storage slots.
+var (
+	// ImplementationSlot is keccak256("eip1967.proxy.implementation") - 1.
+	ImplementationSlot = hash.MustFromHex("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	// AdminSlot is keccak256("eip1967.proxy.admin") - 1.
+	AdminSlot = hash.MustFromHex("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+	// BeaconSlot is keccak256("eip1967.proxy.beacon") - 1.
+	BeaconSlot = hash.MustFromHex("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+)
+
+// EIP-1167 runtime code surrounding the 20-byte implementation address.
+var (
+	minimalProxyPrefix = []byte{0x36, 0x3d, 0x3d, 0x37, 0x3d, 0x3d, 0x3d, 0x36, 0x3d, 0x73}
+	minimalProxySuffix = []byte{0x5a, 0xf4, 0x3d, 0x82, 0x80, 0x3e, 0x90, 0x3d, 0x91, 0x60, 0x2b, 0x57, 0xfd, 0x5b, 0xf3}
+)
+
+// minimalProxyDeployer copies the 45-byte runtime code that follows it to
+// memory and returns it.
+var minimalProxyDeployer = []byte{0x3d, 0x60, 0x2d, 0x80, 0x60, 0x0a, 0x3d, 0x39, 0x81, 0xf3}
+
+// MinimalProxySize is the length of EIP-1167 runtime code.
+const MinimalProxySize = 45
+
+// IsMinimalProxy returns true if code is EIP-1167 minimal proxy runtime
+// code.
+func IsMinimalProxy(code []byte) bool {
+	_, ok := MinimalProxyImplementation(code)
+	return ok
+}
+
+// MinimalProxyRuntimeCode returns the EIP-1167 runtime code of a clone
+// delegating to impl.
+func MinimalProxyRuntimeCode(impl address.Address) []byte {
+	code := make([]byte, 0, MinimalProxySize)
+	code = append(code, minimalProxyPrefix...)
+	code = append(code, impl[:]...)
+	return append(code, minimalProxySuffix...)
+}
+
+// MinimalProxyInitCode returns the EIP-1167 init code deploying a clone of
+// impl, as used by OpenZeppelin's Clones library.
+func MinimalProxyInitCode(impl address.Address) []byte {
+	return append(append([]byte{}, minimalProxyDeployer...), MinimalProxyRuntimeCode(impl)...)
+}
+
+// MinimalProxyCreate2Address predicts the address of a clone of impl
+// deployed by deployer with CREATE2 and salt.
+func MinimalProxyCreate2Address(deployer address.Address, salt [32]byte, impl address.Address) address.Address {
+	return address.CalculateCreate2Address(deployer, salt, MinimalProxyInitCode(impl))
+}
+
+// MinimalProxyImplementation returns the implementation address embedded
+// in EIP-1167 runtime code. The boolean is false if code is not a minimal
+// proxy.
+func MinimalProxyImplementation(code []byte) (address.Address, bool) {
+	if len(code) != MinimalProxySize ||
+		!bytes.HasPrefix(code, minimalProxyPrefix) ||
+		!bytes.HasSuffix(code, minimalProxySuffix) {
+		return address.Address{}, false
+	}
+
+	var impl address.Address
+	copy(impl[:], code[len(minimalProxyPrefix):])
+	return impl, true
+}
+
+// StorageReader reads a storage slot of an account, for example from an
+// EVM state handle or a JSON-RPC client.
+type StorageReader interface {
+	StorageAt(account address.Address, slot hash.Hash) (hash.Hash, error)
+}
+
+// Implementation reads the EIP-1967 implementation address of proxy.
+// It returns the zero address if the slot is unset.
+func Implementation(r StorageReader, proxy address.Address) (address.Address, error) {
+	return readAddress(r, proxy, ImplementationSlot)
+}
+
+// Admin reads the EIP-1967 admin address of proxy.
+// It returns the zero address if the slot is unset.
+func Admin(r StorageReader, proxy address.Address) (address.Address, error) {
+	return readAddress(r, proxy, AdminSlot)
+}
+
+// Beacon reads the EIP-1967 beacon address of proxy.
+// It returns the zero address if the slot is unset.
+func Beacon(r StorageReader, proxy address.Address) (address.Address, error) {
+	return readAddress(r, proxy, BeaconSlot)
+}
+
+// readAddress reads a slot holding a left-padded address.
+func readAddress(r StorageReader, account address.Address, slot hash.Hash) (address.Address, error) {
+	word, err := r.StorageAt(account, slot)
+	if err != nil {
+		return address.Address{}, err
+	}
+
+	var a address.Address
+	copy(a[:], word[hash.Size-address.Size:])
+	return a, nil
+}
@@ -0,0 +1,128 @@
+package proxy
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+var impl = address.MustFromHex("0xbebebebebebebebebebebebebebebebebebebebe")
+
+func TestSlots(t *testing.T) {
+	tests := []struct {
+		label string
+		slot  hash.Hash
+	}{
+		{"eip1967.proxy.implementation", ImplementationSlot},
+		{"eip1967.proxy.admin", AdminSlot},
+		{"eip1967.proxy.beacon", BeaconSlot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			h := keccak256.HashString(tt.label)
+			want := new(big.Int).Sub(new(big.Int).SetBytes(h[:]), big.NewInt(1))
+			if got := new(big.Int).SetBytes(tt.slot[:]); got.Cmp(want) != 0 {
+				t.Errorf("slot = %x, want keccak256(label) - 1 = %x", got, want)
+			}
+		})
+	}
+}
+
+func TestMinimalProxyImplementation(t *testing.T) {
+	// Runtime code from EIP-1167
+	code, _ := hex.DecodeString("363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd5bf3")
+
+	got, ok := MinimalProxyImplementation(code)
+	if !ok {
+		t.Fatal("minimal proxy not recognized")
+	}
+	if got != impl {
+		t.Errorf("implementation = %s, want %s", got.Hex(), impl.Hex())
+	}
+	if !IsMinimalProxy(code) {
+		t.Error("IsMinimalProxy() = false")
+	}
+
+	tests := []struct {
+		name string
+		code []byte
+	}{
+		{"empty", nil},
+		{"truncated", code[:44]},
+		{"trailing byte", append(append([]byte{}, code...), 0x00)},
+		{"wrong opcode", append([]byte{0x37}, code[1:]...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if IsMinimalProxy(tt.code) {
+				t.Error("IsMinimalProxy() = true")
+			}
+		})
+	}
+}
+
+func TestMinimalProxyConstruction(t *testing.T) {
+	runtime := MinimalProxyRuntimeCode(impl)
+	if got := hex.EncodeToString(runtime); got != "363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd5bf3" {
+		t.Errorf("MinimalProxyRuntimeCode() = %s", got)
+	}
+	if got, ok := MinimalProxyImplementation(runtime); !ok || got != impl {
+		t.Errorf("round trip = %s, %v", got.Hex(), ok)
+	}
+
+	initCode := MinimalProxyInitCode(impl)
+	if got := hex.EncodeToString(initCode); got != "3d602d80600a3d3981f3"+hex.EncodeToString(runtime) {
+		t.Errorf("MinimalProxyInitCode() = %s", got)
+	}
+
+	deployer := address.MustFromHex("0x1111111111111111111111111111111111111111")
+	salt := [32]byte{31: 1}
+	if got, want := MinimalProxyCreate2Address(deployer, salt, impl), address.CalculateCreate2Address(deployer, salt, initCode); got != want {
+		t.Errorf("MinimalProxyCreate2Address() = %s, want %s", got.Hex(), want.Hex())
+	}
+}
+
+type storage map[hash.Hash]hash.Hash
+
+func (s storage) StorageAt(_ address.Address, slot hash.Hash) (hash.Hash, error) {
+	return s[slot], nil
+}
+
+type failingStorage struct{}
+
+var errRead = errors.New("read failed")
+
+func (failingStorage) StorageAt(address.Address, hash.Hash) (hash.Hash, error) {
+	return hash.Hash{}, errRead
+}
+
+func TestReaders(t *testing.T) {
+	admin := address.MustFromHex("0xadadadadadadadadadadadadadadadadadadadad")
+	var implWord, adminWord hash.Hash
+	copy(implWord[12:], impl[:])
+	copy(adminWord[12:], admin[:])
+
+	s := storage{ImplementationSlot: implWord, AdminSlot: adminWord}
+	proxy := address.MustFromHex("0x1111111111111111111111111111111111111111")
+
+	if got, err := Implementation(s, proxy); err != nil || got != impl {
+		t.Errorf("Implementation() = %s, %v", got.Hex(), err)
+	}
+	if got, err := Admin(s, proxy); err != nil || got != admin {
+		t.Errorf("Admin() = %s, %v", got.Hex(), err)
+	}
+	if got, err := Beacon(s, proxy); err != nil || !got.IsZero() {
+		t.Errorf("Beacon() = %s, %v, want zero address", got.Hex(), err)
+	}
+
+	if _, err := Implementation(failingStorage{}, proxy); !errors.Is(err, errRead) {
+		t.Errorf("error = %v, want %v", err, errRead)
+	}
+}
@@ -0,0 +1,11 @@
+package receipt
+
+import "testing"
+
+func FuzzDecodeRLP(f *testing.F) {
+	f.Add([]byte{0xc0})
+	f.Add([]byte{0x02, 0xc4, 0x01, 0x80, 0x80, 0xc0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		DecodeRLP(data)
+	})
+}
@@ -0,0 +1,249 @@
+// Package receipt provides transaction receipts, logs and logs blooms with
+// their consensus RLP encoding.
+//
+// Receipts of typed transactions are encoded as an EIP-2718 envelope,
+// type || rlp(receipt); legacy receipts (type 0) are the bare RLP list.
+// DeriveRoot computes the receipts root committed to in the block header.
+package receipt
+
+import (
+	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+	"github.com/voltaire-labs/voltaire-go/primitives/trie"
+)
+
+// BloomSize is the size of a logs bloom in bytes.
+const BloomSize = 256
+
+// Transaction status codes (EIP-658).
+const (
+	StatusFailed  uint8 = 0
+	StatusSuccess uint8 = 1
+)
+
+// Errors
+var (
+	ErrEmpty        = errors.New("receipt: empty input")
+	ErrInvalidType  = errors.New("receipt: invalid transaction type")
+	ErrInvalidRLP   = errors.New("receipt: invalid RLP structure")
+	ErrInvalidField = errors.New("receipt: invalid field")
+)
+
+// Bloom is a 2048-bit logs bloom filter.
+type Bloom [BloomSize]byte
+
+// Add adds data (an address or topic) to the bloom. Each item sets three
+// bits chosen from the first six bytes of keccak256(data).
+func (b *Bloom) Add(data []byte) {
+	h := keccak256.Hash(data)
+	for i := 0; i < 6; i += 2 {
+		bit := (uint(h[i])<<8 | uint(h[i+1])) & 2047
+		b[BloomSize-1-bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Test returns true if data may have been added to the bloom. False
+// positives are possible; false negatives are not.
+func (b Bloom) Test(data []byte) bool {
+	var probe Bloom
+	probe.Add(data)
+	for i := range probe {
+		if b[i]&probe[i] != probe[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Log is an event emitted during transaction execution.
+type Log struct {
+	Address address.Address
+	Topics  []hash.Hash
+	Data    []byte
+}
+
+// CreateBloom returns the bloom of the addresses and topics of logs.
+func CreateBloom(logs []Log) Bloom {
+	var b Bloom
+	for _, l := range logs {
+		b.Add(l.Address[:])
+		for _, topic := range l.Topics {
+			b.Add(topic[:])
+		}
+	}
+	return b
+}
+
+// Receipt is the consensus part of a transaction receipt.
+type Receipt struct {
+	// Type is the EIP-2718 transaction type; 0 for legacy transactions.
+	Type uint8
+	// Status is StatusSuccess or StatusFailed. Ignored if PostState is set.
+	Status uint8
+	// PostState is the intermediate state root of pre-Byzantium receipts.
+	PostState         []byte
+	CumulativeGasUsed uint64
+	Bloom             Bloom
+	Logs              []Log
+}
+
+// New creates a post-Byzantium receipt and computes its bloom from logs.
+func New(txType uint8, success bool, cumulativeGasUsed uint64, logs []Log) Receipt {
+	status := StatusFailed
+	if success {
+		status = StatusSuccess
+	}
+	return Receipt{
+		Type:              txType,
+		Status:            status,
+		CumulativeGasUsed: cumulativeGasUsed,
+		Bloom:             CreateBloom(logs),
+		Logs:              logs,
+	}
+}
+
+// EncodeRLP returns the consensus encoding of the receipt: the RLP list
+// for legacy receipts, or type || RLP list for typed receipts.
+func (r Receipt) EncodeRLP() []byte {
+	var status []byte
+	switch {
+	case r.PostState != nil:
+		status = r.PostState
+	case r.Status == StatusSuccess:
+		status = []byte{0x01}
+	default:
+		status = []byte{}
+	}
+
+	logs := make([]interface{}, len(r.Logs))
+	for i, l := range r.Logs {
+		topics := make([]interface{}, len(l.Topics))
+		for j := range l.Topics {
+			topics[j] = l.Topics[j][:]
+		}
+		logs[i] = []interface{}{l.Address[:], topics, l.Data}
+	}
+
+	// Cannot fail: all items are supported types
+	encoded, _ := rlp.EncodeList([]interface{}{status, r.CumulativeGasUsed, r.Bloom[:], logs})
+	if r.Type == 0 {
+		return encoded
+	}
+	return append([]byte{r.Type}, encoded...)
+}
+
+// DecodeRLP decodes a receipt in consensus encoding.
+func DecodeRLP(data []byte) (Receipt, error) {
+	if len(data) == 0 {
+		return Receipt{}, ErrEmpty
+	}
+
+	var r Receipt
+	if data[0] < 0x80 {
+		// EIP-2718: types are in [0, 0x7f]; 0 is reserved for legacy
+		if data[0] == 0 {
+			return Receipt{}, ErrInvalidType
+		}
+		r.Type = data[0]
+		data = data[1:]
+	} else if data[0] < 0xc0 {
+		return Receipt{}, ErrInvalidRLP
+	}
+
+	decoded, err := rlp.DecodeBytes(data)
+	if err != nil {
+		return Receipt{}, err
+	}
+	fields, ok := decoded.([]interface{})
+	if !ok || len(fields) != 4 {
+		return Receipt{}, ErrInvalidRLP
+	}
+
+	status, ok := fields[0].([]byte)
+	if !ok {
+		return Receipt{}, ErrInvalidField
+	}
+	switch {
+	case len(status) == hash.Size:
+		r.PostState = status
+	case len(status) == 0:
+		r.Status = StatusFailed
+	case len(status) == 1 && status[0] == 1:
+		r.Status = StatusSuccess
+	default:
+		return Receipt{}, ErrInvalidField
+	}
+
+	gas, ok := fields[1].([]byte)
+	if !ok {
+		return Receipt{}, ErrInvalidField
+	}
+	if r.CumulativeGasUsed, err = rlp.Uint64FromBytes(gas); err != nil {
+		return Receipt{}, ErrInvalidField
+	}
+
+	bloom, ok := fields[2].([]byte)
+	if !ok || len(bloom) != BloomSize {
+		return Receipt{}, ErrInvalidField
+	}
+	copy(r.Bloom[:], bloom)
+
+	logs, ok := fields[3].([]interface{})
+	if !ok {
+		return Receipt{}, ErrInvalidField
+	}
+	for _, item := range logs {
+		l, err := decodeLog(item)
+		if err != nil {
+			return Receipt{}, err
+		}
+		r.Logs = append(r.Logs, l)
+	}
+
+	return r, nil
+}
+
+func decodeLog(item interface{}) (Log, error) {
+	fields, ok := item.([]interface{})
+	if !ok || len(fields) != 3 {
+		return Log{}, ErrInvalidRLP
+	}
+
+	var l Log
+	addr, ok := fields[0].([]byte)
+	if !ok || len(addr) != address.Size {
+		return Log{}, ErrInvalidField
+	}
+	copy(l.Address[:], addr)
+
+	topics, ok := fields[1].([]interface{})
+	if !ok {
+		return Log{}, ErrInvalidField
+	}
+	for _, t := range topics {
+		b, ok := t.([]byte)
+		if !ok || len(b) != hash.Size {
+			return Log{}, ErrInvalidField
+		}
+		l.Topics = append(l.Topics, hash.Hash(b))
+	}
+
+	if l.Data, ok = fields[2].([]byte); !ok {
+		return Log{}, ErrInvalidField
+	}
+	return l, nil
+}
+
+// DeriveRoot computes the receipts root of a block: the root of the trie
+// mapping rlp(index) to each receipt's consensus encoding.
+func DeriveRoot(receipts []Receipt) hash.Hash {
+	values := make([][]byte, len(receipts))
+	for i, r := range receipts {
+		values[i] = r.EncodeRLP()
+	}
+	return trie.OrderedRoot(values)
+}
@@ -0,0 +1,174 @@
+package receipt
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/bits"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/trie"
+)
+
+var transferTopic = hash.MustFromHex("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+func sampleLogs() []Log {
+	return []Log{{
+		Address: address.MustFromHex("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"),
+		Topics: []hash.Hash{
+			transferTopic,
+			hash.MustFromHex("0x000000000000000000000000d8da6bf26964af9d7eed9e03e53415d37aa96045"),
+		},
+		Data: []byte{0x01, 0x02},
+	}}
+}
+
+func TestBloom(t *testing.T) {
+	var b Bloom
+	b.Add(transferTopic[:])
+
+	set := 0
+	for _, x := range b {
+		set += bits.OnesCount8(x)
+	}
+	if set < 1 || set > 3 {
+		t.Errorf("Add set %d bits, want 1 to 3", set)
+	}
+	if !b.Test(transferTopic[:]) {
+		t.Error("Test() = false for added item")
+	}
+	if b.Test([]byte("not added")) {
+		t.Error("Test() = true for item not added")
+	}
+}
+
+func TestCreateBloom(t *testing.T) {
+	logs := sampleLogs()
+	b := CreateBloom(logs)
+
+	if !b.Test(logs[0].Address[:]) {
+		t.Error("bloom missing log address")
+	}
+	for _, topic := range logs[0].Topics {
+		if !b.Test(topic[:]) {
+			t.Errorf("bloom missing topic %s", topic.Hex())
+		}
+	}
+	if CreateBloom(nil) != (Bloom{}) {
+		t.Error("bloom of no logs should be empty")
+	}
+}
+
+func TestEncodeLegacy(t *testing.T) {
+	r := New(0, true, 21000, nil)
+
+	// [0x01, 21000, bloom, []]
+	want := "f90108" + "01" + "825208" + "b90100" + strings.Repeat("00", BloomSize) + "c0"
+	if got := hex.EncodeToString(r.EncodeRLP()); got != want {
+		t.Errorf("EncodeRLP() = %s, want %s", got, want)
+	}
+}
+
+func TestEncodeTyped(t *testing.T) {
+	legacy := New(0, false, 21000, nil).EncodeRLP()
+	typed := New(2, false, 21000, nil).EncodeRLP()
+
+	if typed[0] != 0x02 || !bytes.Equal(typed[1:], legacy) {
+		t.Errorf("typed receipt should be 0x02 || legacy payload, got %x", typed[:4])
+	}
+	// Failed status is the empty string
+	if legacy[3] != 0x80 {
+		t.Errorf("status byte = %#x, want 0x80", legacy[3])
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Receipt
+	}{
+		{"legacy success", New(0, true, 21000, nil)},
+		{"dynamic fee with logs", New(2, true, 1_234_567, sampleLogs())},
+		{"blob failed", New(3, false, 30_000_000, nil)},
+		{"pre-byzantium", Receipt{PostState: bytes.Repeat([]byte{0xab}, 32), CumulativeGasUsed: 42}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeRLP(tt.r.EncodeRLP())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.r) {
+				t.Errorf("got %+v, want %+v", got, tt.r)
+			}
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	valid := New(2, true, 21000, sampleLogs()).EncodeRLP()
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{"empty", nil, ErrEmpty},
+		{"type zero", append([]byte{0x00}, valid[1:]...), ErrInvalidType},
+		{"string", []byte{0x83, 1, 2, 3}, ErrInvalidRLP},
+		{"wrong field count", []byte{0x02, 0xc2, 0x01, 0x01}, ErrInvalidRLP},
+		{"bad status", []byte{0xc4, 0x02, 0x01, 0x80, 0xc0}, ErrInvalidField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeRLP(tt.data); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeriveRoot(t *testing.T) {
+	if got := DeriveRoot(nil); got != trie.EmptyRoot {
+		t.Errorf("DeriveRoot(nil) = %s, want empty root", got.Hex())
+	}
+
+	// The receiptsRoot of any block whose only transaction is a
+	// successful plain transfer
+	tests := []struct {
+		name string
+		r    Receipt
+		want string
+	}{
+		{"legacy transfer", New(0, true, 21000, nil), "0x056b23fbba480696b65fe5a59b8f2148a1299103c4f57df839233af2cf4ca2d2"},
+		{"dynamic fee transfer", New(2, true, 21000, nil), "0xf78dfb743fbd92ade140711c8bbc542b5e307f0ab7984eff35d751969fe57efa"},
+	}
+	for _, tt := range tests {
+		if got := DeriveRoot([]Receipt{tt.r}); got.Hex() != tt.want {
+			t.Errorf("DeriveRoot(%s) = %s, want %s", tt.name, got.Hex(), tt.want)
+		}
+	}
+
+	// Enough receipts for branch and extension nodes, mixing types,
+	// failures and logs
+	var receipts []Receipt
+	var gas uint64
+	for i := 0; i < 20; i++ {
+		gas += 21000 + 1000*uint64(i)
+		var logs []Log
+		if i%3 == 0 {
+			logs = sampleLogs()
+		}
+		receipts = append(receipts, New(uint8(i%4), i%5 != 4, gas, logs))
+	}
+	const want = "0x0a85a774b08c48a77d6a7f6956c271d5c50087b188b9e262e2c54e75fb52248d"
+	if got := DeriveRoot(receipts); got.Hex() != want {
+		t.Errorf("DeriveRoot(20 receipts) = %s, want %s", got.Hex(), want)
+	}
+}
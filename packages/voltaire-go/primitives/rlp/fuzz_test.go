@@ -0,0 +1,16 @@
+package rlp
+
+import "testing"
+
+func FuzzDecode(f *testing.F) {
+	for _, s := range []string{"80", "c0", "83646f67", "c88363617483646f67", "b838", "f800"} {
+		f.Add(hexToBytes(s))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		DecodeBytes(data)
+		DecodeWithRemainder(data)
+		if IsCanonical(data) && !IsValid(data) {
+			t.Errorf("IsCanonical(%x) but not IsValid", data)
+		}
+	})
+}
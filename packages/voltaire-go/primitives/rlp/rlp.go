@@ -19,6 +19,7 @@ var (
 	ErrMaxDepthExceeded  = errors.New("rlp: max depth exceeded")
 	ErrUnsupportedType   = errors.New("rlp: unsupported type")
 	ErrNegativeInteger   = errors.New("rlp: negative integers not supported")
+	ErrUint64Overflow    = errors.New("rlp: integer overflows uint64")
 )
 
 // Encode encodes a byte slice to RLP format.
@@ -57,8 +58,12 @@ func EncodeBigInt(n *big.Int) ([]byte, error) {
 	return encodeBytes(n.Bytes()), nil
 }
 
+// RawValue is an already-encoded RLP item. EncodeList copies it into the
+// output verbatim, which allows embedding pre-encoded structures.
+type RawValue []byte
+
 // EncodeList encodes a list of items to RLP format.
-// Items can be []byte or []interface{} (nested lists).
+// Items can be []byte, RawValue or []interface{} (nested lists).
 func EncodeList(items []interface{}) ([]byte, error) {
 	return encodeList(items, 0)
 }
@@ -77,6 +82,8 @@ func encodeList(items []interface{}, depth int) ([]byte, error) {
 		switch v := item.(type) {
 		case []byte:
 			encoded = encodeBytes(v)
+		case RawValue:
+			encoded = v
 		case []interface{}:
 			encoded, err = encodeList(v, depth+1)
 			if err != nil {
@@ -234,7 +241,8 @@ func decode(data []byte, depth int) (interface{}, []byte, error) {
 			return nil, nil, ErrNonCanonical
 		}
 
-		if len(data) < 1+lenLen+int(length) {
+		// Compare in uint64 so that a huge length cannot overflow int
+		if length > uint64(len(data)-1-lenLen) {
 			return nil, nil, ErrInputTooShort
 		}
 
@@ -276,7 +284,7 @@ func decode(data []byte, depth int) (interface{}, []byte, error) {
 		return nil, nil, ErrNonCanonical
 	}
 
-	if len(data) < 1+lenLen+int(length) {
+	if length > uint64(len(data)-1-lenLen) {
 		return nil, nil, ErrInputTooShort
 	}
 
@@ -313,6 +321,19 @@ func IsCanonical(data []byte) bool {
 	return IsValid(data) // Our decoder rejects non-canonical
 }
 
+// Uint64FromBytes converts a decoded RLP string to a uint64.
+// The string must be a canonical integer: at most 8 bytes, with no leading
+// zero bytes. The empty string is zero.
+func Uint64FromBytes(b []byte) (uint64, error) {
+	if len(b) > 8 {
+		return 0, ErrUint64Overflow
+	}
+	if len(b) > 0 && b[0] == 0 {
+		return 0, ErrNonCanonical
+	}
+	return bytesToUint64(b), nil
+}
+
 // uint64ToBytes converts uint64 to big-endian bytes with no leading zeros.
 func uint64ToBytes(n uint64) []byte {
 	if n == 0 {
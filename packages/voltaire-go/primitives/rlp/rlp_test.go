@@ -454,6 +454,8 @@ func TestDecodeInputTooShort(t *testing.T) {
 		{"truncated list", "c38363"},          // claims 3 bytes, only 2
 		{"truncated long string header", "b8"}, // missing length byte
 		{"truncated long list header", "f8"},   // missing length byte
+		{"huge string length", "bfffffffffffffffff00"},
+		{"huge list length", "ffffffffffffffffff00"},
 	}
 
 	for _, tt := range tests {
@@ -630,6 +632,46 @@ func TestIsValid(t *testing.T) {
 	}
 }
 
+func TestEncodeListRawValue(t *testing.T) {
+	// ["cat", <pre-encoded "dog">]
+	got, err := EncodeList([]interface{}{[]byte("cat"), RawValue(hexToBytes("83646f67"))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "c88363617483646f67"; bytesToHex(got) != want {
+		t.Errorf("got %s, want %s", bytesToHex(got), want)
+	}
+}
+
+func TestUint64FromBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    uint64
+		wantErr error
+	}{
+		{"zero", "", 0, nil},
+		{"one byte", "7f", 0x7f, nil},
+		{"two bytes", "0400", 1024, nil},
+		{"max", "ffffffffffffffff", 1<<64 - 1, nil},
+		{"leading zero", "0001", 0, ErrNonCanonical},
+		{"zero byte", "00", 0, ErrNonCanonical},
+		{"too long", "010000000000000000", 0, ErrUint64Overflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Uint64FromBytes(hexToBytes(tt.input))
+			if err != tt.wantErr {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 // =============================================================================
 // Benchmarks
 // =============================================================================
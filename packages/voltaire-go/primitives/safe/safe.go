@@ -0,0 +1,148 @@
+// Package safe provides Safe (formerly Gnosis Safe) transaction hashing and
+// signature encoding.
+//
+// Owners sign the EIP-712 SafeTx hash. execTransaction takes the owners'
+// signatures concatenated in ascending order of owner address, each as
+// r || s || v, where v also selects how the signature is checked.
+package safe
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/eip712"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/signature"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// SafeTxTypeHash is the type hash of SafeTx.
+var SafeTxTypeHash = eip712.TypeHash("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)")
+
+// Errors
+var (
+	ErrInvalidSignatureLength = errors.New("safe: signature must be 65 bytes")
+	ErrInvalidV               = errors.New("safe: invalid v")
+	ErrDuplicateSigner        = errors.New("safe: duplicate signer")
+)
+
+// Operation is the call type executed by the Safe.
+type Operation uint8
+
+// Operations.
+const (
+	Call         Operation = 0
+	DelegateCall Operation = 1
+)
+
+// Tx is a Safe transaction.
+type Tx struct {
+	To             address.Address
+	Value          u256.U256
+	Data           []byte
+	Operation      Operation
+	SafeTxGas      u256.U256
+	BaseGas        u256.U256
+	GasPrice       u256.U256
+	GasToken       address.Address
+	RefundReceiver address.Address
+	Nonce          u256.U256
+}
+
+// Domain returns the EIP-712 domain of a Safe (v1.3.0 and later), which
+// has only chainId and verifyingContract.
+func Domain(chainID uint64, safe address.Address) eip712.Domain {
+	return eip712.Domain{ChainID: chainID, VerifyingContract: &safe}
+}
+
+// StructHash returns hashStruct(tx).
+func (tx Tx) StructHash() hash.Hash {
+	return eip712.HashStruct(SafeTxTypeHash,
+		eip712.AddressWord(tx.To),
+		[32]byte(tx.Value),
+		[32]byte(keccak256.Hash(tx.Data)),
+		eip712.Uint64Word(uint64(tx.Operation)),
+		[32]byte(tx.SafeTxGas),
+		[32]byte(tx.BaseGas),
+		[32]byte(tx.GasPrice),
+		eip712.AddressWord(tx.GasToken),
+		eip712.AddressWord(tx.RefundReceiver),
+		[32]byte(tx.Nonce),
+	)
+}
+
+// Hash returns the SafeTx hash the owners sign, as returned by
+// getTransactionHash on the Safe at address safe.
+func (tx Tx) Hash(chainID uint64, safe address.Address) hash.Hash {
+	return eip712.Digest(Domain(chainID, safe).Separator(), tx.StructHash())
+}
+
+// Signature is one owner's signature in the format checked by the Safe.
+type Signature struct {
+	Signer address.Address
+	R, S   [32]byte
+	V      uint8
+}
+
+// ECDSASignature wraps an owner's signature over the SafeTx hash. sig is
+// r || s || v with v of 0, 1, 27 or 28.
+func ECDSASignature(signer address.Address, sig []byte) (Signature, error) {
+	return fromRSV(signer, sig, 27)
+}
+
+// EthSignSignature wraps an owner's eth_sign (EIP-191) signature over the
+// SafeTx hash. The Safe recognizes these by v being 31 or 32.
+func EthSignSignature(signer address.Address, sig []byte) (Signature, error) {
+	return fromRSV(signer, sig, 31)
+}
+
+// ApprovedHashSignature returns the signature of an owner that approved
+// the hash on-chain with approveHash, or that is the sender of
+// execTransaction: r is the owner, s is unused and v is 1.
+func ApprovedHashSignature(owner address.Address) Signature {
+	return Signature{Signer: owner, R: eip712.AddressWord(owner), V: 1}
+}
+
+// Bytes returns r || s || v.
+func (s Signature) Bytes() []byte {
+	out := make([]byte, 0, 65)
+	out = append(out, s.R[:]...)
+	out = append(out, s.S[:]...)
+	return append(out, s.V)
+}
+
+// EncodeSignatures returns the signatures argument of execTransaction:
+// the signatures sorted by signer address and concatenated.
+func EncodeSignatures(sigs []Signature) ([]byte, error) {
+	sorted := append([]Signature(nil), sigs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Signer[:], sorted[j].Signer[:]) < 0
+	})
+
+	out := make([]byte, 0, 65*len(sorted))
+	for i, s := range sorted {
+		if i > 0 && s.Signer == sorted[i-1].Signer {
+			return nil, ErrDuplicateSigner
+		}
+		out = append(out, s.Bytes()...)
+	}
+	return out, nil
+}
+
+// fromRSV parses r || s || v and rebases v (0/1 or 27/28) onto base.
+func fromRSV(signer address.Address, sig []byte, base uint8) (Signature, error) {
+	if len(sig) != signature.Size {
+		return Signature{}, ErrInvalidSignatureLength
+	}
+	parsed, _ := signature.Parse(sig)
+
+	// EIP-155 values only appear in legacy transactions
+	yParity, _, err := signature.ParseV(uint64(parsed.V))
+	if err != nil || parsed.V >= 35 {
+		return Signature{}, ErrInvalidV
+	}
+	return Signature{Signer: signer, R: parsed.R, S: parsed.S, V: base + yParity}, nil
+}
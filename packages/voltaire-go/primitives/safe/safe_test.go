@@ -0,0 +1,135 @@
+package safe
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+var (
+	safeAddr = address.MustFromHex("0x1111111111111111111111111111111111111111")
+	ownerA   = address.MustFromHex("0x00000000000000000000000000000000000000aa")
+	ownerB   = address.MustFromHex("0x00000000000000000000000000000000000000bb")
+)
+
+func TestTypeHashes(t *testing.T) {
+	// SAFE_TX_TYPEHASH and DOMAIN_SEPARATOR_TYPEHASH from Safe v1.3.0
+	if got, want := SafeTxTypeHash.Hex(), "0xbb8310d486368db6bd6f849402fdd73ad53d316b5a4b2644ad6efe0f941286d8"; got != want {
+		t.Errorf("SafeTxTypeHash = %s, want %s", got, want)
+	}
+	domainType := keccak256.HashString(Domain(1, safeAddr).EncodeType())
+	if got, want := domainType.Hex(), "0x47e79534a245952e8b16893a336b85a3d9ea9fa8c573f3d803afb92a79469218"; got != want {
+		t.Errorf("domain type hash = %s, want %s", got, want)
+	}
+}
+
+func TestHash(t *testing.T) {
+	tx := Tx{
+		To:        address.MustFromHex("0x2222222222222222222222222222222222222222"),
+		Value:     u256.FromUint64(1),
+		Data:      []byte{0xde, 0xad},
+		Operation: DelegateCall,
+		Nonce:     u256.FromUint64(5),
+	}
+
+	// abi.encode(SAFE_TX_TYPEHASH, to, value, keccak256(data), operation,
+	// safeTxGas, baseGas, gasPrice, gasToken, refundReceiver, nonce)
+	dataHash := keccak256.Hash(tx.Data)
+	operation := u256.FromUint64(1)
+	encoded := bytes.Join([][]byte{
+		SafeTxTypeHash[:],
+		make([]byte, 12), tx.To[:],
+		tx.Value[:],
+		dataHash[:],
+		operation[:],
+		make([]byte, 32*3),
+		make([]byte, 32*2),
+		tx.Nonce[:],
+	}, nil)
+	structHash := keccak256.Hash(encoded)
+	if tx.StructHash() != structHash {
+		t.Fatalf("StructHash() = %s, want %s", tx.StructHash().Hex(), structHash.Hex())
+	}
+
+	separator := Domain(1, safeAddr).Separator()
+	want := keccak256.Hash(bytes.Join([][]byte{{0x19, 0x01}, separator[:], structHash[:]}, nil))
+	if got := tx.Hash(1, safeAddr); got != want {
+		t.Errorf("Hash() = %s, want %s", got.Hex(), want.Hex())
+	}
+
+	if tx.Hash(1, safeAddr) == tx.Hash(5, safeAddr) {
+		t.Error("hash must depend on the chain ID")
+	}
+}
+
+func TestSignatureV(t *testing.T) {
+	sig := append(bytes.Repeat([]byte{0x01}, 64), 28)
+
+	tests := []struct {
+		name  string
+		f     func(address.Address, []byte) (Signature, error)
+		sig   []byte
+		wantV uint8
+	}{
+		{"ecdsa 27/28", ECDSASignature, sig, 28},
+		{"ecdsa 0/1", ECDSASignature, append(sig[:64:64], 0), 27},
+		{"eth_sign", EthSignSignature, sig, 32},
+		{"eth_sign 0/1", EthSignSignature, append(sig[:64:64], 0), 31},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := tt.f(ownerA, tt.sig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.V != tt.wantV {
+				t.Errorf("V = %d, want %d", s.V, tt.wantV)
+			}
+			if !bytes.Equal(s.Bytes()[:64], tt.sig[:64]) {
+				t.Error("r || s not preserved")
+			}
+		})
+	}
+
+	if _, err := ECDSASignature(ownerA, sig[:64]); !errors.Is(err, ErrInvalidSignatureLength) {
+		t.Errorf("short signature error = %v", err)
+	}
+	for _, v := range []byte{2, 29, 35, 37} {
+		if _, err := ECDSASignature(ownerA, append(sig[:64:64], v)); !errors.Is(err, ErrInvalidV) {
+			t.Errorf("v = %d error = %v, want %v", v, err, ErrInvalidV)
+		}
+	}
+}
+
+func TestEncodeSignatures(t *testing.T) {
+	sigB, _ := ECDSASignature(ownerB, append(bytes.Repeat([]byte{0xbb}, 64), 27))
+	sigA := ApprovedHashSignature(ownerA)
+
+	encoded, err := EncodeSignatures([]Signature{sigB, sigA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(encoded) != 130 {
+		t.Fatalf("length = %d, want 130", len(encoded))
+	}
+
+	// Owner A sorts first: r = owner, s = 0, v = 1
+	want := append(make([]byte, 31), 0xaa)
+	want = append(want, make([]byte, 32)...)
+	want = append(want, 1)
+	if !bytes.Equal(encoded[:65], want) {
+		t.Errorf("first signature = %x, want %x", encoded[:65], want)
+	}
+	if !bytes.Equal(encoded[65:], sigB.Bytes()) {
+		t.Errorf("second signature = %x", encoded[65:])
+	}
+
+	if _, err := EncodeSignatures([]Signature{sigA, sigA}); !errors.Is(err, ErrDuplicateSigner) {
+		t.Errorf("duplicate signer error = %v", err)
+	}
+}
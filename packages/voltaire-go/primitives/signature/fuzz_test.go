@@ -0,0 +1,12 @@
+package signature
+
+import "testing"
+
+func FuzzParse(f *testing.F) {
+	f.Add(make([]byte, 64))
+	f.Add(make([]byte, 65))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		Parse(data)
+		FromCompact(data)
+	})
+}
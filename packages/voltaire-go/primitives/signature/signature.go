@@ -2,6 +2,7 @@
 package signature
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -18,6 +19,7 @@ const (
 var (
 	ErrInvalidLength = errors.New("signature: invalid length")
 	ErrInvalidHex    = errors.New("signature: invalid hex")
+	ErrInvalidV      = errors.New("signature: invalid v")
 )
 
 // secp256k1 curve order N
@@ -90,6 +92,89 @@ func MustFromHex(s string) Signature {
 	return sig
 }
 
+// FromCompact creates a Signature from a 64-byte EIP-2098 compact
+// signature (r || yParityAndS), where the top bit of the second word is
+// the y-parity. V is set to the y-parity (0 or 1).
+func FromCompact(b []byte) (Signature, error) {
+	if len(b) != CompactSize {
+		return Signature{}, ErrInvalidLength
+	}
+	var sig Signature
+	copy(sig.R[:], b[:32])
+	copy(sig.S[:], b[32:64])
+	sig.V = sig.S[0] >> 7
+	sig.S[0] &= 0x7f
+	return sig, nil
+}
+
+// Parse creates a Signature from either a 65-byte (r || s || v) or a
+// 64-byte EIP-2098 compact signature.
+func Parse(b []byte) (Signature, error) {
+	if len(b) == CompactSize {
+		return FromCompact(b)
+	}
+	return FromBytes(b)
+}
+
+// ParseHex is like Parse for a hex string, with or without 0x prefix.
+func ParseHex(s string) (Signature, error) {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return Signature{}, ErrInvalidHex
+	}
+	return Parse(b)
+}
+
+// ParseV splits a transaction v value into its y-parity and EIP-155
+// chain ID. v may be 0/1, 27/28 (chain ID 0) or chainId*2 + 35 + yParity.
+func ParseV(v uint64) (yParity byte, chainID uint64, err error) {
+	switch {
+	case v <= 1:
+		return byte(v), 0, nil
+	case v == 27 || v == 28:
+		return byte(v - 27), 0, nil
+	case v >= 35:
+		return byte((v - 35) % 2), (v - 35) / 2, nil
+	default:
+		return 0, 0, ErrInvalidV
+	}
+}
+
+// EIP155V returns the EIP-155 v value of sig for chainID:
+// chainId*2 + 35 + yParity. The result does not fit in V for chain IDs
+// above 109, so it is returned as a uint64.
+func (sig Signature) EIP155V(chainID uint64) uint64 {
+	return chainID*2 + 35 + uint64(sig.YParity())
+}
+
+// Compact returns the 64-byte EIP-2098 encoding (r || yParityAndS).
+// It is only meaningful for low-s signatures.
+func (sig Signature) Compact() []byte {
+	b := make([]byte, CompactSize)
+	copy(b[:32], sig.R[:])
+	copy(b[32:], sig.S[:])
+	b[32] |= sig.YParity() << 7
+	return b
+}
+
+// IsValid returns true if r and s are in [1, N-1] and V is a recognized
+// encoding (0/1, 27/28 or EIP-155). It does not require low s; combine
+// with IsLowS to reject malleable signatures (EIP-2).
+func (sig Signature) IsValid() bool {
+	if sig.V > 1 && sig.V != 27 && sig.V != 28 && sig.V < 35 {
+		return false
+	}
+	return inRange(sig.R) && inRange(sig.S)
+}
+
+// inRange returns true if 0 < x < N.
+func inRange(x [32]byte) bool {
+	return x != [32]byte{} && bytes.Compare(x[:], secp256k1N[:]) < 0
+}
+
 // Bytes returns the 65-byte representation (r || s || v).
 func (sig Signature) Bytes() []byte {
 	b := make([]byte, Size)
@@ -2,6 +2,7 @@ package signature
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -705,3 +706,111 @@ func TestLargeChainIDV(t *testing.T) {
 		t.Errorf("RecoveryID for v=255 = %d, want 0", sig309.RecoveryID())
 	}
 }
+
+func TestFromCompact(t *testing.T) {
+	// EIP-2098 test vector: "Hello World" signed with key 0x1234...1234
+	const compact = "68a020a209d3d56c46f38cc50a33f704f4a9a10a59377f8dd762ac66910e9b90" +
+		"7e865ad05c4035ab5792787d4a0297a43617ae897930a6fe4d822b8faea52064"
+	full := MustFromHex("0x68a020a209d3d56c46f38cc50a33f704f4a9a10a59377f8dd762ac66910e9b90" +
+		"7e865ad05c4035ab5792787d4a0297a43617ae897930a6fe4d822b8faea52064" + "1b")
+
+	sig, err := ParseHex("0x" + compact)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig.R != full.R || sig.S != full.S || sig.YParity() != full.YParity() {
+		t.Errorf("ParseHex(compact) = %s, want %s", sig.Hex(), full.Hex())
+	}
+	if got := hex.EncodeToString(full.Compact()); got != compact {
+		t.Errorf("Compact() = %s, want %s", got, compact)
+	}
+
+	// yParity 1 sets the top bit of s
+	odd := FromRSV(full.R, full.S, 28)
+	c := odd.Compact()
+	if c[32]&0x80 == 0 {
+		t.Error("Compact() did not encode yParity 1")
+	}
+	back, err := Parse(c)
+	if err != nil || back.S != odd.S || back.V != 1 {
+		t.Errorf("Parse(compact) = %s, %v", back.Hex(), err)
+	}
+
+	parsed, err := Parse(full.Bytes())
+	if err != nil || !parsed.Equal(full) {
+		t.Errorf("Parse(65 bytes) = %s, %v", parsed.Hex(), err)
+	}
+	if _, err := Parse(make([]byte, 63)); err != ErrInvalidLength {
+		t.Errorf("Parse(63 bytes) error = %v, want %v", err, ErrInvalidLength)
+	}
+	if _, err := ParseHex("0xzz"); err != ErrInvalidHex {
+		t.Errorf("ParseHex(invalid) error = %v, want %v", err, ErrInvalidHex)
+	}
+}
+
+func TestParseV(t *testing.T) {
+	tests := []struct {
+		v       uint64
+		yParity byte
+		chainID uint64
+		wantErr bool
+	}{
+		{0, 0, 0, false},
+		{1, 1, 0, false},
+		{27, 0, 0, false},
+		{28, 1, 0, false},
+		{37, 0, 1, false},
+		{38, 1, 1, false},
+		{2*11155111 + 36, 1, 11155111, false},
+		{2, 0, 0, true},
+		{30, 0, 0, true},
+		{34, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		yParity, chainID, err := ParseV(tt.v)
+		if tt.wantErr {
+			if err != ErrInvalidV {
+				t.Errorf("ParseV(%d) error = %v, want %v", tt.v, err, ErrInvalidV)
+			}
+			continue
+		}
+		if err != nil || yParity != tt.yParity || chainID != tt.chainID {
+			t.Errorf("ParseV(%d) = %d, %d, %v", tt.v, yParity, chainID, err)
+		}
+	}
+
+	sig := FromRSV([32]byte{31: 1}, [32]byte{31: 1}, 1)
+	if got := sig.EIP155V(11155111); got != 2*11155111+36 {
+		t.Errorf("EIP155V() = %d", got)
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	one := [32]byte{31: 1}
+	nMinusOne := testSecp256k1N
+	nMinusOne[31]--
+
+	tests := []struct {
+		name string
+		sig  Signature
+		want bool
+	}{
+		{"minimal", FromRSV(one, one, 27), true},
+		{"max components", FromRSV(nMinusOne, nMinusOne, 0), true},
+		{"eip-155 v", FromRSV(one, one, 37), true},
+		{"zero r", FromRSV([32]byte{}, one, 27), false},
+		{"zero s", FromRSV(one, [32]byte{}, 27), false},
+		{"r = n", FromRSV(testSecp256k1N, one, 27), false},
+		{"s = n", FromRSV(one, testSecp256k1N, 27), false},
+		{"bad v", FromRSV(one, one, 29), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sig.IsValid(); got != tt.want {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
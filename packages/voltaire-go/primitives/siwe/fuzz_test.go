@@ -0,0 +1,16 @@
+package siwe
+
+import "testing"
+
+func FuzzParse(f *testing.F) {
+	f.Add("example.com wants you to sign in with your Ethereum account:\n0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F\n\nURI: https://example.com\nVersion: 1\nChain ID: 1\nNonce: 12345678\nIssued At: 2021-09-30T16:25:24Z")
+	f.Fuzz(func(t *testing.T, s string) {
+		m, err := Parse(s)
+		if err != nil {
+			return
+		}
+		if _, err := Parse(m.String()); err != nil {
+			t.Errorf("Parse(String()) of %q: %v", s, err)
+		}
+	})
+}
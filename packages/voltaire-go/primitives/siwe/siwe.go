@@ -0,0 +1,407 @@
+// Package siwe parses, formats and verifies Sign-In with Ethereum
+// (EIP-4361) messages.
+//
+// The signed payload is the formatted message text, hashed as an EIP-191
+// personal message. Externally owned accounts are verified by recovering
+// the signer; contract accounts can be verified through ERC-1271 with a
+// Caller.
+package siwe
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/bytes4"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/signature"
+)
+
+// Version is the only message version defined by EIP-4361.
+const Version = "1"
+
+// MinNonceLength is the minimum length of a nonce.
+const MinNonceLength = 8
+
+// Errors
+var (
+	ErrInvalidMessage   = errors.New("siwe: malformed message")
+	ErrInvalidAddress   = errors.New("siwe: address must be EIP-55 checksummed")
+	ErrInvalidVersion   = errors.New("siwe: unsupported version")
+	ErrInvalidNonce     = errors.New("siwe: nonce must be at least 8 alphanumeric characters")
+	ErrInvalidTime      = errors.New("siwe: invalid RFC 3339 timestamp")
+	ErrDomainMismatch   = errors.New("siwe: domain mismatch")
+	ErrNonceMismatch    = errors.New("siwe: nonce mismatch")
+	ErrExpired          = errors.New("siwe: message expired")
+	ErrNotYetValid      = errors.New("siwe: message not yet valid")
+	ErrInvalidSignature = errors.New("siwe: invalid signature")
+)
+
+const (
+	preambleSuffix  = " wants you to sign in with your Ethereum account:"
+	uriTag          = "URI: "
+	versionTag      = "Version: "
+	chainIDTag      = "Chain ID: "
+	nonceTag        = "Nonce: "
+	issuedAtTag     = "Issued At: "
+	expirationTag   = "Expiration Time: "
+	notBeforeTag    = "Not Before: "
+	requestIDTag    = "Request ID: "
+	resourcesHeader = "Resources:"
+	resourcePrefix  = "- "
+)
+
+// Message is a Sign-In with Ethereum message.
+//
+// Timestamps are kept as the RFC 3339 strings that appear in the message,
+// so that formatting a parsed message reproduces the signed text exactly.
+type Message struct {
+	// Scheme is the optional URI scheme of the requesting origin.
+	Scheme  string
+	Domain  string
+	Address address.Address
+	// Statement is an optional human-readable assertion. It must not
+	// contain a newline.
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        uint64
+	Nonce          string
+	IssuedAt       string
+	ExpirationTime string
+	NotBefore      string
+	RequestID      string
+	Resources      []string
+}
+
+// Parse parses a message in the EIP-4361 format.
+func Parse(s string) (Message, error) {
+	lines := strings.Split(s, "\n")
+	p := parser{lines: lines}
+	var m Message
+
+	preamble, ok := p.next()
+	if !ok || !strings.HasSuffix(preamble, preambleSuffix) {
+		return Message{}, ErrInvalidMessage
+	}
+	origin := strings.TrimSuffix(preamble, preambleSuffix)
+	if scheme, domain, found := strings.Cut(origin, "://"); found {
+		m.Scheme, origin = scheme, domain
+	}
+	if origin == "" || strings.ContainsAny(origin, " /") {
+		return Message{}, ErrInvalidMessage
+	}
+	m.Domain = origin
+
+	addr, ok := p.next()
+	if !ok {
+		return Message{}, ErrInvalidMessage
+	}
+	// EIP-4361 requires the EIP-55 form, which always starts with "0x"
+	if !strings.HasPrefix(addr, "0x") || !address.ValidateChecksum(addr) {
+		return Message{}, ErrInvalidAddress
+	}
+	m.Address = address.MustFromHex(addr)
+
+	// address LF LF [statement LF] LF
+	if blank, ok := p.next(); !ok || blank != "" {
+		return Message{}, ErrInvalidMessage
+	}
+	line, ok := p.next()
+	if !ok {
+		return Message{}, ErrInvalidMessage
+	}
+	if line != "" {
+		m.Statement = line
+		if blank, ok := p.next(); !ok || blank != "" {
+			return Message{}, ErrInvalidMessage
+		}
+	}
+
+	var err error
+	if m.URI, err = p.field(uriTag); err != nil {
+		return Message{}, err
+	}
+	if m.Version, err = p.field(versionTag); err != nil {
+		return Message{}, err
+	}
+	chainID, err := p.field(chainIDTag)
+	if err != nil {
+		return Message{}, err
+	}
+	if m.ChainID, err = strconv.ParseUint(chainID, 10, 64); err != nil {
+		return Message{}, ErrInvalidMessage
+	}
+	if m.Nonce, err = p.field(nonceTag); err != nil {
+		return Message{}, err
+	}
+	if m.IssuedAt, err = p.field(issuedAtTag); err != nil {
+		return Message{}, err
+	}
+	m.ExpirationTime = p.optionalField(expirationTag)
+	m.NotBefore = p.optionalField(notBeforeTag)
+	m.RequestID = p.optionalField(requestIDTag)
+
+	if line, ok := p.peek(); ok && line == resourcesHeader {
+		p.next()
+		for {
+			line, ok := p.peek()
+			if !ok || !strings.HasPrefix(line, resourcePrefix) {
+				break
+			}
+			p.next()
+			m.Resources = append(m.Resources, strings.TrimPrefix(line, resourcePrefix))
+		}
+	}
+	if !p.done() {
+		return Message{}, ErrInvalidMessage
+	}
+
+	if err := m.check(); err != nil {
+		return Message{}, err
+	}
+	return m, nil
+}
+
+// String formats m in the EIP-4361 format. This is the text the user signs.
+func (m Message) String() string {
+	origin := m.Domain
+	if m.Scheme != "" {
+		origin = m.Scheme + "://" + origin
+	}
+
+	lines := []string{origin + preambleSuffix, m.Address.ChecksumHex(), ""}
+	if m.Statement != "" {
+		lines = append(lines, m.Statement)
+	}
+	lines = append(lines, "",
+		uriTag+m.URI,
+		versionTag+m.Version,
+		chainIDTag+strconv.FormatUint(m.ChainID, 10),
+		nonceTag+m.Nonce,
+		issuedAtTag+m.IssuedAt,
+	)
+	if m.ExpirationTime != "" {
+		lines = append(lines, expirationTag+m.ExpirationTime)
+	}
+	if m.NotBefore != "" {
+		lines = append(lines, notBeforeTag+m.NotBefore)
+	}
+	if m.RequestID != "" {
+		lines = append(lines, requestIDTag+m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		lines = append(lines, resourcesHeader)
+		for _, r := range m.Resources {
+			lines = append(lines, resourcePrefix+r)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Hash returns the EIP-191 personal message hash of the formatted message:
+// keccak256("\x19Ethereum Signed Message:\n" || len || message).
+func (m Message) Hash() hash.Hash {
+	msg := m.String()
+	prefix := "\x19Ethereum Signed Message:\n" + strconv.Itoa(len(msg))
+	return keccak256.Sum([]byte(prefix), []byte(msg))
+}
+
+// ValidateOptions are the checks Validate applies besides the message's
+// own well-formedness. Empty fields are not checked.
+type ValidateOptions struct {
+	// Domain is the domain the server expects the message to be bound to.
+	Domain string
+	// Nonce is the nonce the server issued for this sign-in.
+	Nonce string
+	// Time is the time the expiry and not-before bounds are checked
+	// against. The zero value means time.Now().
+	Time time.Time
+}
+
+// Validate checks that m is well formed, matches opts and is valid at
+// opts.Time.
+func (m Message) Validate(opts ValidateOptions) error {
+	if err := m.check(); err != nil {
+		return err
+	}
+	if opts.Domain != "" && m.Domain != opts.Domain {
+		return ErrDomainMismatch
+	}
+	if opts.Nonce != "" && m.Nonce != opts.Nonce {
+		return ErrNonceMismatch
+	}
+
+	now := opts.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if m.ExpirationTime != "" {
+		exp, _ := time.Parse(time.RFC3339, m.ExpirationTime) // checked above
+		if !now.Before(exp) {
+			return ErrExpired
+		}
+	}
+	if m.NotBefore != "" {
+		nbf, _ := time.Parse(time.RFC3339, m.NotBefore) // checked above
+		if now.Before(nbf) {
+			return ErrNotYetValid
+		}
+	}
+	return nil
+}
+
+// RecoverAddress recovers the externally owned account that signed m. sig
+// is a 65-byte r || s || v signature with v of 0, 1, 27 or 28, or a 64-byte
+// EIP-2098 compact signature.
+func (m Message) RecoverAddress(sig []byte) (address.Address, error) {
+	parsed, err := signature.Parse(sig)
+	if err != nil || !parsed.IsValid() || (parsed.V > 1 && parsed.V != 27 && parsed.V != 28) {
+		return address.Address{}, ErrInvalidSignature
+	}
+	addr, err := ffi.Secp256k1RecoverAddress(m.Hash(), parsed.R, parsed.S, parsed.YParity())
+	if err != nil {
+		return address.Address{}, ErrInvalidSignature
+	}
+	return address.Address(addr), nil
+}
+
+// Caller executes a read-only call against a contract, for example through
+// an EVM state handle or a JSON-RPC client.
+type Caller interface {
+	Call(to address.Address, data []byte) ([]byte, error)
+}
+
+// IsValidSignatureSelector is the ERC-1271 isValidSignature(bytes32,bytes)
+// selector, which is also the magic value returned for a valid signature.
+var IsValidSignatureSelector = bytes4.Selector("isValidSignature(bytes32,bytes)")
+
+// Verify validates m against opts and checks that sig was produced by
+// m.Address. If the recovered signer does not match and c is non-nil, the
+// signature is checked with ERC-1271 isValidSignature on m.Address.
+func (m Message) Verify(sig []byte, opts ValidateOptions, c Caller) error {
+	if err := m.Validate(opts); err != nil {
+		return err
+	}
+
+	if signer, err := m.RecoverAddress(sig); err == nil && signer == m.Address {
+		return nil
+	}
+	if c == nil {
+		return ErrInvalidSignature
+	}
+
+	out, err := c.Call(m.Address, encodeIsValidSignature(m.Hash(), sig))
+	if err != nil {
+		return err
+	}
+	// The magic value is returned as a left-aligned bytes4 word
+	if len(out) < 32 || !IsValidSignatureSelector.Matches(out) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// encodeIsValidSignature ABI-encodes isValidSignature(bytes32,bytes).
+func encodeIsValidSignature(h hash.Hash, sig []byte) []byte {
+	padded := (len(sig) + 31) / 32 * 32
+	data := make([]byte, 4+32*3+padded)
+	copy(data, IsValidSignatureSelector[:])
+	copy(data[4:], h[:])
+	data[4+63] = 0x40 // offset of the bytes argument
+	putUint64(data[4+64:4+96], uint64(len(sig)))
+	copy(data[4+96:], sig)
+	return data
+}
+
+func putUint64(word []byte, v uint64) {
+	for i := len(word) - 1; v > 0; i-- {
+		word[i] = byte(v)
+		v >>= 8
+	}
+}
+
+// check validates the fields that EIP-4361 constrains.
+func (m Message) check() error {
+	if m.Domain == "" || m.URI == "" {
+		return ErrInvalidMessage
+	}
+	if strings.Contains(m.Statement, "\n") {
+		return ErrInvalidMessage
+	}
+	if m.Version != Version {
+		return ErrInvalidVersion
+	}
+	if len(m.Nonce) < MinNonceLength || !isAlphanumeric(m.Nonce) {
+		return ErrInvalidNonce
+	}
+	if _, err := time.Parse(time.RFC3339, m.IssuedAt); err != nil {
+		return ErrInvalidTime
+	}
+	for _, ts := range []string{m.ExpirationTime, m.NotBefore} {
+		if ts == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, ts); err != nil {
+			return ErrInvalidTime
+		}
+	}
+	return nil
+}
+
+func isAlphanumeric(s string) bool {
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// parser walks the lines of a message.
+type parser struct {
+	lines []string
+	pos   int
+}
+
+func (p *parser) peek() (string, bool) {
+	if p.pos >= len(p.lines) {
+		return "", false
+	}
+	return p.lines[p.pos], true
+}
+
+func (p *parser) next() (string, bool) {
+	line, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return line, ok
+}
+
+func (p *parser) done() bool {
+	return p.pos == len(p.lines)
+}
+
+// field consumes a required "Tag: value" line.
+func (p *parser) field(tag string) (string, error) {
+	line, ok := p.next()
+	if !ok || !strings.HasPrefix(line, tag) {
+		return "", ErrInvalidMessage
+	}
+	return strings.TrimPrefix(line, tag), nil
+}
+
+// optionalField consumes a "Tag: value" line if the next line has tag.
+func (p *parser) optionalField(tag string) string {
+	line, ok := p.peek()
+	if !ok || !strings.HasPrefix(line, tag) {
+		return ""
+	}
+	p.pos++
+	return strings.TrimPrefix(line, tag)
+}
@@ -0,0 +1,239 @@
+package siwe
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/privatekey"
+)
+
+// specMessage is the example message from EIP-4361.
+const specMessage = `service.invalid wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+I accept the ServiceOrg Terms of Service: https://service.invalid/tos
+
+URI: https://service.invalid/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+var testKey = privatekey.MustFromHex("0x4646464646464646464646464646464646464646464646464646464646464646")
+
+func TestParse(t *testing.T) {
+	m, err := Parse(specMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Message{
+		Domain:    "service.invalid",
+		Address:   address.MustFromHex("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+		Statement: "I accept the ServiceOrg Terms of Service: https://service.invalid/tos",
+		URI:       "https://service.invalid/login",
+		Version:   "1",
+		ChainID:   1,
+		Nonce:     "32891756",
+		IssuedAt:  "2021-09-30T16:25:24Z",
+		Resources: []string{
+			"ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/",
+			"https://example.com/my-web2-claim.json",
+		},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("Parse() = %+v, want %+v", m, want)
+	}
+	if got := m.String(); got != specMessage {
+		t.Errorf("String() = %q, want %q", got, specMessage)
+	}
+}
+
+func TestParseOptionalFields(t *testing.T) {
+	const msg = `https://example.com wants you to sign in with your Ethereum account:
+0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F
+
+
+URI: https://example.com
+Version: 1
+Chain ID: 10
+Nonce: abcdefgh12
+Issued At: 2024-01-01T00:00:00Z
+Expiration Time: 2024-01-02T00:00:00Z
+Not Before: 2024-01-01T00:00:00.5+01:00
+Request ID: req-1`
+
+	m, err := Parse(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Scheme != "https" || m.Domain != "example.com" || m.Statement != "" {
+		t.Errorf("origin/statement = %q %q %q", m.Scheme, m.Domain, m.Statement)
+	}
+	if m.ExpirationTime != "2024-01-02T00:00:00Z" || m.NotBefore != "2024-01-01T00:00:00.5+01:00" || m.RequestID != "req-1" {
+		t.Errorf("optional fields = %+v", m)
+	}
+	if got := m.String(); got != msg {
+		t.Errorf("String() = %q, want %q", got, msg)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		old     string
+		new     string
+		wantErr error
+	}{
+		{"bad preamble", "wants you to sign in", "wants to sign in", ErrInvalidMessage},
+		{"lowercase address", "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", ErrInvalidAddress},
+		{"address without prefix", "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "C02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", ErrInvalidAddress},
+		{"address with uppercase prefix", "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0XC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", ErrInvalidAddress},
+		{"version", "Version: 1", "Version: 2", ErrInvalidVersion},
+		{"short nonce", "Nonce: 32891756", "Nonce: 1234", ErrInvalidNonce},
+		{"nonce not alphanumeric", "Nonce: 32891756", "Nonce: 3289-1756", ErrInvalidNonce},
+		{"chain id", "Chain ID: 1", "Chain ID: one", ErrInvalidMessage},
+		{"issued at", "2021-09-30T16:25:24Z", "yesterday", ErrInvalidTime},
+		{"missing uri", "URI: https://service.invalid/login\n", "", ErrInvalidMessage},
+		{"trailing line", "my-web2-claim.json", "my-web2-claim.json\nextra", ErrInvalidMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := string(bytes.Replace([]byte(specMessage), []byte(tt.old), []byte(tt.new), 1))
+			if _, err := Parse(msg); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func testMessage() Message {
+	return Message{
+		Domain:         "example.com",
+		Address:        address.Address(testKey.Address()),
+		URI:            "https://example.com/login",
+		Version:        Version,
+		ChainID:        1,
+		Nonce:          "k3v8Zq1xW9",
+		IssuedAt:       "2024-01-01T00:00:00Z",
+		ExpirationTime: "2024-01-01T01:00:00Z",
+		NotBefore:      "2024-01-01T00:00:00Z",
+	}
+}
+
+func TestValidate(t *testing.T) {
+	m := testMessage()
+	at := func(s string) time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+
+	tests := []struct {
+		name    string
+		opts    ValidateOptions
+		wantErr error
+	}{
+		{"valid", ValidateOptions{Domain: "example.com", Nonce: "k3v8Zq1xW9", Time: at("2024-01-01T00:30:00Z")}, nil},
+		{"domain", ValidateOptions{Domain: "evil.com", Time: at("2024-01-01T00:30:00Z")}, ErrDomainMismatch},
+		{"nonce", ValidateOptions{Nonce: "reusedNonce", Time: at("2024-01-01T00:30:00Z")}, ErrNonceMismatch},
+		{"expired", ValidateOptions{Time: at("2024-01-01T01:00:00Z")}, ErrExpired},
+		{"not yet valid", ValidateOptions{Time: at("2023-12-31T23:59:59Z")}, ErrNotYetValid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := m.Validate(tt.opts); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	m := testMessage()
+	opts := ValidateOptions{Time: time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)}
+
+	sig, err := testKey.Sign(m.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := m.RecoverAddress(sig)
+	if err != nil || signer != m.Address {
+		t.Fatalf("RecoverAddress() = %s, %v, want %s", signer, err, m.Address)
+	}
+	if err := m.Verify(sig, opts, nil); err != nil {
+		t.Errorf("Verify() = %v", err)
+	}
+
+	// Wallets commonly return v as 27 or 28
+	legacy := append([]byte{}, sig...)
+	legacy[64] += 27
+	if err := m.Verify(legacy, opts, nil); err != nil {
+		t.Errorf("Verify(v+27) = %v", err)
+	}
+
+	other := m
+	other.Nonce = "differentNonce"
+	if err := other.Verify(sig, opts, nil); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify(tampered) = %v, want %v", err, ErrInvalidSignature)
+	}
+	if err := m.Verify(sig[:10], opts, nil); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify(short signature) = %v, want %v", err, ErrInvalidSignature)
+	}
+}
+
+// wallet is a Caller that answers isValidSignature like an ERC-1271
+// contract wallet accepting a single signature.
+type wallet struct {
+	account address.Address
+	sig     []byte
+	calls   int
+}
+
+func (w *wallet) Call(to address.Address, data []byte) ([]byte, error) {
+	w.calls++
+	out := make([]byte, 32)
+	if to == w.account && IsValidSignatureSelector.Matches(data) &&
+		data[4+95] == byte(len(w.sig)) && bytes.HasPrefix(data[4+96:], w.sig) {
+		copy(out, IsValidSignatureSelector[:])
+	}
+	return out, nil
+}
+
+func TestVerifyERC1271(t *testing.T) {
+	m := testMessage()
+	m.Address = address.MustFromHex("0x00000000000000000000000000000000c0ffee01")
+	opts := ValidateOptions{Time: time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)}
+
+	sig := bytes.Repeat([]byte{0xab}, 70)
+	w := &wallet{account: m.Address, sig: sig}
+	if err := m.Verify(sig, opts, w); err != nil {
+		t.Errorf("Verify() = %v", err)
+	}
+	if w.calls != 1 {
+		t.Errorf("isValidSignature called %d times, want 1", w.calls)
+	}
+
+	data := encodeIsValidSignature(m.Hash(), sig)
+	if len(data) != 4+32*3+96 || data[4+63] != 0x40 || data[4+95] != 70 {
+		t.Errorf("calldata layout = %x", data)
+	}
+
+	if err := m.Verify([]byte{1, 2, 3}, opts, w); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify(rejected) = %v, want %v", err, ErrInvalidSignature)
+	}
+	if err := m.Verify(sig, opts, nil); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify(no caller) = %v, want %v", err, ErrInvalidSignature)
+	}
+}
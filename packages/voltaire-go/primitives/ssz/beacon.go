@@ -0,0 +1,55 @@
+package ssz
+
+import (
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+// BeaconBlockHeaderSize is the SSZ size of a BeaconBlockHeader.
+const BeaconBlockHeaderSize = 112
+
+// BeaconBlockHeader is the consensus-layer block header. Its hash tree root
+// is the beacon block root exposed to the execution layer by EIP-4788.
+type BeaconBlockHeader struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    hash.Hash
+	StateRoot     hash.Hash
+	BodyRoot      hash.Hash
+}
+
+// EncodeSSZ returns the SSZ serialization of h.
+func (h BeaconBlockHeader) EncodeSSZ() []byte {
+	b := make([]byte, 0, BeaconBlockHeaderSize)
+	b = appendUint64(b, h.Slot)
+	b = appendUint64(b, h.ProposerIndex)
+	b = append(b, h.ParentRoot[:]...)
+	b = append(b, h.StateRoot[:]...)
+	return append(b, h.BodyRoot[:]...)
+}
+
+// HashTreeRoot returns the hash tree root of h.
+func (h BeaconBlockHeader) HashTreeRoot() hash.Hash {
+	return ContainerRoot(
+		Uint64Root(h.Slot),
+		Uint64Root(h.ProposerIndex),
+		h.ParentRoot,
+		h.StateRoot,
+		h.BodyRoot,
+	)
+}
+
+// DecodeBeaconBlockHeader decodes an SSZ-serialized BeaconBlockHeader.
+func DecodeBeaconBlockHeader(data []byte) (BeaconBlockHeader, error) {
+	if len(data) != BeaconBlockHeaderSize {
+		return BeaconBlockHeader{}, ErrInvalidLength
+	}
+
+	r := reader{data: data}
+	var h BeaconBlockHeader
+	h.Slot = r.uint64()
+	h.ProposerIndex = r.uint64()
+	r.fixed(h.ParentRoot[:])
+	r.fixed(h.StateRoot[:])
+	r.fixed(h.BodyRoot[:])
+	return h, r.err
+}
@@ -0,0 +1,12 @@
+package ssz
+
+import "testing"
+
+func FuzzDecode(f *testing.F) {
+	f.Add(make([]byte, 112))
+	f.Add(make([]byte, 584))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		DecodeBeaconBlockHeader(data)
+		DecodeExecutionPayloadHeader(data)
+	})
+}
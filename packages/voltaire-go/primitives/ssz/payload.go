@@ -0,0 +1,134 @@
+package ssz
+
+import (
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/receipt"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// MaxExtraDataBytes is the maximum length of ExecutionPayloadHeader.ExtraData.
+const MaxExtraDataBytes = 32
+
+// executionPayloadHeaderFixedSize is the size of the fixed part of an
+// ExecutionPayloadHeader, including the extra data offset.
+const executionPayloadHeaderFixedSize = 584
+
+// ExecutionPayloadHeader is the Deneb execution payload header carried in
+// beacon block bodies and states.
+type ExecutionPayloadHeader struct {
+	ParentHash       hash.Hash
+	FeeRecipient     address.Address
+	StateRoot        hash.Hash
+	ReceiptsRoot     hash.Hash
+	LogsBloom        receipt.Bloom
+	PrevRandao       hash.Hash
+	BlockNumber      uint64
+	GasLimit         uint64
+	GasUsed          uint64
+	Timestamp        uint64
+	ExtraData        []byte
+	BaseFeePerGas    u256.U256
+	BlockHash        hash.Hash
+	TransactionsRoot hash.Hash
+	WithdrawalsRoot  hash.Hash
+	BlobGasUsed      uint64
+	ExcessBlobGas    uint64
+}
+
+// EncodeSSZ returns the SSZ serialization of h. It returns ErrListTooLong
+// if ExtraData exceeds MaxExtraDataBytes.
+func (h ExecutionPayloadHeader) EncodeSSZ() ([]byte, error) {
+	if len(h.ExtraData) > MaxExtraDataBytes {
+		return nil, ErrListTooLong
+	}
+
+	b := make([]byte, 0, executionPayloadHeaderFixedSize+len(h.ExtraData))
+	b = append(b, h.ParentHash[:]...)
+	b = append(b, h.FeeRecipient[:]...)
+	b = append(b, h.StateRoot[:]...)
+	b = append(b, h.ReceiptsRoot[:]...)
+	b = append(b, h.LogsBloom[:]...)
+	b = append(b, h.PrevRandao[:]...)
+	b = appendUint64(b, h.BlockNumber)
+	b = appendUint64(b, h.GasLimit)
+	b = appendUint64(b, h.GasUsed)
+	b = appendUint64(b, h.Timestamp)
+	b = appendUint32(b, executionPayloadHeaderFixedSize)
+	b = appendUint256(b, h.BaseFeePerGas)
+	b = append(b, h.BlockHash[:]...)
+	b = append(b, h.TransactionsRoot[:]...)
+	b = append(b, h.WithdrawalsRoot[:]...)
+	b = appendUint64(b, h.BlobGasUsed)
+	b = appendUint64(b, h.ExcessBlobGas)
+	return append(b, h.ExtraData...), nil
+}
+
+// HashTreeRoot returns the hash tree root of h. It returns ErrListTooLong
+// if ExtraData exceeds MaxExtraDataBytes.
+func (h ExecutionPayloadHeader) HashTreeRoot() (hash.Hash, error) {
+	if len(h.ExtraData) > MaxExtraDataBytes {
+		return hash.Hash{}, ErrListTooLong
+	}
+
+	return ContainerRoot(
+		h.ParentHash,
+		BytesRoot(h.FeeRecipient[:]),
+		h.StateRoot,
+		h.ReceiptsRoot,
+		BytesRoot(h.LogsBloom[:]),
+		h.PrevRandao,
+		Uint64Root(h.BlockNumber),
+		Uint64Root(h.GasLimit),
+		Uint64Root(h.GasUsed),
+		Uint64Root(h.Timestamp),
+		ByteListRoot(h.ExtraData, MaxExtraDataBytes),
+		Uint256Root(h.BaseFeePerGas),
+		h.BlockHash,
+		h.TransactionsRoot,
+		h.WithdrawalsRoot,
+		Uint64Root(h.BlobGasUsed),
+		Uint64Root(h.ExcessBlobGas),
+	), nil
+}
+
+// DecodeExecutionPayloadHeader decodes an SSZ-serialized
+// ExecutionPayloadHeader.
+func DecodeExecutionPayloadHeader(data []byte) (ExecutionPayloadHeader, error) {
+	if len(data) < executionPayloadHeaderFixedSize {
+		return ExecutionPayloadHeader{}, ErrInvalidLength
+	}
+
+	r := reader{data: data}
+	var h ExecutionPayloadHeader
+	r.fixed(h.ParentHash[:])
+	r.fixed(h.FeeRecipient[:])
+	r.fixed(h.StateRoot[:])
+	r.fixed(h.ReceiptsRoot[:])
+	r.fixed(h.LogsBloom[:])
+	r.fixed(h.PrevRandao[:])
+	h.BlockNumber = r.uint64()
+	h.GasLimit = r.uint64()
+	h.GasUsed = r.uint64()
+	h.Timestamp = r.uint64()
+	offset := r.uint32()
+	h.BaseFeePerGas = r.uint256()
+	r.fixed(h.BlockHash[:])
+	r.fixed(h.TransactionsRoot[:])
+	r.fixed(h.WithdrawalsRoot[:])
+	h.BlobGasUsed = r.uint64()
+	h.ExcessBlobGas = r.uint64()
+	if r.err != nil {
+		return ExecutionPayloadHeader{}, r.err
+	}
+
+	// The only variable-size field must start right after the fixed part
+	if offset != executionPayloadHeaderFixedSize {
+		return ExecutionPayloadHeader{}, ErrInvalidOffset
+	}
+	if len(data)-executionPayloadHeaderFixedSize > MaxExtraDataBytes {
+		return ExecutionPayloadHeader{}, ErrListTooLong
+	}
+	h.ExtraData = append([]byte{}, data[executionPayloadHeaderFixedSize:]...)
+	return h, nil
+}
@@ -0,0 +1,177 @@
+// Package ssz provides SimpleSerialize encoding and Merkleization for
+// consensus-layer types.
+//
+// Serialization is little-endian with fixed-size fields inline and
+// variable-size fields referenced by 4-byte offsets. The hash tree root of
+// a value is the SHA-256 Merkle root of its 32-byte chunks, padded with
+// zero chunks to a power of two; lists additionally mix in their length.
+package ssz
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/sha256"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// BytesPerChunk is the size of a Merkleization chunk.
+const BytesPerChunk = 32
+
+// offsetSize is the size of a variable-size field offset.
+const offsetSize = 4
+
+// Errors
+var (
+	ErrInvalidLength = errors.New("ssz: invalid length")
+	ErrInvalidOffset = errors.New("ssz: invalid offset")
+	ErrListTooLong   = errors.New("ssz: list exceeds its limit")
+)
+
+// zeroHashes[i] is the root of a tree of depth i with only zero chunks.
+var zeroHashes = func() [65]hash.Hash {
+	var z [65]hash.Hash
+	for i := 1; i < len(z); i++ {
+		z[i] = sha256.Sum(z[i-1][:], z[i-1][:])
+	}
+	return z
+}()
+
+// Merkleize returns the Merkle root of chunks, padded with zero chunks to
+// the next power of two of limit. A limit of zero, or one smaller than
+// len(chunks), pads to the next power of two of len(chunks).
+func Merkleize(chunks []hash.Hash, limit uint64) hash.Hash {
+	if limit < uint64(len(chunks)) {
+		limit = uint64(len(chunks))
+	}
+	depth := 0
+	for uint64(1)<<depth < limit {
+		depth++
+	}
+	if len(chunks) == 0 {
+		return zeroHashes[depth]
+	}
+
+	layer := append([]hash.Hash{}, chunks...)
+	for d := 0; d < depth; d++ {
+		if len(layer)%2 == 1 {
+			layer = append(layer, zeroHashes[d])
+		}
+		next := make([]hash.Hash, len(layer)/2)
+		for i := range next {
+			next[i] = sha256.Sum(layer[2*i][:], layer[2*i+1][:])
+		}
+		layer = next
+	}
+	return layer[0]
+}
+
+// MixInLength returns sha256(root || uint256(length)), the root of a list
+// of length items whose contents Merkleize to root.
+func MixInLength(root hash.Hash, length uint64) hash.Hash {
+	var l hash.Hash
+	binary.LittleEndian.PutUint64(l[:], length)
+	return sha256.Sum(root[:], l[:])
+}
+
+// Pack splits data into chunks, zero-padding the last one.
+func Pack(data []byte) []hash.Hash {
+	chunks := make([]hash.Hash, (len(data)+BytesPerChunk-1)/BytesPerChunk)
+	for i := range chunks {
+		copy(chunks[i][:], data[i*BytesPerChunk:])
+	}
+	return chunks
+}
+
+// Uint64Root returns the hash tree root of a uint64: its little-endian
+// encoding in a single chunk.
+func Uint64Root(v uint64) hash.Hash {
+	var h hash.Hash
+	binary.LittleEndian.PutUint64(h[:], v)
+	return h
+}
+
+// Uint256Root returns the hash tree root of a uint256: its little-endian
+// encoding in a single chunk.
+func Uint256Root(v u256.U256) hash.Hash {
+	var h hash.Hash
+	for i := range v {
+		h[i] = v[u256.Size-1-i]
+	}
+	return h
+}
+
+// BytesRoot returns the hash tree root of a fixed-size byte vector.
+func BytesRoot(b []byte) hash.Hash {
+	return Merkleize(Pack(b), 0)
+}
+
+// ByteListRoot returns the hash tree root of a byte list with a maximum
+// length of maxLen bytes.
+func ByteListRoot(b []byte, maxLen uint64) hash.Hash {
+	limit := (maxLen + BytesPerChunk - 1) / BytesPerChunk
+	return MixInLength(Merkleize(Pack(b), limit), uint64(len(b)))
+}
+
+// ContainerRoot returns the hash tree root of a container from the roots
+// of its fields, in declaration order.
+func ContainerRoot(fields ...hash.Hash) hash.Hash {
+	return Merkleize(fields, 0)
+}
+
+// reader decodes fixed-size fields in order, keeping the first error.
+type reader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return make([]byte, n)
+	}
+	if len(r.data)-r.pos < n {
+		r.err = ErrInvalidLength
+		return make([]byte, n)
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *reader) fixed(dst []byte) {
+	copy(dst, r.next(len(dst)))
+}
+
+func (r *reader) uint64() uint64 {
+	return binary.LittleEndian.Uint64(r.next(8))
+}
+
+func (r *reader) uint32() uint32 {
+	return binary.LittleEndian.Uint32(r.next(offsetSize))
+}
+
+func (r *reader) uint256() u256.U256 {
+	b := r.next(u256.Size)
+	var v u256.U256
+	for i := range v {
+		v[i] = b[u256.Size-1-i]
+	}
+	return v
+}
+
+func appendUint64(dst []byte, v uint64) []byte {
+	return binary.LittleEndian.AppendUint64(dst, v)
+}
+
+func appendUint32(dst []byte, v uint32) []byte {
+	return binary.LittleEndian.AppendUint32(dst, v)
+}
+
+func appendUint256(dst []byte, v u256.U256) []byte {
+	for i := u256.Size - 1; i >= 0; i-- {
+		dst = append(dst, v[i])
+	}
+	return dst
+}
@@ -0,0 +1,187 @@
+package ssz
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/sha256"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+func fill(b byte) hash.Hash {
+	var h hash.Hash
+	for i := range h {
+		h[i] = b
+	}
+	return h
+}
+
+func TestMerkleize(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []hash.Hash
+		limit  uint64
+		want   string
+	}{
+		{"single chunk", []hash.Hash{fill(0xab)}, 0, "0xabababababababababababababababababababababababababababababababab"},
+		{"empty", nil, 0, "0x0000000000000000000000000000000000000000000000000000000000000000"},
+		{"zero tree depth 3", nil, 8, "0xc78009fdf07fc56a11f122370658a353aaa542ed63e44c4bc15ff4cd105ab33c"},
+		{"padded to limit", []hash.Hash{{}}, 8, "0xc78009fdf07fc56a11f122370658a353aaa542ed63e44c4bc15ff4cd105ab33c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Merkleize(tt.chunks, tt.limit).Hex(); got != tt.want {
+				t.Errorf("Merkleize() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	a, b := fill(1), fill(2)
+	if got, want := Merkleize([]hash.Hash{a, b}, 0), sha256.Sum(a[:], b[:]); got != want {
+		t.Errorf("Merkleize(a, b) = %s, want %s", got.Hex(), want.Hex())
+	}
+}
+
+func TestListRoots(t *testing.T) {
+	// Empty list with a one-chunk limit: sha256(zero chunk || length 0)
+	if got := ByteListRoot(nil, 32).Hex(); got != "0xf5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b" {
+		t.Errorf("ByteListRoot(nil) = %s", got)
+	}
+
+	if got := Uint256Root(u256.FromUint64(0x0102)); got != (hash.Hash{0: 0x02, 1: 0x01}) {
+		t.Errorf("Uint256Root() = %s", got.Hex())
+	}
+	if got := Uint64Root(0x0102); got != (hash.Hash{0: 0x02, 1: 0x01}) {
+		t.Errorf("Uint64Root() = %s", got.Hex())
+	}
+	if chunks := Pack(make([]byte, 33)); len(chunks) != 2 {
+		t.Errorf("Pack(33 bytes) returned %d chunks, want 2", len(chunks))
+	}
+}
+
+func TestBeaconBlockHeader(t *testing.T) {
+	h := BeaconBlockHeader{
+		Slot:          123456,
+		ProposerIndex: 42,
+		ParentRoot:    fill(0x11),
+		StateRoot:     fill(0x22),
+		BodyRoot:      fill(0x33),
+	}
+
+	encoded := h.EncodeSSZ()
+	const want = "40e20100000000002a00000000000000" +
+		"1111111111111111111111111111111111111111111111111111111111111111" +
+		"2222222222222222222222222222222222222222222222222222222222222222" +
+		"3333333333333333333333333333333333333333333333333333333333333333"
+	if got := hex.EncodeToString(encoded); got != want {
+		t.Errorf("EncodeSSZ() = %s, want %s", got, want)
+	}
+
+	const root = "0xaf850446388f8a4f1c4936da05adc49645a4b69261e74fa7eccbab519743f63b"
+	if got := h.HashTreeRoot().Hex(); got != root {
+		t.Errorf("HashTreeRoot() = %s, want %s", got, root)
+	}
+
+	decoded, err := DecodeBeaconBlockHeader(encoded)
+	if err != nil || decoded != h {
+		t.Errorf("DecodeBeaconBlockHeader() = %+v, %v", decoded, err)
+	}
+	if _, err := DecodeBeaconBlockHeader(encoded[1:]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("short input error = %v, want %v", err, ErrInvalidLength)
+	}
+}
+
+func samplePayloadHeader() ExecutionPayloadHeader {
+	h := ExecutionPayloadHeader{
+		ParentHash:       fill(1),
+		FeeRecipient:     address.MustFromHex("0xfefefefefefefefefefefefefefefefefefefefe"),
+		StateRoot:        fill(2),
+		ReceiptsRoot:     fill(3),
+		PrevRandao:       fill(4),
+		BlockNumber:      18_000_000,
+		GasLimit:         30_000_000,
+		GasUsed:          21_000,
+		Timestamp:        1_700_000_000,
+		ExtraData:        []byte("voltaire"),
+		BaseFeePerGas:    u256.FromUint64(7_000_000_000),
+		BlockHash:        fill(5),
+		TransactionsRoot: fill(6),
+		WithdrawalsRoot:  fill(7),
+		BlobGasUsed:      131072,
+	}
+	for i := range h.LogsBloom {
+		h.LogsBloom[i] = byte(i)
+	}
+	return h
+}
+
+func TestExecutionPayloadHeader(t *testing.T) {
+	h := samplePayloadHeader()
+
+	encoded, err := h.EncodeSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(encoded) != executionPayloadHeaderFixedSize+len(h.ExtraData) {
+		t.Errorf("encoded length = %d", len(encoded))
+	}
+	const digest = "0xe4a56d13ca48c29cfa6d4c9e06fd749ed04780fb572970128df454c539f4dc92"
+	if got := sha256.Hash(encoded).Hex(); got != digest {
+		t.Errorf("sha256(EncodeSSZ()) = %s, want %s", got, digest)
+	}
+
+	root, err := h.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := root.Hex(); got != "0x069cb4b2283da34f1c7d511eac0bd96f9629ebd4b36b261b2d2bbda50a28455d" {
+		t.Errorf("HashTreeRoot() = %s", got)
+	}
+
+	decoded, err := DecodeExecutionPayloadHeader(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, h) {
+		t.Errorf("DecodeExecutionPayloadHeader() = %+v, want %+v", decoded, h)
+	}
+}
+
+func TestExecutionPayloadHeaderInvalid(t *testing.T) {
+	h := samplePayloadHeader()
+	h.ExtraData = bytes.Repeat([]byte{1}, MaxExtraDataBytes+1)
+	if _, err := h.EncodeSSZ(); !errors.Is(err, ErrListTooLong) {
+		t.Errorf("EncodeSSZ() error = %v, want %v", err, ErrListTooLong)
+	}
+	if _, err := h.HashTreeRoot(); !errors.Is(err, ErrListTooLong) {
+		t.Errorf("HashTreeRoot() error = %v, want %v", err, ErrListTooLong)
+	}
+
+	encoded, _ := samplePayloadHeader().EncodeSSZ()
+	badOffset := append([]byte{}, encoded...)
+	badOffset[436] = 0
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{"short", encoded[:executionPayloadHeaderFixedSize-1], ErrInvalidLength},
+		{"bad offset", badOffset, ErrInvalidOffset},
+		{"extra data too long", append(encoded, make([]byte, MaxExtraDataBytes)...), ErrListTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeExecutionPayloadHeader(tt.data); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
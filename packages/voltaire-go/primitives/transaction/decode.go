@@ -0,0 +1,330 @@
+package transaction
+
+import (
+	"github.com/voltaire-labs/voltaire-go/primitives/accesslist"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+	"github.com/voltaire-labs/voltaire-go/primitives/signature"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// Number of RLP fields in each signed transaction encoding.
+const (
+	legacyFields     = 9
+	accessListFields = 11
+	dynamicFeeFields = 12
+	blobFields       = 14
+	setCodeFields    = 13
+
+	authorizationFields = 6
+)
+
+// DecodeRLP decodes the network encoding of a transaction. A first byte of
+// 0xc0 or above is a legacy RLP list; otherwise it is the EIP-2718 type.
+//
+// For legacy transactions, ChainID is derived from V: zero for V of 27 or
+// 28, (V - 35) / 2 for EIP-155 signatures.
+func DecodeRLP(data []byte) (Transaction, error) {
+	if len(data) == 0 {
+		return nil, ErrEmpty
+	}
+	if data[0] >= 0xc0 {
+		return decodeLegacy(data)
+	}
+
+	fields, err := decodeFields(data[1:])
+	if err != nil {
+		return nil, err
+	}
+	switch Type(data[0]) {
+	case AccessListType:
+		return decodeAccessListTx(fields)
+	case DynamicFeeType:
+		return decodeDynamicFeeTx(fields)
+	case BlobType:
+		return decodeBlobTx(fields)
+	case SetCodeType:
+		return decodeSetCodeTx(fields)
+	default:
+		return nil, ErrInvalidType
+	}
+}
+
+func decodeFields(data []byte) ([]interface{}, error) {
+	decoded, err := rlp.DecodeBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	fields, ok := decoded.([]interface{})
+	if !ok {
+		return nil, ErrInvalidRLP
+	}
+	return fields, nil
+}
+
+func decodeLegacy(data []byte) (Transaction, error) {
+	fields, err := decodeFields(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) != legacyFields {
+		return nil, ErrInvalidRLP
+	}
+
+	d := decoder{fields: fields}
+	var tx Legacy
+	tx.Nonce = d.uint64()
+	tx.GasPrice = d.u256()
+	tx.Gas = d.uint64()
+	tx.To = d.optionalAddress()
+	tx.Value = d.u256()
+	tx.Data = d.bytes()
+	tx.V = d.uint64()
+	tx.R = d.u256()
+	tx.S = d.u256()
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	if _, chainID, err := signature.ParseV(tx.V); err == nil {
+		tx.ChainID = chainID
+	}
+	return tx, nil
+}
+
+func decodeAccessListTx(fields []interface{}) (Transaction, error) {
+	if len(fields) != accessListFields {
+		return nil, ErrInvalidRLP
+	}
+
+	d := decoder{fields: fields}
+	var tx AccessListTx
+	tx.ChainID = d.uint64()
+	tx.Nonce = d.uint64()
+	tx.GasPrice = d.u256()
+	tx.Gas = d.uint64()
+	tx.To = d.optionalAddress()
+	tx.Value = d.u256()
+	tx.Data = d.bytes()
+	tx.AccessList = d.accessList()
+	tx.YParity, tx.R, tx.S = d.signature()
+	if d.err != nil {
+		return nil, d.err
+	}
+	return tx, nil
+}
+
+func decodeDynamicFeeTx(fields []interface{}) (Transaction, error) {
+	if len(fields) != dynamicFeeFields {
+		return nil, ErrInvalidRLP
+	}
+
+	d := decoder{fields: fields}
+	var tx DynamicFeeTx
+	tx.ChainID = d.uint64()
+	tx.Nonce = d.uint64()
+	tx.MaxPriorityFeePerGas = d.u256()
+	tx.MaxFeePerGas = d.u256()
+	tx.Gas = d.uint64()
+	tx.To = d.optionalAddress()
+	tx.Value = d.u256()
+	tx.Data = d.bytes()
+	tx.AccessList = d.accessList()
+	tx.YParity, tx.R, tx.S = d.signature()
+	if d.err != nil {
+		return nil, d.err
+	}
+	return tx, nil
+}
+
+func decodeBlobTx(fields []interface{}) (Transaction, error) {
+	if len(fields) != blobFields {
+		return nil, ErrInvalidRLP
+	}
+
+	d := decoder{fields: fields}
+	var tx BlobTx
+	tx.ChainID = d.uint64()
+	tx.Nonce = d.uint64()
+	tx.MaxPriorityFeePerGas = d.u256()
+	tx.MaxFeePerGas = d.u256()
+	tx.Gas = d.uint64()
+	d.fixed(tx.To[:])
+	tx.Value = d.u256()
+	tx.Data = d.bytes()
+	tx.AccessList = d.accessList()
+	tx.MaxFeePerBlobGas = d.u256()
+	for _, item := range d.list() {
+		b, ok := item.([]byte)
+		if !ok || len(b) != hash.Size {
+			return nil, ErrInvalidField
+		}
+		tx.BlobVersionedHashes = append(tx.BlobVersionedHashes, hash.Hash(b))
+	}
+	tx.YParity, tx.R, tx.S = d.signature()
+	if d.err != nil {
+		return nil, d.err
+	}
+	return tx, nil
+}
+
+func decodeSetCodeTx(fields []interface{}) (Transaction, error) {
+	if len(fields) != setCodeFields {
+		return nil, ErrInvalidRLP
+	}
+
+	d := decoder{fields: fields}
+	var tx SetCodeTx
+	tx.ChainID = d.uint64()
+	tx.Nonce = d.uint64()
+	tx.MaxPriorityFeePerGas = d.u256()
+	tx.MaxFeePerGas = d.u256()
+	tx.Gas = d.uint64()
+	d.fixed(tx.To[:])
+	tx.Value = d.u256()
+	tx.Data = d.bytes()
+	tx.AccessList = d.accessList()
+	for _, item := range d.list() {
+		fields, ok := item.([]interface{})
+		if !ok || len(fields) != authorizationFields {
+			return nil, ErrInvalidRLP
+		}
+		ad := decoder{fields: fields}
+		var a Authorization
+		a.ChainID = ad.uint64()
+		ad.fixed(a.Address[:])
+		a.Nonce = ad.uint64()
+		a.YParity, a.R, a.S = ad.signature()
+		if ad.err != nil {
+			return nil, ad.err
+		}
+		tx.AuthorizationList = append(tx.AuthorizationList, a)
+	}
+	tx.YParity, tx.R, tx.S = d.signature()
+	if d.err != nil {
+		return nil, d.err
+	}
+	return tx, nil
+}
+
+// decoder reads transaction fields in order, keeping the first error.
+type decoder struct {
+	fields []interface{}
+	pos    int
+	err    error
+}
+
+func (d *decoder) item() interface{} {
+	if d.err != nil {
+		return nil
+	}
+	v := d.fields[d.pos]
+	d.pos++
+	return v
+}
+
+func (d *decoder) bytes() []byte {
+	v := d.item()
+	if d.err != nil {
+		return nil
+	}
+	b, ok := v.([]byte)
+	if !ok {
+		d.err = ErrInvalidField
+	}
+	return b
+}
+
+func (d *decoder) list() []interface{} {
+	v := d.item()
+	if d.err != nil {
+		return nil
+	}
+	l, ok := v.([]interface{})
+	if !ok {
+		d.err = ErrInvalidField
+	}
+	return l
+}
+
+func (d *decoder) fixed(dst []byte) {
+	b := d.bytes()
+	if d.err == nil && len(b) != len(dst) {
+		d.err = ErrInvalidField
+	}
+	copy(dst, b)
+}
+
+// optionalAddress decodes a recipient, returning nil for contract creation.
+func (d *decoder) optionalAddress() *address.Address {
+	b := d.bytes()
+	if d.err != nil || len(b) == 0 {
+		return nil
+	}
+	if len(b) != address.Size {
+		d.err = ErrInvalidField
+		return nil
+	}
+	a := address.Address(b)
+	return &a
+}
+
+func (d *decoder) uint64() uint64 {
+	b := d.bytes()
+	if d.err != nil {
+		return 0
+	}
+	v, err := rlp.Uint64FromBytes(b)
+	switch err {
+	case nil:
+	case rlp.ErrNonCanonical:
+		d.err = ErrNonCanonicalInt
+	default:
+		d.err = ErrInvalidField
+	}
+	return v
+}
+
+func (d *decoder) u256() u256.U256 {
+	b := d.bytes()
+	if d.err != nil {
+		return u256.U256{}
+	}
+	if len(b) > u256.Size {
+		d.err = ErrInvalidField
+		return u256.U256{}
+	}
+	if len(b) > 0 && b[0] == 0 {
+		d.err = ErrNonCanonicalInt
+		return u256.U256{}
+	}
+	v, _ := u256.FromBytes(b)
+	return v
+}
+
+func (d *decoder) accessList() accesslist.AccessList {
+	l := d.list()
+	if d.err != nil {
+		return nil
+	}
+	// Cannot fail: decoded items are supported types
+	encoded, _ := rlp.EncodeList(l)
+	al, err := accesslist.DecodeRLP(encoded)
+	if err != nil {
+		d.err = err
+	}
+	return al
+}
+
+// signature decodes the y-parity, r and s fields of a typed transaction or
+// authorization.
+func (d *decoder) signature() (uint8, u256.U256, u256.U256) {
+	v := d.uint64()
+	if d.err == nil && v > 0xff {
+		d.err = ErrInvalidField
+	}
+	r := d.u256()
+	s := d.u256()
+	return uint8(v), r, s
+}
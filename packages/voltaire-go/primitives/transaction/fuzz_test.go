@@ -0,0 +1,13 @@
+package transaction
+
+import "testing"
+
+func FuzzDecodeRLP(f *testing.F) {
+	f.Add([]byte{0xc0})
+	for _, typ := range []byte{0x01, 0x02, 0x03, 0x04} {
+		f.Add([]byte{typ, 0xc0})
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		DecodeRLP(data)
+	})
+}
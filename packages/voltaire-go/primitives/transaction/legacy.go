@@ -0,0 +1,76 @@
+package transaction
+
+import (
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// Legacy is a pre-EIP-2718 transaction.
+type Legacy struct {
+	Nonce    uint64
+	GasPrice u256.U256
+	Gas      uint64
+	// To is nil for contract creation.
+	To    *address.Address
+	Value u256.U256
+	Data  []byte
+
+	// ChainID selects EIP-155 replay protection when signing. Zero signs
+	// the original six-field payload valid on any chain.
+	ChainID uint64
+
+	// V is 27 + yParity before EIP-155, or chainId*2 + 35 + yParity.
+	V    uint64
+	R, S u256.U256
+}
+
+// Type returns LegacyType.
+func (tx Legacy) Type() Type {
+	return LegacyType
+}
+
+// EncodeRLP returns rlp([nonce, gasPrice, gas, to, value, data, v, r, s]).
+func (tx Legacy) EncodeRLP() []byte {
+	items := append(tx.fields(), tx.V, uintBytes(tx.R), uintBytes(tx.S))
+
+	// Cannot fail: all items are supported types
+	encoded, _ := rlp.EncodeList(items)
+	return encoded
+}
+
+// SigningPayload returns rlp([nonce, gasPrice, gas, to, value, data,
+// chainId, 0, 0]) for EIP-155, or the first six fields when ChainID is
+// zero.
+func (tx Legacy) SigningPayload() []byte {
+	items := tx.fields()
+	if tx.ChainID != 0 {
+		items = append(items, tx.ChainID, uint64(0), uint64(0))
+	}
+
+	// Cannot fail: all items are supported types
+	encoded, _ := rlp.EncodeList(items)
+	return encoded
+}
+
+// SigningHash returns keccak256 of the signing payload.
+func (tx Legacy) SigningHash() hash.Hash {
+	return keccak(tx.SigningPayload())
+}
+
+// Hash returns the transaction hash.
+func (tx Legacy) Hash() hash.Hash {
+	return keccak(tx.EncodeRLP())
+}
+
+func (tx Legacy) fields() []interface{} {
+	return []interface{}{
+		tx.Nonce,
+		uintBytes(tx.GasPrice),
+		tx.Gas,
+		toBytes(tx.To),
+		uintBytes(tx.Value),
+		tx.Data,
+	}
+}
@@ -0,0 +1,71 @@
+package transaction
+
+import (
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/signature"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// Sender decodes a signed transaction in its network encoding and recovers
+// the address that signed it.
+//
+// Signatures with a zero r or s, a high s (EIP-2) or a recovery id other
+// than 0 or 1 are rejected with ErrInvalidSignature. Legacy V values of 27
+// and 28 are accepted as well as EIP-155 values.
+func Sender(raw []byte) (address.Address, error) {
+	tx, err := DecodeRLP(raw)
+	if err != nil {
+		return address.Address{}, err
+	}
+	return RecoverSender(tx)
+}
+
+// RecoverSender recovers the address that signed tx.
+func RecoverSender(tx Transaction) (address.Address, error) {
+	var (
+		yParity uint8
+		r, s    u256.U256
+	)
+	switch tx := tx.(type) {
+	case Legacy:
+		// A bare y-parity of 0 or 1 is not a legacy V
+		var chainID uint64
+		var err error
+		yParity, chainID, err = signature.ParseV(tx.V)
+		if err != nil || tx.V <= 1 || chainID != tx.ChainID {
+			return address.Address{}, ErrInvalidSignature
+		}
+		r, s = tx.R, tx.S
+	case AccessListTx:
+		yParity, r, s = tx.YParity, tx.R, tx.S
+	case DynamicFeeTx:
+		yParity, r, s = tx.YParity, tx.R, tx.S
+	case BlobTx:
+		yParity, r, s = tx.YParity, tx.R, tx.S
+	case SetCodeTx:
+		yParity, r, s = tx.YParity, tx.R, tx.S
+	default:
+		return address.Address{}, ErrInvalidType
+	}
+
+	return recoverAddress(tx.SigningHash(), yParity, r, s)
+}
+
+// RecoverAuthority recovers the account that signed an EIP-7702
+// authorization.
+func (a Authorization) RecoverAuthority() (address.Address, error) {
+	return recoverAddress(a.SigningHash(), a.YParity, a.R, a.S)
+}
+
+func recoverAddress(h [32]byte, yParity uint8, r, s u256.U256) (address.Address, error) {
+	if yParity > 1 || r.IsZero() || s.IsZero() || !signature.FromRSV(r, s, yParity).IsLowS() {
+		return address.Address{}, ErrInvalidSignature
+	}
+
+	addr, err := ffi.Secp256k1RecoverAddress(h, r, s, yParity)
+	if err != nil {
+		return address.Address{}, ErrInvalidSignature
+	}
+	return address.Address(addr), nil
+}
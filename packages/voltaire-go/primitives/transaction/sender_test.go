@@ -0,0 +1,154 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/accesslist"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// keySender is the address of key.
+var keySender = address.MustFromHex("0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F")
+
+func sign(t *testing.T, h hash.Hash) (uint8, u256.U256, u256.U256) {
+	t.Helper()
+	sig, err := key.Sign(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, _ := u256.FromBytes(sig[:32])
+	s, _ := u256.FromBytes(sig[32:64])
+	return sig[64], r, s
+}
+
+func TestSenderEIP155(t *testing.T) {
+	raw, _ := hex.DecodeString("f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025" +
+		"a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276" +
+		"a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+
+	tx, err := DecodeRLP(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, ok := tx.(Legacy)
+	if !ok {
+		t.Fatalf("DecodeRLP() = %T, want Legacy", tx)
+	}
+	if legacy.ChainID != 1 || legacy.Nonce != 9 || *legacy.To != to35 {
+		t.Errorf("decoded %+v", legacy)
+	}
+	if !bytes.Equal(tx.EncodeRLP(), raw) {
+		t.Error("re-encoding does not match input")
+	}
+
+	got, err := Sender(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != keySender {
+		t.Errorf("Sender() = %s, want %s", got.ChecksumHex(), keySender.ChecksumHex())
+	}
+}
+
+func TestSenderPreEIP155(t *testing.T) {
+	tx := eip155Tx()
+	tx.ChainID = 0
+	yParity, r, s := sign(t, tx.SigningHash())
+	tx.V, tx.R, tx.S = 27+uint64(yParity), r, s
+
+	got, err := Sender(tx.EncodeRLP())
+	if err != nil || got != keySender {
+		t.Errorf("Sender() = %s, %v", got.ChecksumHex(), err)
+	}
+}
+
+func TestSenderTyped(t *testing.T) {
+	al := accesslist.AccessList{{Address: to35, StorageKeys: []hash.Hash{{31: 1}}}}
+
+	auth := Authorization{ChainID: 1, Address: to35, Nonce: 7}
+	auth.YParity, auth.R, auth.S = sign(t, auth.SigningHash())
+
+	tests := []Transaction{
+		AccessListTx{ChainID: 1, Nonce: 1, GasPrice: u256.FromUint64(1), Gas: 21000, To: &to35, AccessList: al},
+		DynamicFeeTx{ChainID: 1, Nonce: 2, MaxFeePerGas: u256.FromUint64(2), Gas: 53000, Data: []byte{0x60, 0x00}},
+		BlobTx{ChainID: 1, To: to35, AccessList: al, MaxFeePerBlobGas: u256.FromUint64(3), BlobVersionedHashes: []hash.Hash{{0: 0x01}}},
+		SetCodeTx{ChainID: 1, To: to35, Value: u256.FromUint64(5), AuthorizationList: []Authorization{auth}},
+	}
+
+	for _, tx := range tests {
+		t.Run(reflect.TypeOf(tx).Name(), func(t *testing.T) {
+			yParity, r, s := sign(t, tx.SigningHash())
+			switch v := tx.(type) {
+			case AccessListTx:
+				v.YParity, v.R, v.S = yParity, r, s
+				tx = v
+			case DynamicFeeTx:
+				v.YParity, v.R, v.S = yParity, r, s
+				tx = v
+			case BlobTx:
+				v.YParity, v.R, v.S = yParity, r, s
+				tx = v
+			case SetCodeTx:
+				v.YParity, v.R, v.S = yParity, r, s
+				tx = v
+			}
+			raw := tx.EncodeRLP()
+
+			decoded, err := DecodeRLP(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded.Type() != tx.Type() || !bytes.Equal(decoded.EncodeRLP(), raw) {
+				t.Errorf("round trip = %x, want %x", decoded.EncodeRLP(), raw)
+			}
+
+			got, err := Sender(raw)
+			if err != nil || got != keySender {
+				t.Errorf("Sender() = %s, %v", got.ChecksumHex(), err)
+			}
+		})
+	}
+
+	authority, err := auth.RecoverAuthority()
+	if err != nil || authority != keySender {
+		t.Errorf("RecoverAuthority() = %s, %v", authority.ChecksumHex(), err)
+	}
+}
+
+func TestSenderInvalid(t *testing.T) {
+	tx := DynamicFeeTx{ChainID: 1, Gas: 21000, To: &to35}
+	yParity, r, s := sign(t, tx.SigningHash())
+
+	highS := u256.MustFromHex("0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140")
+
+	tests := []struct {
+		name    string
+		raw     []byte
+		wantErr error
+	}{
+		{"empty", nil, ErrEmpty},
+		{"unknown type", []byte{0x05, 0xc0}, ErrInvalidType},
+		{"field count", []byte{0x02, 0xc0}, ErrInvalidRLP},
+		{"not a list", []byte{0x02, 0x80}, ErrInvalidRLP},
+		{"zero r", DynamicFeeTx{ChainID: 1, YParity: yParity, S: s}.EncodeRLP(), ErrInvalidSignature},
+		{"high s", DynamicFeeTx{ChainID: 1, YParity: yParity, R: r, S: highS}.EncodeRLP(), ErrInvalidSignature},
+		{"bad parity", DynamicFeeTx{ChainID: 1, YParity: 2, R: r, S: s}.EncodeRLP(), ErrInvalidSignature},
+		{"legacy v", Legacy{V: 30, R: r, S: s}.EncodeRLP(), ErrInvalidSignature},
+		{"legacy y-parity as v", Legacy{V: 1, R: r, S: s}.EncodeRLP(), ErrInvalidSignature},
+		{"non-canonical nonce", []byte{0xc9, 0x00, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80}, ErrNonCanonicalInt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Sender(tt.raw); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Sender() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
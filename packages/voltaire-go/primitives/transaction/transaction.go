@@ -0,0 +1,106 @@
+// Package transaction provides Ethereum transactions and their encodings.
+//
+// Every transaction has two distinct encodings:
+//
+//   - The network encoding (EncodeRLP) is what is broadcast, included in
+//     blocks and hashed for the transaction hash. For typed transactions
+//     (EIP-2718) it is the type byte followed by the RLP payload including
+//     the signature; for legacy transactions it is the plain RLP list.
+//   - The signing payload (SigningPayload) is what is hashed and signed.
+//     It omits the signature. Typed transactions keep the type prefix; legacy
+//     transactions either drop the signature (pre-EIP-155) or replace it
+//     with [chainId, 0, 0] (EIP-155).
+//
+// Blob transactions are encoded in their canonical form, without the
+// blobs, commitments and proofs carried by the mempool wrapper.
+package transaction
+
+import (
+	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+	"github.com/voltaire-labs/voltaire-go/primitives/trie"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// Errors
+var (
+	ErrEmpty            = errors.New("transaction: empty input")
+	ErrInvalidType      = errors.New("transaction: unsupported transaction type")
+	ErrInvalidRLP       = errors.New("transaction: invalid RLP structure")
+	ErrInvalidField     = errors.New("transaction: invalid field")
+	ErrNonCanonicalInt  = errors.New("transaction: non-canonical integer")
+	ErrInvalidSignature = errors.New("transaction: invalid signature")
+)
+
+// Type is an EIP-2718 transaction type.
+type Type byte
+
+// Transaction types.
+const (
+	LegacyType     Type = 0x00
+	AccessListType Type = 0x01 // EIP-2930
+	DynamicFeeType Type = 0x02 // EIP-1559
+	BlobType       Type = 0x03 // EIP-4844
+	SetCodeType    Type = 0x04 // EIP-7702
+)
+
+// Transaction is implemented by every transaction type.
+type Transaction interface {
+	// Type returns the EIP-2718 transaction type.
+	Type() Type
+	// EncodeRLP returns the network encoding, including the signature.
+	EncodeRLP() []byte
+	// SigningPayload returns the bytes whose hash is signed.
+	SigningPayload() []byte
+	// SigningHash returns keccak256 of the signing payload.
+	SigningHash() hash.Hash
+	// Hash returns the transaction hash: keccak256 of the network encoding.
+	Hash() hash.Hash
+}
+
+// DeriveRoot computes the transactions root of a block: the root of the
+// trie mapping rlp(index) to each transaction's network encoding.
+func DeriveRoot(txs []Transaction) hash.Hash {
+	values := make([][]byte, len(txs))
+	for i, tx := range txs {
+		values[i] = tx.EncodeRLP()
+	}
+	return trie.OrderedRoot(values)
+}
+
+// encodeTyped returns type || rlp(fields).
+func encodeTyped(t Type, fields []interface{}) []byte {
+	// Cannot fail: all items are supported types
+	encoded, _ := rlp.EncodeList(fields)
+	return append([]byte{byte(t)}, encoded...)
+}
+
+// signatureFields returns the y-parity, r and s fields of a typed
+// transaction.
+func signatureFields(yParity uint8, r, s u256.U256) []interface{} {
+	return []interface{}{uint64(yParity), uintBytes(r), uintBytes(s)}
+}
+
+func keccak(data []byte) hash.Hash {
+	return keccak256.Hash(data)
+}
+
+// toBytes returns the encoding of a recipient: empty for contract creation.
+func toBytes(to *address.Address) []byte {
+	if to == nil {
+		return []byte{}
+	}
+	return to[:]
+}
+
+// uintBytes returns the minimal big-endian encoding of v (empty for zero).
+func uintBytes(v u256.U256) []byte {
+	if v.IsZero() {
+		return []byte{}
+	}
+	return v.TrimmedBytes()
+}
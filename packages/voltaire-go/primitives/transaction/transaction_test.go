@@ -0,0 +1,199 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/accesslist"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/privatekey"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+	"github.com/voltaire-labs/voltaire-go/primitives/trie"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+var (
+	to35 = address.MustFromHex("0x3535353535353535353535353535353535353535")
+	key  = privatekey.MustFromHex("0x4646464646464646464646464646464646464646464646464646464646464646")
+)
+
+// eip155Tx is the example transaction from EIP-155.
+func eip155Tx() Legacy {
+	return Legacy{
+		Nonce:    9,
+		GasPrice: u256.FromUint64(20_000_000_000),
+		Gas:      21000,
+		To:       &to35,
+		Value:    u256.FromUint64(1_000_000_000_000_000_000),
+		ChainID:  1,
+	}
+}
+
+func TestLegacyEIP155(t *testing.T) {
+	tx := eip155Tx()
+
+	const payload = "ec098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a764000080018080"
+	if got := hex.EncodeToString(tx.SigningPayload()); got != payload {
+		t.Errorf("SigningPayload() = %s, want %s", got, payload)
+	}
+	const signingHash = "0xdaf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"
+	if got := tx.SigningHash().Hex(); got != signingHash {
+		t.Errorf("SigningHash() = %s, want %s", got, signingHash)
+	}
+
+	sig, err := key.Sign(tx.SigningHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.R, _ = u256.FromBytes(sig[:32])
+	tx.S, _ = u256.FromBytes(sig[32:64])
+	tx.V = tx.ChainID*2 + 35 + uint64(sig[64])
+
+	const signed = "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025" +
+		"a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276" +
+		"a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+	if got := hex.EncodeToString(tx.EncodeRLP()); got != signed {
+		t.Errorf("EncodeRLP() = %s, want %s", got, signed)
+	}
+	if tx.Type() != LegacyType {
+		t.Errorf("Type() = %d", tx.Type())
+	}
+}
+
+func TestLegacyPreEIP155(t *testing.T) {
+	tx := eip155Tx()
+	tx.ChainID = 0
+
+	fields := decodeList(t, tx.SigningPayload())
+	if len(fields) != 6 {
+		t.Errorf("pre-EIP-155 signing payload has %d fields, want 6", len(fields))
+	}
+	if len(decodeList(t, tx.EncodeRLP())) != 9 {
+		t.Error("network encoding must always carry v, r and s")
+	}
+}
+
+func TestLegacyContractCreation(t *testing.T) {
+	tx := Legacy{Data: []byte{0x60, 0x00}}
+
+	// [0, 0, 0, "", 0, 0x6000, 0, 0, 0]
+	if got := hex.EncodeToString(tx.EncodeRLP()); got != "cb8080808080826000808080" {
+		t.Errorf("EncodeRLP() = %s", got)
+	}
+}
+
+func TestDynamicFeeSigningPayload(t *testing.T) {
+	tx := DynamicFeeTx{
+		ChainID:              1,
+		MaxPriorityFeePerGas: u256.FromUint64(1),
+		MaxFeePerGas:         u256.FromUint64(2),
+		Gas:                  21000,
+		To:                   &to35,
+	}
+
+	// 0x02 || [1, 0, 1, 2, 21000, to, 0, "", []]
+	want := "02df0180010282520894" + strings.Repeat("35", 20) + "8080c0"
+	if got := hex.EncodeToString(tx.SigningPayload()); got != want {
+		t.Errorf("SigningPayload() = %s, want %s", got, want)
+	}
+}
+
+func TestTypedEncodings(t *testing.T) {
+	al := accesslist.AccessList{{Address: to35, StorageKeys: []hash.Hash{{31: 1}}}}
+	r := u256.FromUint64(0xaa)
+	s := u256.FromUint64(0xbb)
+
+	tests := []struct {
+		tx            Transaction
+		signingFields int
+	}{
+		{AccessListTx{ChainID: 1, Nonce: 1, To: &to35, AccessList: al, YParity: 1, R: r, S: s}, 8},
+		{DynamicFeeTx{ChainID: 1, Nonce: 2, AccessList: al, YParity: 1, R: r, S: s}, 9},
+		{BlobTx{ChainID: 1, To: to35, BlobVersionedHashes: []hash.Hash{{0: 0x01}}, YParity: 1, R: r, S: s}, 11},
+		{SetCodeTx{ChainID: 1, To: to35, AuthorizationList: []Authorization{{ChainID: 1, Address: to35, R: r, S: s}}, YParity: 1, R: r, S: s}, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(reflect.TypeOf(tt.tx).Name(), func(t *testing.T) {
+			network := tt.tx.EncodeRLP()
+			payload := tt.tx.SigningPayload()
+
+			if network[0] != byte(tt.tx.Type()) || payload[0] != byte(tt.tx.Type()) {
+				t.Fatalf("type prefix = %#x / %#x, want %#x", network[0], payload[0], tt.tx.Type())
+			}
+
+			signing := decodeList(t, payload[1:])
+			full := decodeList(t, network[1:])
+			if len(signing) != tt.signingFields {
+				t.Errorf("signing payload has %d fields, want %d", len(signing), tt.signingFields)
+			}
+			if len(full) != len(signing)+3 {
+				t.Errorf("network encoding has %d fields, want %d", len(full), len(signing)+3)
+			}
+			if !reflect.DeepEqual(full[:len(signing)], signing) {
+				t.Error("network encoding does not start with the signing fields")
+			}
+
+			sig := full[len(signing):]
+			if !bytes.Equal(sig[0].([]byte), []byte{1}) || !bytes.Equal(sig[1].([]byte), []byte{0xaa}) || !bytes.Equal(sig[2].([]byte), []byte{0xbb}) {
+				t.Errorf("signature fields = %x", sig)
+			}
+
+			if tt.tx.Hash() == tt.tx.SigningHash() {
+				t.Error("Hash() must differ from SigningHash()")
+			}
+		})
+	}
+}
+
+func TestAuthorizationSigningPayload(t *testing.T) {
+	a := Authorization{ChainID: 1, Address: to35}
+
+	// 0x05 || [1, address, 0]
+	want := "05d70194" + strings.Repeat("35", 20) + "80"
+	if got := hex.EncodeToString(a.SigningPayload()); got != want {
+		t.Errorf("SigningPayload() = %s, want %s", got, want)
+	}
+}
+
+func TestDeriveRoot(t *testing.T) {
+	if got := DeriveRoot(nil); got != trie.EmptyRoot {
+		t.Errorf("DeriveRoot(nil) = %s, want empty root", got.Hex())
+	}
+
+	// Mainnet block 46147, the first block with a transaction
+	to := address.MustFromHex("0x5df9b87991262f6ba471f09758cde1c0fc1de734")
+	tx := Legacy{
+		GasPrice: u256.FromUint64(50_000_000_000_000),
+		Gas:      21000,
+		To:       &to,
+		Value:    u256.FromUint64(31337),
+		V:        28,
+		R:        u256.MustFromHex("0x88ff6cf0fefd94db46111149ae4bfc179e9b94721fffd821d38d16464b3f71d0"),
+		S:        u256.MustFromHex("0x45e0aff800961cfce805daef7016b9b675c137a6a41a548f7b60a3484c06a33a"),
+	}
+	if got := tx.Hash().Hex(); got != "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060" {
+		t.Fatalf("Hash() = %s, want block 46147 transaction", got)
+	}
+	const root = "0x4513310fcb9f6f616972a3b948dc5d547f280849a87ebb5af0191f98b87be598"
+	if got := DeriveRoot([]Transaction{tx}); got.Hex() != root {
+		t.Errorf("DeriveRoot(block 46147) = %s, want %s", got.Hex(), root)
+	}
+}
+
+func decodeList(t *testing.T, data []byte) []interface{} {
+	t.Helper()
+	decoded, err := rlp.DecodeBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, ok := decoded.([]interface{})
+	if !ok {
+		t.Fatalf("not a list: %x", data)
+	}
+	return list
+}
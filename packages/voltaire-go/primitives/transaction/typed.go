@@ -0,0 +1,285 @@
+package transaction
+
+import (
+	"github.com/voltaire-labs/voltaire-go/primitives/accesslist"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// AccessListTx is an EIP-2930 transaction.
+type AccessListTx struct {
+	ChainID    uint64
+	Nonce      uint64
+	GasPrice   u256.U256
+	Gas        uint64
+	To         *address.Address
+	Value      u256.U256
+	Data       []byte
+	AccessList accesslist.AccessList
+
+	YParity uint8
+	R, S    u256.U256
+}
+
+// Type returns AccessListType.
+func (tx AccessListTx) Type() Type {
+	return AccessListType
+}
+
+// EncodeRLP returns 0x01 || rlp([chainId, nonce, gasPrice, gas, to, value,
+// data, accessList, yParity, r, s]).
+func (tx AccessListTx) EncodeRLP() []byte {
+	return encodeTyped(AccessListType, append(tx.fields(), signatureFields(tx.YParity, tx.R, tx.S)...))
+}
+
+// SigningPayload returns 0x01 || rlp([chainId, nonce, gasPrice, gas, to,
+// value, data, accessList]).
+func (tx AccessListTx) SigningPayload() []byte {
+	return encodeTyped(AccessListType, tx.fields())
+}
+
+// SigningHash returns keccak256 of the signing payload.
+func (tx AccessListTx) SigningHash() hash.Hash {
+	return keccak(tx.SigningPayload())
+}
+
+// Hash returns the transaction hash.
+func (tx AccessListTx) Hash() hash.Hash {
+	return keccak(tx.EncodeRLP())
+}
+
+func (tx AccessListTx) fields() []interface{} {
+	return []interface{}{
+		tx.ChainID,
+		tx.Nonce,
+		uintBytes(tx.GasPrice),
+		tx.Gas,
+		toBytes(tx.To),
+		uintBytes(tx.Value),
+		tx.Data,
+		rlp.RawValue(tx.AccessList.EncodeRLP()),
+	}
+}
+
+// DynamicFeeTx is an EIP-1559 transaction.
+type DynamicFeeTx struct {
+	ChainID              uint64
+	Nonce                uint64
+	MaxPriorityFeePerGas u256.U256
+	MaxFeePerGas         u256.U256
+	Gas                  uint64
+	To                   *address.Address
+	Value                u256.U256
+	Data                 []byte
+	AccessList           accesslist.AccessList
+
+	YParity uint8
+	R, S    u256.U256
+}
+
+// Type returns DynamicFeeType.
+func (tx DynamicFeeTx) Type() Type {
+	return DynamicFeeType
+}
+
+// EncodeRLP returns 0x02 || rlp([chainId, nonce, maxPriorityFeePerGas,
+// maxFeePerGas, gas, to, value, data, accessList, yParity, r, s]).
+func (tx DynamicFeeTx) EncodeRLP() []byte {
+	return encodeTyped(DynamicFeeType, append(tx.fields(), signatureFields(tx.YParity, tx.R, tx.S)...))
+}
+
+// SigningPayload returns 0x02 || rlp([chainId, nonce,
+// maxPriorityFeePerGas, maxFeePerGas, gas, to, value, data, accessList]).
+func (tx DynamicFeeTx) SigningPayload() []byte {
+	return encodeTyped(DynamicFeeType, tx.fields())
+}
+
+// SigningHash returns keccak256 of the signing payload.
+func (tx DynamicFeeTx) SigningHash() hash.Hash {
+	return keccak(tx.SigningPayload())
+}
+
+// Hash returns the transaction hash.
+func (tx DynamicFeeTx) Hash() hash.Hash {
+	return keccak(tx.EncodeRLP())
+}
+
+func (tx DynamicFeeTx) fields() []interface{} {
+	return []interface{}{
+		tx.ChainID,
+		tx.Nonce,
+		uintBytes(tx.MaxPriorityFeePerGas),
+		uintBytes(tx.MaxFeePerGas),
+		tx.Gas,
+		toBytes(tx.To),
+		uintBytes(tx.Value),
+		tx.Data,
+		rlp.RawValue(tx.AccessList.EncodeRLP()),
+	}
+}
+
+// BlobTx is an EIP-4844 transaction. Blob transactions cannot create
+// contracts, so To is required.
+type BlobTx struct {
+	ChainID              uint64
+	Nonce                uint64
+	MaxPriorityFeePerGas u256.U256
+	MaxFeePerGas         u256.U256
+	Gas                  uint64
+	To                   address.Address
+	Value                u256.U256
+	Data                 []byte
+	AccessList           accesslist.AccessList
+	MaxFeePerBlobGas     u256.U256
+	BlobVersionedHashes  []hash.Hash
+
+	YParity uint8
+	R, S    u256.U256
+}
+
+// Type returns BlobType.
+func (tx BlobTx) Type() Type {
+	return BlobType
+}
+
+// EncodeRLP returns 0x03 || rlp([chainId, nonce, maxPriorityFeePerGas,
+// maxFeePerGas, gas, to, value, data, accessList, maxFeePerBlobGas,
+// blobVersionedHashes, yParity, r, s]).
+func (tx BlobTx) EncodeRLP() []byte {
+	return encodeTyped(BlobType, append(tx.fields(), signatureFields(tx.YParity, tx.R, tx.S)...))
+}
+
+// SigningPayload returns 0x03 || rlp([chainId, nonce,
+// maxPriorityFeePerGas, maxFeePerGas, gas, to, value, data, accessList,
+// maxFeePerBlobGas, blobVersionedHashes]).
+func (tx BlobTx) SigningPayload() []byte {
+	return encodeTyped(BlobType, tx.fields())
+}
+
+// SigningHash returns keccak256 of the signing payload.
+func (tx BlobTx) SigningHash() hash.Hash {
+	return keccak(tx.SigningPayload())
+}
+
+// Hash returns the transaction hash.
+func (tx BlobTx) Hash() hash.Hash {
+	return keccak(tx.EncodeRLP())
+}
+
+func (tx BlobTx) fields() []interface{} {
+	hashes := make([]interface{}, len(tx.BlobVersionedHashes))
+	for i := range tx.BlobVersionedHashes {
+		hashes[i] = tx.BlobVersionedHashes[i][:]
+	}
+	return []interface{}{
+		tx.ChainID,
+		tx.Nonce,
+		uintBytes(tx.MaxPriorityFeePerGas),
+		uintBytes(tx.MaxFeePerGas),
+		tx.Gas,
+		tx.To[:],
+		uintBytes(tx.Value),
+		tx.Data,
+		rlp.RawValue(tx.AccessList.EncodeRLP()),
+		uintBytes(tx.MaxFeePerBlobGas),
+		hashes,
+	}
+}
+
+// SetCodeTx is an EIP-7702 transaction. Like blob transactions, it cannot
+// create contracts.
+type SetCodeTx struct {
+	ChainID              uint64
+	Nonce                uint64
+	MaxPriorityFeePerGas u256.U256
+	MaxFeePerGas         u256.U256
+	Gas                  uint64
+	To                   address.Address
+	Value                u256.U256
+	Data                 []byte
+	AccessList           accesslist.AccessList
+	AuthorizationList    []Authorization
+
+	YParity uint8
+	R, S    u256.U256
+}
+
+// Type returns SetCodeType.
+func (tx SetCodeTx) Type() Type {
+	return SetCodeType
+}
+
+// EncodeRLP returns 0x04 || rlp([chainId, nonce, maxPriorityFeePerGas,
+// maxFeePerGas, gas, to, value, data, accessList, authorizationList,
+// yParity, r, s]).
+func (tx SetCodeTx) EncodeRLP() []byte {
+	return encodeTyped(SetCodeType, append(tx.fields(), signatureFields(tx.YParity, tx.R, tx.S)...))
+}
+
+// SigningPayload returns 0x04 || rlp([chainId, nonce,
+// maxPriorityFeePerGas, maxFeePerGas, gas, to, value, data, accessList,
+// authorizationList]).
+func (tx SetCodeTx) SigningPayload() []byte {
+	return encodeTyped(SetCodeType, tx.fields())
+}
+
+// SigningHash returns keccak256 of the signing payload.
+func (tx SetCodeTx) SigningHash() hash.Hash {
+	return keccak(tx.SigningPayload())
+}
+
+// Hash returns the transaction hash.
+func (tx SetCodeTx) Hash() hash.Hash {
+	return keccak(tx.EncodeRLP())
+}
+
+func (tx SetCodeTx) fields() []interface{} {
+	auths := make([]interface{}, len(tx.AuthorizationList))
+	for i, a := range tx.AuthorizationList {
+		auths[i] = append(a.fields(), signatureFields(a.YParity, a.R, a.S)...)
+	}
+	return []interface{}{
+		tx.ChainID,
+		tx.Nonce,
+		uintBytes(tx.MaxPriorityFeePerGas),
+		uintBytes(tx.MaxFeePerGas),
+		tx.Gas,
+		tx.To[:],
+		uintBytes(tx.Value),
+		tx.Data,
+		rlp.RawValue(tx.AccessList.EncodeRLP()),
+		auths,
+	}
+}
+
+// authorizationMagic prefixes the EIP-7702 authorization signing payload.
+const authorizationMagic = 0x05
+
+// Authorization is a signed EIP-7702 delegation of an account's code to
+// Address. A ChainID of zero is valid on any chain.
+type Authorization struct {
+	ChainID uint64
+	Address address.Address
+	Nonce   uint64
+
+	YParity uint8
+	R, S    u256.U256
+}
+
+// SigningPayload returns 0x05 || rlp([chainId, address, nonce]).
+func (a Authorization) SigningPayload() []byte {
+	// Cannot fail: all items are supported types
+	encoded, _ := rlp.EncodeList(a.fields())
+	return append([]byte{authorizationMagic}, encoded...)
+}
+
+// SigningHash returns keccak256 of the signing payload.
+func (a Authorization) SigningHash() hash.Hash {
+	return keccak(a.SigningPayload())
+}
+
+func (a Authorization) fields() []interface{} {
+	return []interface{}{a.ChainID, a.Address[:], a.Nonce}
+}
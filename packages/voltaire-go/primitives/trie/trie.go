@@ -0,0 +1,144 @@
+// Package trie computes Merkle Patricia Trie roots.
+//
+// Only root computation is provided: the trie is built in memory from a
+// complete set of key/value pairs and hashed, without storing nodes. This
+// covers the transactions, receipts and withdrawals roots in block headers.
+package trie
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+)
+
+// EmptyRoot is the root of an empty trie: keccak256(rlp("")).
+var EmptyRoot = hash.Hash{
+	0x56, 0xe8, 0x1f, 0x17, 0x1b, 0xcc, 0x55, 0xa6, 0xff, 0x83, 0x45, 0xe6, 0x92, 0xc0, 0xf8, 0x6e,
+	0x5b, 0x48, 0xe0, 0x1b, 0x99, 0x6c, 0xad, 0xc0, 0x01, 0x62, 0x2f, 0xb5, 0xe3, 0x63, 0xb4, 0x21,
+}
+
+// OrderedRoot computes the root of a trie mapping rlp(i) to values[i],
+// the layout used for the transactions, receipts and withdrawals roots.
+func OrderedRoot(values [][]byte) hash.Hash {
+	keys := make([][]byte, len(values))
+	for i := range values {
+		// Cannot fail for uint64
+		keys[i], _ = rlp.EncodeUint64(uint64(i))
+	}
+	return root(keys, values)
+}
+
+// root computes the trie root of the given pairs. Keys must be unique and
+// values non-empty.
+func root(keys, values [][]byte) hash.Hash {
+	if len(keys) == 0 {
+		return EmptyRoot
+	}
+
+	pairs := make([]pair, len(keys))
+	for i := range keys {
+		pairs[i] = pair{key: toNibbles(keys[i]), value: values[i]}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return bytes.Compare(pairs[i].key, pairs[j].key) < 0
+	})
+
+	return keccak256.Hash(encodeNode(pairs, 0))
+}
+
+type pair struct {
+	key   []byte // nibbles
+	value []byte
+}
+
+// encodeNode returns the RLP encoding of the node holding pairs, all of
+// which share the first depth nibbles. pairs must be sorted by key.
+func encodeNode(pairs []pair, depth int) []byte {
+	if len(pairs) == 1 {
+		p := pairs[0]
+		return encodeList(compactKey(p.key[depth:], true), p.value)
+	}
+
+	// Keys are sorted, so the first and last bound the common prefix
+	first, last := pairs[0].key, pairs[len(pairs)-1].key
+	prefix := 0
+	for depth+prefix < len(first) && depth+prefix < len(last) && first[depth+prefix] == last[depth+prefix] {
+		prefix++
+	}
+	if prefix > 0 {
+		child := encodeNode(pairs, depth+prefix)
+		return encodeList(compactKey(first[depth:depth+prefix], false), rlp.RawValue(reference(child)))
+	}
+
+	// Branch: one child per nibble, plus the value of a key ending here
+	items := make([]interface{}, 17)
+	for i := range items {
+		items[i] = []byte{}
+	}
+	for len(pairs) > 0 {
+		if len(pairs[0].key) == depth {
+			items[16] = pairs[0].value
+			pairs = pairs[1:]
+			continue
+		}
+		nibble := pairs[0].key[depth]
+		n := 1
+		for n < len(pairs) && pairs[n].key[depth] == nibble {
+			n++
+		}
+		items[nibble] = rlp.RawValue(reference(encodeNode(pairs[:n], depth+1)))
+		pairs = pairs[n:]
+	}
+
+	encoded, _ := rlp.EncodeList(items)
+	return encoded
+}
+
+// reference returns how a parent refers to a child node: nodes shorter
+// than 32 bytes are embedded, longer ones are replaced by their hash.
+func reference(node []byte) []byte {
+	if len(node) < 32 {
+		return node
+	}
+	h := keccak256.Hash(node)
+	encoded, _ := rlp.Encode(h[:])
+	return encoded
+}
+
+func encodeList(key []byte, value interface{}) []byte {
+	// Cannot fail: all items are supported types
+	encoded, _ := rlp.EncodeList([]interface{}{key, value})
+	return encoded
+}
+
+// compactKey applies hex-prefix encoding to a nibble path.
+func compactKey(nibbles []byte, leaf bool) []byte {
+	var flag byte
+	if leaf {
+		flag = 2
+	}
+
+	out := make([]byte, len(nibbles)/2+1)
+	if len(nibbles)%2 == 1 {
+		out[0] = (flag+1)<<4 | nibbles[0]
+		nibbles = nibbles[1:]
+	} else {
+		out[0] = flag << 4
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		out[1+i/2] = nibbles[i]<<4 | nibbles[i+1]
+	}
+	return out
+}
+
+func toNibbles(key []byte) []byte {
+	out := make([]byte, 2*len(key))
+	for i, b := range key {
+		out[2*i] = b >> 4
+		out[2*i+1] = b & 0x0f
+	}
+	return out
+}
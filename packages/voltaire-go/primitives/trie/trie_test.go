@@ -0,0 +1,70 @@
+package trie
+
+import (
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+)
+
+func TestRoot(t *testing.T) {
+	// Vectors from the ethereum/tests trieanyorder suite
+	tests := []struct {
+		name  string
+		pairs [][2]string
+		want  string
+	}{
+		{
+			name:  "dogs",
+			pairs: [][2]string{{"doe", "reindeer"}, {"dog", "puppy"}, {"dogglesworth", "cat"}},
+			want:  "0x8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3",
+		},
+		{
+			name:  "puppy",
+			pairs: [][2]string{{"do", "verb"}, {"horse", "stallion"}, {"doge", "coin"}, {"dog", "puppy"}},
+			want:  "0x5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys, values [][]byte
+			for _, p := range tt.pairs {
+				keys = append(keys, []byte(p[0]))
+				values = append(values, []byte(p[1]))
+			}
+			if got := root(keys, values); got != hash.MustFromHex(tt.want) {
+				t.Errorf("root = %s, want %s", got.Hex(), tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderedRootEmpty(t *testing.T) {
+	if got := OrderedRoot(nil); got != EmptyRoot {
+		t.Errorf("OrderedRoot(nil) = %s, want %s", got.Hex(), EmptyRoot.Hex())
+	}
+}
+
+func TestOrderedRootKeys(t *testing.T) {
+	// More than 128 items exercises both one- and two-byte rlp(i) keys
+	values := make([][]byte, 200)
+	for i := range values {
+		values[i] = []byte{byte(i), 0xff}
+	}
+
+	keys := make([][]byte, len(values))
+	for i := range keys {
+		if i < 128 {
+			keys[i] = []byte{byte(i)}
+			if i == 0 {
+				keys[i] = []byte{0x80}
+			}
+		} else {
+			keys[i] = []byte{0x81, byte(i)}
+		}
+	}
+
+	if OrderedRoot(values) != root(keys, values) {
+		t.Error("OrderedRoot does not key values by rlp(index)")
+	}
+}
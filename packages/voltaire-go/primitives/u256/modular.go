@@ -0,0 +1,66 @@
+package u256
+
+import (
+	"math/big"
+	"sync"
+)
+
+// Modular arithmetic with EVM semantics: a zero modulus yields zero
+// instead of an error, and intermediate results are not truncated to 256
+// bits (as in ADDMOD and MULMOD).
+
+// scratch holds the big.Int operands of one modular operation. Pooling
+// them keeps repeated calls from allocating fresh big.Ints each time.
+type scratch struct {
+	x, y, m big.Int
+}
+
+var scratchPool = sync.Pool{New: func() interface{} { return new(scratch) }}
+
+func getScratch(x, y, m U256) *scratch {
+	s := scratchPool.Get().(*scratch)
+	x.ToBigInt(&s.x)
+	y.ToBigInt(&s.y)
+	m.ToBigInt(&s.m)
+	return s
+}
+
+// AddMod returns (u + v) mod m, or zero if m is zero.
+func (u U256) AddMod(v, m U256) U256 {
+	if m.IsZero() {
+		return Zero
+	}
+	s := getScratch(u, v, m)
+	defer scratchPool.Put(s)
+	s.x.Add(&s.x, &s.y)
+	return fromReduced(s.x.Mod(&s.x, &s.m))
+}
+
+// MulMod returns (u * v) mod m, or zero if m is zero.
+func (u U256) MulMod(v, m U256) U256 {
+	if m.IsZero() {
+		return Zero
+	}
+	s := getScratch(u, v, m)
+	defer scratchPool.Put(s)
+	s.x.Mul(&s.x, &s.y)
+	return fromReduced(s.x.Mod(&s.x, &s.m))
+}
+
+// ExpMod returns u^e mod m, or zero if m is zero. This matches the MODEXP
+// precompile (EIP-198) for 256-bit operands, including 0^0 = 1 mod m.
+func (u U256) ExpMod(e, m U256) U256 {
+	if m.IsZero() {
+		return Zero
+	}
+	s := getScratch(u, e, m)
+	defer scratchPool.Put(s)
+	return fromReduced(s.x.Exp(&s.x, &s.y, &s.m))
+}
+
+// fromReduced converts a value already reduced below a 256-bit modulus.
+func fromReduced(i *big.Int) U256 {
+	var u U256
+	i.FillBytes(u[:])
+	return u
+}
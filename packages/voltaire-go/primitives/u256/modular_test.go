@@ -0,0 +1,78 @@
+package u256
+
+import "testing"
+
+var maxU256 = MustFromHex("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+func TestAddMod(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, m U256
+		want    U256
+	}{
+		{"simple", FromUint64(10), FromUint64(10), FromUint64(8), FromUint64(4)},
+		{"zero modulus", FromUint64(1), FromUint64(2), Zero, Zero},
+		// Sum exceeds 256 bits: (2^256 - 1) * 2 mod (2^256 - 1) = 0
+		{"no wraparound", maxU256, maxU256, maxU256, Zero},
+		{"overflow carry", maxU256, FromUint64(2), FromUint64(2), FromUint64(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.AddMod(tt.b, tt.m); got != tt.want {
+				t.Errorf("AddMod() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMulMod(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, m U256
+		want    U256
+	}{
+		{"simple", FromUint64(10), FromUint64(10), FromUint64(8), FromUint64(4)},
+		{"zero modulus", FromUint64(3), FromUint64(4), Zero, Zero},
+		// (2^256 - 1)^2 mod 12 = 9, computed without truncating the product
+		{"wide product", maxU256, maxU256, FromUint64(12), FromUint64(9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.MulMod(tt.b, tt.m); got != tt.want {
+				t.Errorf("MulMod() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpMod(t *testing.T) {
+	tests := []struct {
+		name       string
+		base, e, m U256
+		want       U256
+	}{
+		{"simple", FromUint64(3), FromUint64(5), FromUint64(7), FromUint64(5)},
+		{"zero modulus", FromUint64(3), FromUint64(5), Zero, Zero},
+		{"modulus one", FromUint64(3), FromUint64(5), One, Zero},
+		{"zero to zero", Zero, Zero, FromUint64(5), One},
+		{"zero exponent", FromUint64(7), Zero, FromUint64(5), One},
+		// Fermat: 3^(p-1) mod p = 1 for the secp256k1 field prime
+		{
+			"fermat",
+			FromUint64(3),
+			MustFromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2e"),
+			MustFromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+			One,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.base.ExpMod(tt.e, tt.m); got != tt.want {
+				t.Errorf("ExpMod() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,10 +3,12 @@ package u256
 
 import (
 	"bytes"
+	"database/sql/driver"
 	"fmt"
 	"math/big"
 
 	"github.com/voltaire-labs/voltaire-go/internal/ffi"
+	"github.com/voltaire-labs/voltaire-go/primitives/hex"
 )
 
 // Size is the size of a U256 in bytes.
@@ -34,6 +36,17 @@ func FromHex(s string) (U256, error) {
 	return U256(arr), nil
 }
 
+// FromQuantity parses a JSON-RPC quantity: "0x" followed by the shortest
+// hex representation of the value, so "0x0" for zero. Leading zero digits
+// are rejected, as by hex.DecodeBigQuantity.
+func FromQuantity(s string) (U256, error) {
+	v, err := hex.DecodeBigQuantity(s)
+	if err != nil {
+		return U256{}, err
+	}
+	return FromBigInt(v)
+}
+
 // FromBytes creates a U256 from a byte slice.
 func FromBytes(b []byte) (U256, error) {
 	if len(b) > Size {
@@ -56,8 +69,9 @@ func FromBigInt(i *big.Int) (U256, error) {
 	if i.BitLen() > 256 {
 		return U256{}, ffi.ErrInvalidLength
 	}
-	b := i.Bytes()
-	return FromBytes(b)
+	var u U256
+	i.FillBytes(u[:])
+	return u, nil
 }
 
 // FromUint64 creates a U256 from a uint64.
@@ -83,6 +97,14 @@ func (u U256) Hex() string {
 	return ffi.U256ToHex(u)
 }
 
+// QuantityHex returns the JSON-RPC quantity encoding: 0x-prefixed hex
+// without leading zeros, and "0x0" for zero.
+func (u U256) QuantityHex() string {
+	// A U256 is never negative, so encoding cannot fail
+	s, _ := hex.EncodeBigQuantity(u.BigInt())
+	return s
+}
+
 // Bytes returns the U256 as a byte slice (32 bytes).
 func (u U256) Bytes() []byte {
 	return u[:]
@@ -134,13 +156,16 @@ func (u U256) String() string {
 }
 
 // MarshalText implements encoding.TextMarshaler.
+// The value is encoded as a JSON-RPC quantity, as by MarshalJSON, so map
+// keys and struct fields share one encoding.
 func (u U256) MarshalText() ([]byte, error) {
-	return []byte(u.Hex()), nil
+	return []byte(u.QuantityHex()), nil
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
+// Accepts a JSON-RPC quantity (see FromQuantity).
 func (u *U256) UnmarshalText(text []byte) error {
-	v, err := FromHex(string(text))
+	v, err := FromQuantity(string(text))
 	if err != nil {
 		return err
 	}
@@ -149,14 +174,73 @@ func (u *U256) UnmarshalText(text []byte) error {
 }
 
 // MarshalJSON implements json.Marshaler.
+// The value is encoded as a JSON-RPC quantity (see QuantityHex).
 func (u U256) MarshalJSON() ([]byte, error) {
-	return []byte(`"` + u.Hex() + `"`), nil
+	return []byte(`"` + u.QuantityHex() + `"`), nil
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
+// Accepts a JSON-RPC quantity string; null leaves the value unchanged.
 func (u *U256) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
 	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
 		return ffi.ErrInvalidInput
 	}
-	return u.UnmarshalText(data[1 : len(data)-1])
+	v, err := FromQuantity(string(data[1 : len(data)-1]))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// Value implements driver.Valuer, storing the decimal string so the value
+// fits a NUMERIC(78) column without loss.
+func (u U256) Value() (driver.Value, error) {
+	return u.BigInt().String(), nil
+}
+
+// Scan implements sql.Scanner.
+// Accepts decimal or 0x-prefixed hex text (as string or []byte) and
+// non-negative int64 values. NULL is an error rather than zero; scan
+// nullable columns into sql.Null[U256].
+func (u *U256) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		return fmt.Errorf("%w: NULL, use sql.Null[U256] for nullable columns", ffi.ErrInvalidInput)
+	case int64:
+		if v < 0 {
+			return ffi.ErrInvalidInput
+		}
+		*u = FromUint64(uint64(v))
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return ffi.ErrUnsupportedType
+	}
+
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		v, err := FromHex(s)
+		if err != nil {
+			return err
+		}
+		*u = v
+		return nil
+	}
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return ffi.ErrInvalidInput
+	}
+	v, err := FromBigInt(i)
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
 }
@@ -1,9 +1,14 @@
 package u256
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"math/big"
+	"strings"
 	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/internal/ffi"
 )
 
 func TestFromHex(t *testing.T) {
@@ -131,3 +136,190 @@ func TestJSONRoundtrip(t *testing.T) {
 		t.Error("roundtrip failed")
 	}
 }
+
+func TestQuantityHex(t *testing.T) {
+	tests := []struct {
+		name string
+		in   U256
+		want string
+	}{
+		{"zero", Zero, "0x0"},
+		{"one", One, "0x1"},
+		{"no leading zero nibble", FromUint64(0x0400), "0x400"},
+		{"uint64 max", FromUint64(1<<64 - 1), "0xffffffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.in.QuantityHex(); got != tt.want {
+				t.Errorf("QuantityHex() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromQuantity(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    U256
+		wantErr bool
+	}{
+		{name: "zero", input: "0x0", want: Zero},
+		{name: "odd digits", input: "0x400", want: FromUint64(0x400)},
+		{name: "uppercase prefix", input: "0X1", want: One},
+		{name: "max u256", input: "0x" + strings.Repeat("f", 64), want: MustFromHex("0x" + strings.Repeat("f", 64))},
+		{name: "missing prefix", input: "400", wantErr: true},
+		{name: "empty digits", input: "0x", wantErr: true},
+		{name: "too long", input: "0x1" + strings.Repeat("0", 64), wantErr: true},
+		{name: "invalid hex", input: "0xgg", wantErr: true},
+		{name: "leading zero", input: "0x0001", wantErr: true},
+		{name: "zero with leading zero", input: "0x00", wantErr: true},
+		{name: "fixed width", input: "0x" + strings.Repeat("0", 63) + "1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromQuantity(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FromQuantity(%s) = %s, want %s", tt.input, got.QuantityHex(), tt.want.QuantityHex())
+			}
+		})
+	}
+}
+
+func TestJSONQuantity(t *testing.T) {
+	type result struct {
+		Balance U256  `json:"balance"`
+		Nonce   *U256 `json:"nonce"`
+	}
+
+	data, err := json.Marshal(result{Balance: FromUint64(1000)})
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if want := `{"balance":"0x3e8","nonce":null}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+
+	var decoded result
+	if err := json.Unmarshal([]byte(`{"balance":"0x3e8","nonce":null}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if decoded.Balance != FromUint64(1000) || decoded.Nonce != nil {
+		t.Errorf("unexpected decode: %+v", decoded)
+	}
+
+	var u U256
+	for _, bad := range []string{`"3e8"`, `"0x"`, `"0x03e8"`, `1000`} {
+		if err := json.Unmarshal([]byte(bad), &u); err == nil {
+			t.Errorf("Unmarshal(%s) expected error", bad)
+		}
+	}
+}
+
+func TestJSONMapKeys(t *testing.T) {
+	balances := map[U256]U256{
+		Zero:             One,
+		FromUint64(1000): FromUint64(0x400),
+	}
+
+	data, err := json.Marshal(balances)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if want := `{"0x0":"0x1","0x3e8":"0x400"}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+
+	var decoded map[U256]U256
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if len(decoded) != len(balances) {
+		t.Fatalf("decoded %d entries, want %d", len(decoded), len(balances))
+	}
+	for k, v := range balances {
+		if decoded[k] != v {
+			t.Errorf("decoded[%s] = %s, want %s", k.QuantityHex(), decoded[k].QuantityHex(), v.QuantityHex())
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"0x01":"0x1"}`), &decoded); err == nil {
+		t.Error("Unmarshal(leading zero key) expected error")
+	}
+}
+
+func TestSQLValueScan(t *testing.T) {
+	u := FromUint64(1000)
+
+	v, err := u.Value()
+	if err != nil {
+		t.Fatalf("Value error: %v", err)
+	}
+	if v != "1000" {
+		t.Errorf("Value() = %v, want \"1000\"", v)
+	}
+
+	max := "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "decimal string", src: "1000", want: "1000"},
+		{name: "decimal bytes", src: []byte("1000"), want: "1000"},
+		{name: "hex string", src: "0x3e8", want: "1000"},
+		{name: "int64", src: int64(1000), want: "1000"},
+		{name: "max u256", src: max, want: max},
+		{name: "overflow", src: max + "0", wantErr: true},
+		{name: "negative int64", src: int64(-1), wantErr: true},
+		{name: "negative decimal", src: "-1", wantErr: true},
+		{name: "garbage", src: "abc", wantErr: true},
+		{name: "null", src: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got U256
+			err := got.Scan(tt.src)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan error: %v", err)
+			}
+			if got.BigInt().String() != tt.want {
+				t.Errorf("got %s, want %s", got.BigInt(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLNull(t *testing.T) {
+	var u U256
+	if err := u.Scan(nil); !errors.Is(err, ffi.ErrInvalidInput) {
+		t.Errorf("Scan(nil) error = %v, want %v", err, ffi.ErrInvalidInput)
+	}
+
+	var n sql.Null[U256]
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Null.Scan(nil) = %+v, %v, want invalid", n, err)
+	}
+	if err := n.Scan("1000"); err != nil || !n.Valid || n.V != FromUint64(1000) {
+		t.Errorf("Null.Scan(1000) = %+v, %v", n, err)
+	}
+}
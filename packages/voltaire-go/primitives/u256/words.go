@@ -0,0 +1,39 @@
+package u256
+
+import (
+	"encoding/binary"
+	"math/big"
+)
+
+// Words returns u as four 64-bit limbs, least significant first. This is
+// the memory layout of github.com/holiman/uint256.Int, so
+// uint256.Int(u.Words()) converts without allocating. The package does
+// not import uint256; Words and FromWords are the whole interop surface.
+func (u U256) Words() [4]uint64 {
+	return [4]uint64{
+		binary.BigEndian.Uint64(u[24:32]),
+		binary.BigEndian.Uint64(u[16:24]),
+		binary.BigEndian.Uint64(u[8:16]),
+		binary.BigEndian.Uint64(u[0:8]),
+	}
+}
+
+// FromWords creates a U256 from four 64-bit limbs, least significant
+// first. A *uint256.Int x converts with FromWords(*x).
+func FromWords(w [4]uint64) U256 {
+	var u U256
+	binary.BigEndian.PutUint64(u[24:32], w[0])
+	binary.BigEndian.PutUint64(u[16:24], w[1])
+	binary.BigEndian.PutUint64(u[8:16], w[2])
+	binary.BigEndian.PutUint64(u[0:8], w[3])
+	return u
+}
+
+// ToBigInt sets z to u and returns z, reusing z's storage. If z is nil a
+// new big.Int is allocated, as with BigInt.
+func (u U256) ToBigInt(z *big.Int) *big.Int {
+	if z == nil {
+		z = new(big.Int)
+	}
+	return z.SetBytes(u[:])
+}
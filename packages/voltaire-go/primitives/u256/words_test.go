@@ -0,0 +1,58 @@
+package u256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	u := MustFromHex("0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	want := [4]uint64{
+		0x18191a1b1c1d1e1f,
+		0x1011121314151617,
+		0x08090a0b0c0d0e0f,
+		0x0001020304050607,
+	}
+	if got := u.Words(); got != want {
+		t.Errorf("Words() = %x, want %x", got, want)
+	}
+	if got := FromWords(want); got != u {
+		t.Errorf("FromWords() = %s, want %s", got, u)
+	}
+	if got := FromUint64(42).Words(); got != [4]uint64{42} {
+		t.Errorf("FromUint64(42).Words() = %v", got)
+	}
+}
+
+func TestToBigInt(t *testing.T) {
+	z := new(big.Int).Lsh(big.NewInt(1), 300)
+	if got := maxU256.ToBigInt(z); got != z || got.Cmp(maxU256.BigInt()) != 0 {
+		t.Errorf("ToBigInt() = %s", got)
+	}
+	if got := FromUint64(7).ToBigInt(nil); got.Int64() != 7 {
+		t.Errorf("ToBigInt(nil) = %s", got)
+	}
+}
+
+func TestZeroAllocConversions(t *testing.T) {
+	u := maxU256
+	z := new(big.Int)
+	u.ToBigInt(z)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Words", func() { u = FromWords(u.Words()) }},
+		{"ToBigInt", func() { u.ToBigInt(z) }},
+		{"FromBigInt", func() { u, _ = FromBigInt(z) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if allocs := testing.AllocsPerRun(100, tt.fn); allocs != 0 {
+				t.Errorf("%v allocations per run, want 0", allocs)
+			}
+		})
+	}
+}
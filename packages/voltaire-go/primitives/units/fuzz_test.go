@@ -0,0 +1,12 @@
+package units
+
+import "testing"
+
+func FuzzParseUnits(f *testing.F) {
+	f.Add("1.5", 18)
+	f.Add("0.000000001", 9)
+	f.Add("-1", 0)
+	f.Fuzz(func(t *testing.T, s string, decimals int) {
+		ParseUnits(s, decimals%100)
+	})
+}
@@ -0,0 +1,161 @@
+// Package units converts between wei and decimal denominations.
+//
+// Amounts are parsed from and formatted to exact decimal strings; no
+// floating point is involved, so "0.1" ether is exactly 10^17 wei.
+package units
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// Decimal places of the common denominations.
+const (
+	Wei   = 0
+	Gwei  = 9
+	Ether = 18
+)
+
+// MaxDecimals is the largest supported number of decimal places.
+// 10^78 exceeds 2^256, so no larger denomination can hold one unit.
+const MaxDecimals = 77
+
+// Errors
+var (
+	ErrInvalidAmount    = errors.New("units: invalid decimal amount")
+	ErrTooManyDecimals  = errors.New("units: too many decimal places")
+	ErrOverflow         = errors.New("units: amount exceeds 256 bits")
+	ErrInvalidDecimals  = errors.New("units: decimals out of range")
+	ErrInvalidPrecision = errors.New("units: negative precision")
+)
+
+// ParseUnits parses a decimal string such as "1.5" into base units with
+// the given number of decimal places. Fractions finer than one base unit
+// are rejected rather than rounded.
+func ParseUnits(s string, decimals int) (u256.U256, error) {
+	if decimals < 0 || decimals > MaxDecimals {
+		return u256.U256{}, ErrInvalidDecimals
+	}
+
+	// "1.", ".5" and "1.5" are all accepted; "." and "" are not
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return u256.U256{}, ErrInvalidAmount
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return u256.U256{}, ErrInvalidAmount
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > decimals {
+		return u256.U256{}, ErrTooManyDecimals
+	}
+
+	digits := whole + frac + strings.Repeat("0", decimals-len(frac))
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return u256.U256{}, ErrInvalidAmount
+	}
+	v, err := u256.FromBigInt(n)
+	if err != nil {
+		return u256.U256{}, ErrOverflow
+	}
+	return v, nil
+}
+
+// ParseEther parses an ether amount such as "1.5" into wei.
+func ParseEther(s string) (u256.U256, error) {
+	return ParseUnits(s, Ether)
+}
+
+// ParseGwei parses a gwei amount such as "30.5" into wei.
+func ParseGwei(s string) (u256.U256, error) {
+	return ParseUnits(s, Gwei)
+}
+
+// FormatUnits formats base units as a decimal string with the given
+// number of decimal places, without trailing zeros ("1.5", "2", "0").
+func FormatUnits(v u256.U256, decimals int) string {
+	if decimals <= 0 {
+		return v.BigInt().String()
+	}
+
+	digits := v.BigInt().String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	point := len(digits) - decimals
+	whole, frac := digits[:point], strings.TrimRight(digits[point:], "0")
+	if frac == "" {
+		return whole
+	}
+	return whole + "." + frac
+}
+
+// FormatEther formats wei as an ether amount.
+func FormatEther(wei u256.U256) string {
+	return FormatUnits(wei, Ether)
+}
+
+// FormatGwei formats wei as a gwei amount.
+func FormatGwei(wei u256.U256) string {
+	return FormatUnits(wei, Gwei)
+}
+
+// FormatWei formats wei in a denomination with the given number of
+// decimal places, rounded half-up to at most precision fractional digits.
+// Trailing zeros are trimmed. For example, 1234567890000000000 wei with
+// decimals=18 and precision=4 formats as "1.2346".
+func FormatWei(wei u256.U256, decimals, precision int) (string, error) {
+	if decimals < 0 || decimals > MaxDecimals {
+		return "", ErrInvalidDecimals
+	}
+	if precision < 0 {
+		return "", ErrInvalidPrecision
+	}
+	if precision >= decimals {
+		return FormatUnits(wei, decimals), nil
+	}
+
+	// Round to a multiple of 10^(decimals-precision) in big.Int so that a
+	// carry at the top of the range cannot wrap around.
+	step := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-precision)), nil)
+	half := new(big.Int).Rsh(step, 1)
+
+	n := wei.BigInt()
+	n.Add(n, half)
+	n.Quo(n, step)
+
+	// n is now in units of 10^-precision
+	digits := n.String()
+	if precision == 0 {
+		return digits, nil
+	}
+	if len(digits) <= precision {
+		digits = strings.Repeat("0", precision-len(digits)+1) + digits
+	}
+	point := len(digits) - precision
+	whole, frac := digits[:point], strings.TrimRight(digits[point:], "0")
+	if frac == "" {
+		return whole, nil
+	}
+	return whole + "." + frac, nil
+}
+
+// isDigits returns true if s contains only ASCII digits.
+// The empty string is accepted.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
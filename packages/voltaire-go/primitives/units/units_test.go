@@ -0,0 +1,161 @@
+package units
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+const maxU256 = "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		decimals int
+		want     string
+		wantErr  error
+	}{
+		{"whole ether", "1", Ether, "1000000000000000000", nil},
+		{"fractional ether", "1.5", Ether, "1500000000000000000", nil},
+		{"leading point", ".5", Ether, "500000000000000000", nil},
+		{"leading point, zero fraction", ".0", Wei, "0", nil},
+		{"leading point, zero ether", ".000", Ether, "0", nil},
+		{"trailing point", "2.", Ether, "2000000000000000000", nil},
+		{"smallest unit", "0.000000000000000001", Ether, "1", nil},
+		{"trailing zeros beyond decimals", "1.5000000000000000000000", Ether, "1500000000000000000", nil},
+		{"gwei", "30.5", Gwei, "30500000000", nil},
+		{"wei", "42", Wei, "42", nil},
+		{"zero", "0", Ether, "0", nil},
+		{"max u256 as ether", "115792089237316195423570985008687907853269984665640564039457.584007913129639935", Ether, maxU256, nil},
+		{"too precise", "0.0000000000000000001", Ether, "", ErrTooManyDecimals},
+		{"fraction of wei", "1.5", Wei, "", ErrTooManyDecimals},
+		{"overflow", "115792089237316195423570985008687907853269984665640564039458", Ether, "", ErrOverflow},
+		{"empty", "", Ether, "", ErrInvalidAmount},
+		{"point only", ".", Ether, "", ErrInvalidAmount},
+		{"negative", "-1", Ether, "", ErrInvalidAmount},
+		{"exponent", "1e18", Ether, "", ErrInvalidAmount},
+		{"two points", "1.2.3", Ether, "", ErrInvalidAmount},
+		{"whitespace", " 1", Ether, "", ErrInvalidAmount},
+		{"bad decimals", "1", MaxDecimals + 1, "", ErrInvalidDecimals},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUnits(tt.input, tt.decimals)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.BigInt().String() != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEtherAndGwei(t *testing.T) {
+	ether, err := ParseEther("0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ether.BigInt().String() != "100000000000000000" {
+		t.Errorf("ParseEther(0.1) = %s", ether)
+	}
+
+	gwei, err := ParseGwei("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gwei.Uint64() != 1_000_000_000 {
+		t.Errorf("ParseGwei(1) = %s", gwei)
+	}
+}
+
+func TestFormatUnits(t *testing.T) {
+	maxValue, _ := ParseUnits(maxU256, Wei)
+
+	tests := []struct {
+		name     string
+		value    u256.U256
+		decimals int
+		want     string
+	}{
+		{"zero", u256.U256{}, Ether, "0"},
+		{"one wei as ether", u256.FromUint64(1), Ether, "0.000000000000000001"},
+		{"one and a half ether", u256.FromUint64(1_500_000_000_000_000_000), Ether, "1.5"},
+		{"whole ether", u256.FromUint64(2_000_000_000_000_000_000), Ether, "2"},
+		{"gwei", u256.FromUint64(30_500_000_000), Gwei, "30.5"},
+		{"wei", u256.FromUint64(42), Wei, "42"},
+		{"max u256", maxValue, Ether, "115792089237316195423570985008687907853269984665640564039457.584007913129639935"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatUnits(tt.value, tt.decimals)
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+
+			back, err := ParseUnits(got, tt.decimals)
+			if err != nil || !back.Equal(tt.value) {
+				t.Errorf("round trip = %s, %v", back, err)
+			}
+		})
+	}
+
+	if got := FormatEther(u256.FromUint64(1_000_000_000_000_000_000)); got != "1" {
+		t.Errorf("FormatEther = %s, want 1", got)
+	}
+	if got := FormatGwei(u256.FromUint64(1_000_000_000)); got != "1" {
+		t.Errorf("FormatGwei = %s, want 1", got)
+	}
+}
+
+func TestFormatWei(t *testing.T) {
+	maxValue, _ := ParseUnits(maxU256, Wei)
+
+	tests := []struct {
+		name      string
+		value     u256.U256
+		decimals  int
+		precision int
+		want      string
+	}{
+		{"round down", u256.FromUint64(1_234_540_000_000_000_000), Ether, 4, "1.2345"},
+		{"round half up", u256.FromUint64(1_234_550_000_000_000_000), Ether, 4, "1.2346"},
+		{"carry into whole", u256.FromUint64(1_999_960_000_000_000_000), Ether, 4, "2"},
+		{"trims zeros", u256.FromUint64(1_500_000_000_000_000_000), Ether, 4, "1.5"},
+		{"rounds to zero", u256.FromUint64(1), Ether, 4, "0"},
+		{"small value", u256.FromUint64(120_000_000_000_000), Ether, 4, "0.0001"},
+		{"zero precision", u256.FromUint64(2_500_000_000_000_000_000), Ether, 0, "3"},
+		{"precision beyond decimals", u256.FromUint64(30_500_000_000), Gwei, 12, "30.5"},
+		{"max u256", maxValue, Ether, 2, "115792089237316195423570985008687907853269984665640564039457.58"},
+		{"max u256 rounds up", maxValue, 3, 0, "115792089237316195423570985008687907853269984665640564039457584007913129640"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatWei(tt.value, tt.decimals, tt.precision)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := FormatWei(u256.U256{}, Ether, -1); !errors.Is(err, ErrInvalidPrecision) {
+		t.Errorf("negative precision error = %v", err)
+	}
+	if _, err := FormatWei(u256.U256{}, -1, 0); !errors.Is(err, ErrInvalidDecimals) {
+		t.Errorf("negative decimals error = %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+// Package userop provides ERC-4337 user operation packing and hashing.
+//
+// UserOperation is the v0.6 layout with each gas field as its own word.
+// PackedUserOperation is the v0.7 layout used by EntryPoint v0.7, where
+// gas limits and fees are packed pairwise into 32-byte words.
+package userop
+
+import (
+	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+// Canonical EntryPoint deployments.
+var (
+	EntryPointV06 = address.Address{
+		0x5f, 0xf1, 0x37, 0xd4, 0xb0, 0xfd, 0xcd, 0x49, 0xdc, 0xa3,
+		0x0c, 0x7c, 0xf5, 0x7e, 0x57, 0x8a, 0x02, 0x6d, 0x27, 0x89,
+	}
+	EntryPointV07 = address.Address{
+		0x00, 0x00, 0x00, 0x00, 0x71, 0x72, 0x7d, 0xe2, 0x2e, 0x5e,
+		0x9d, 0x8b, 0xaf, 0x0e, 0xda, 0xc6, 0xf3, 0x7d, 0xa0, 0x32,
+	}
+)
+
+// Errors
+var (
+	ErrGasOverflow = errors.New("userop: gas value exceeds 128 bits")
+)
+
+// UserOperation is an ERC-4337 v0.6 user operation.
+type UserOperation struct {
+	Sender               address.Address
+	Nonce                u256.U256
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         u256.U256
+	VerificationGasLimit u256.U256
+	PreVerificationGas   u256.U256
+	MaxFeePerGas         u256.U256
+	MaxPriorityFeePerGas u256.U256
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+// PackedUserOperation is an ERC-4337 v0.7 user operation.
+type PackedUserOperation struct {
+	Sender   address.Address
+	Nonce    u256.U256
+	InitCode []byte
+	CallData []byte
+	// AccountGasLimits is verificationGasLimit (16 bytes) || callGasLimit (16 bytes).
+	AccountGasLimits   [32]byte
+	PreVerificationGas u256.U256
+	// GasFees is maxPriorityFeePerGas (16 bytes) || maxFeePerGas (16 bytes).
+	GasFees          [32]byte
+	PaymasterAndData []byte
+	Signature        []byte
+}
+
+// Hash computes the v0.6 userOpHash signed by the account:
+// keccak256(abi.encode(keccak256(pack(op)), entryPoint, chainId)).
+func (op UserOperation) Hash(entryPoint address.Address, chainID uint64) hash.Hash {
+	inner := keccak256.Hash(concatWords(
+		addressWord(op.Sender),
+		[32]byte(op.Nonce),
+		[32]byte(keccak256.Hash(op.InitCode)),
+		[32]byte(keccak256.Hash(op.CallData)),
+		[32]byte(op.CallGasLimit),
+		[32]byte(op.VerificationGasLimit),
+		[32]byte(op.PreVerificationGas),
+		[32]byte(op.MaxFeePerGas),
+		[32]byte(op.MaxPriorityFeePerGas),
+		[32]byte(keccak256.Hash(op.PaymasterAndData)),
+	))
+	return finalHash(inner, entryPoint, chainID)
+}
+
+// Pack converts op to the v0.7 packed layout.
+// Returns ErrGasOverflow if a gas limit or fee does not fit in 128 bits.
+func (op UserOperation) Pack() (PackedUserOperation, error) {
+	accountGasLimits, err := packUint128Pair(op.VerificationGasLimit, op.CallGasLimit)
+	if err != nil {
+		return PackedUserOperation{}, err
+	}
+	gasFees, err := packUint128Pair(op.MaxPriorityFeePerGas, op.MaxFeePerGas)
+	if err != nil {
+		return PackedUserOperation{}, err
+	}
+
+	return PackedUserOperation{
+		Sender:             op.Sender,
+		Nonce:              op.Nonce,
+		InitCode:           op.InitCode,
+		CallData:           op.CallData,
+		AccountGasLimits:   accountGasLimits,
+		PreVerificationGas: op.PreVerificationGas,
+		GasFees:            gasFees,
+		PaymasterAndData:   op.PaymasterAndData,
+		Signature:          op.Signature,
+	}, nil
+}
+
+// Hash computes the v0.7 userOpHash signed by the account:
+// keccak256(abi.encode(keccak256(pack(op)), entryPoint, chainId)).
+func (op PackedUserOperation) Hash(entryPoint address.Address, chainID uint64) hash.Hash {
+	inner := keccak256.Hash(concatWords(
+		addressWord(op.Sender),
+		[32]byte(op.Nonce),
+		[32]byte(keccak256.Hash(op.InitCode)),
+		[32]byte(keccak256.Hash(op.CallData)),
+		op.AccountGasLimits,
+		[32]byte(op.PreVerificationGas),
+		op.GasFees,
+		[32]byte(keccak256.Hash(op.PaymasterAndData)),
+	))
+	return finalHash(inner, entryPoint, chainID)
+}
+
+// Unpack converts op back to the v0.6 layout.
+func (op PackedUserOperation) Unpack() UserOperation {
+	verificationGasLimit, callGasLimit := unpackUint128Pair(op.AccountGasLimits)
+	maxPriorityFeePerGas, maxFeePerGas := unpackUint128Pair(op.GasFees)
+
+	return UserOperation{
+		Sender:               op.Sender,
+		Nonce:                op.Nonce,
+		InitCode:             op.InitCode,
+		CallData:             op.CallData,
+		CallGasLimit:         callGasLimit,
+		VerificationGasLimit: verificationGasLimit,
+		PreVerificationGas:   op.PreVerificationGas,
+		MaxFeePerGas:         maxFeePerGas,
+		MaxPriorityFeePerGas: maxPriorityFeePerGas,
+		PaymasterAndData:     op.PaymasterAndData,
+		Signature:            op.Signature,
+	}
+}
+
+// finalHash binds the inner hash to an EntryPoint and chain.
+func finalHash(inner hash.Hash, entryPoint address.Address, chainID uint64) hash.Hash {
+	return keccak256.Hash(concatWords(
+		[32]byte(inner),
+		addressWord(entryPoint),
+		[32]byte(u256.FromUint64(chainID)),
+	))
+}
+
+// packUint128Pair packs hi and lo into one word as hi (16 bytes) || lo (16 bytes).
+func packUint128Pair(hi, lo u256.U256) ([32]byte, error) {
+	var out [32]byte
+	for i := 0; i < 16; i++ {
+		if hi[i] != 0 || lo[i] != 0 {
+			return out, ErrGasOverflow
+		}
+	}
+	copy(out[:16], hi[16:])
+	copy(out[16:], lo[16:])
+	return out, nil
+}
+
+func unpackUint128Pair(word [32]byte) (hi, lo u256.U256) {
+	copy(hi[16:], word[:16])
+	copy(lo[16:], word[16:])
+	return hi, lo
+}
+
+// addressWord left-pads an address to a 32-byte ABI word.
+func addressWord(a address.Address) [32]byte {
+	var w [32]byte
+	copy(w[12:], a[:])
+	return w
+}
+
+// concatWords concatenates 32-byte words into a static ABI encoding.
+func concatWords(words ...[32]byte) []byte {
+	out := make([]byte, 0, 32*len(words))
+	for _, w := range words {
+		out = append(out, w[:]...)
+	}
+	return out
+}
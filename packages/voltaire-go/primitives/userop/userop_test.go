@@ -0,0 +1,115 @@
+package userop
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/u256"
+)
+
+func testOp() UserOperation {
+	return UserOperation{
+		Sender:               address.MustFromHex("0x1306b01bc3e4ad202612d3843387e94737673f53"),
+		Nonce:                u256.FromUint64(7),
+		CallData:             []byte{0xb6, 0x1d, 0x27, 0xf6},
+		CallGasLimit:         u256.FromUint64(200000),
+		VerificationGasLimit: u256.FromUint64(100000),
+		PreVerificationGas:   u256.FromUint64(50000),
+		MaxFeePerGas:         u256.FromUint64(2000000000),
+		MaxPriorityFeePerGas: u256.FromUint64(1000000000),
+	}
+}
+
+func TestEntryPointAddresses(t *testing.T) {
+	if got := EntryPointV06.Hex(); got != "0x5ff137d4b0fdcd49dca30c7cf57e578a026d2789" {
+		t.Errorf("EntryPointV06 = %s", got)
+	}
+	if got := EntryPointV07.Hex(); got != "0x0000000071727de22e5e9d8baf0edac6f37da032" {
+		t.Errorf("EntryPointV07 = %s", got)
+	}
+}
+
+func TestUserOperationHash(t *testing.T) {
+	got := testOp().Hash(EntryPointV06, 1).Hex()
+	want := "0x2c916bf0cc3f774ba45e18ba1174ff0ebb7c9a2076e11614a80fd98516762b9f"
+	if got != want {
+		t.Errorf("Hash() = %s, want %s", got, want)
+	}
+}
+
+func TestPackedUserOperationHash(t *testing.T) {
+	packed, err := testOp().Pack()
+	if err != nil {
+		t.Fatalf("Pack() error: %v", err)
+	}
+
+	got := packed.Hash(EntryPointV07, 1).Hex()
+	want := "0x8f16ef08d92c5cac7f1693dc129db72b0abf7a300cffe83d44257c7aa8d3d075"
+	if got != want {
+		t.Errorf("Hash() = %s, want %s", got, want)
+	}
+
+	// Hash binds the EntryPoint and chain
+	if packed.Hash(EntryPointV07, 10) == packed.Hash(EntryPointV07, 1) {
+		t.Error("hash should depend on chain ID")
+	}
+	if packed.Hash(EntryPointV06, 1) == packed.Hash(EntryPointV07, 1) {
+		t.Error("hash should depend on EntryPoint")
+	}
+}
+
+func TestPack(t *testing.T) {
+	packed, err := testOp().Pack()
+	if err != nil {
+		t.Fatalf("Pack() error: %v", err)
+	}
+
+	// verificationGasLimit (100000) || callGasLimit (200000)
+	var wantLimits [32]byte
+	wantLimits[13], wantLimits[14], wantLimits[15] = 0x01, 0x86, 0xa0
+	wantLimits[29], wantLimits[30], wantLimits[31] = 0x03, 0x0d, 0x40
+	if packed.AccountGasLimits != wantLimits {
+		t.Errorf("AccountGasLimits = %x, want %x", packed.AccountGasLimits, wantLimits)
+	}
+
+	// maxPriorityFeePerGas (1 gwei) || maxFeePerGas (2 gwei)
+	var wantFees [32]byte
+	copy(wantFees[12:16], []byte{0x3b, 0x9a, 0xca, 0x00})
+	copy(wantFees[28:32], []byte{0x77, 0x35, 0x94, 0x00})
+	if packed.GasFees != wantFees {
+		t.Errorf("GasFees = %x, want %x", packed.GasFees, wantFees)
+	}
+}
+
+func TestPackUnpackRoundtrip(t *testing.T) {
+	op := testOp()
+	op.InitCode = []byte{0x01, 0x02}
+	op.PaymasterAndData = []byte{0x03}
+	op.Signature = []byte{0x04, 0x05}
+
+	packed, err := op.Pack()
+	if err != nil {
+		t.Fatalf("Pack() error: %v", err)
+	}
+	got := packed.Unpack()
+
+	if got.CallGasLimit != op.CallGasLimit || got.VerificationGasLimit != op.VerificationGasLimit {
+		t.Error("gas limits mismatch after roundtrip")
+	}
+	if got.MaxFeePerGas != op.MaxFeePerGas || got.MaxPriorityFeePerGas != op.MaxPriorityFeePerGas {
+		t.Error("gas fees mismatch after roundtrip")
+	}
+	if !bytes.Equal(got.InitCode, op.InitCode) || !bytes.Equal(got.Signature, op.Signature) {
+		t.Error("byte fields mismatch after roundtrip")
+	}
+}
+
+func TestPackGasOverflow(t *testing.T) {
+	op := testOp()
+	op.MaxFeePerGas[15] = 1 // 2^128
+
+	if _, err := op.Pack(); err != ErrGasOverflow {
+		t.Errorf("Pack() error = %v, want ErrGasOverflow", err)
+	}
+}
@@ -0,0 +1,79 @@
+// Package withdrawal provides EIP-4895 beacon chain withdrawals.
+package withdrawal
+
+import (
+	"errors"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/hash"
+	"github.com/voltaire-labs/voltaire-go/primitives/rlp"
+	"github.com/voltaire-labs/voltaire-go/primitives/trie"
+)
+
+// Errors
+var (
+	ErrInvalidRLP   = errors.New("withdrawal: invalid RLP structure")
+	ErrInvalidField = errors.New("withdrawal: invalid field")
+)
+
+// Withdrawal is a validator withdrawal pushed from the beacon chain to the
+// execution layer.
+type Withdrawal struct {
+	Index          uint64
+	ValidatorIndex uint64
+	Address        address.Address
+	// Amount is in gwei.
+	Amount uint64
+}
+
+// EncodeRLP returns rlp([index, validatorIndex, address, amount]).
+func (w Withdrawal) EncodeRLP() []byte {
+	// Cannot fail: all items are supported types
+	encoded, _ := rlp.EncodeList([]interface{}{w.Index, w.ValidatorIndex, w.Address[:], w.Amount})
+	return encoded
+}
+
+// DecodeRLP decodes an RLP-encoded withdrawal.
+func DecodeRLP(data []byte) (Withdrawal, error) {
+	decoded, err := rlp.DecodeBytes(data)
+	if err != nil {
+		return Withdrawal{}, err
+	}
+	fields, ok := decoded.([]interface{})
+	if !ok || len(fields) != 4 {
+		return Withdrawal{}, ErrInvalidRLP
+	}
+
+	var raw [4][]byte
+	for i, f := range fields {
+		if raw[i], ok = f.([]byte); !ok {
+			return Withdrawal{}, ErrInvalidField
+		}
+	}
+	if len(raw[2]) != address.Size {
+		return Withdrawal{}, ErrInvalidField
+	}
+
+	var w Withdrawal
+	copy(w.Address[:], raw[2])
+	for _, f := range []struct {
+		dst *uint64
+		src []byte
+	}{{&w.Index, raw[0]}, {&w.ValidatorIndex, raw[1]}, {&w.Amount, raw[3]}} {
+		if *f.dst, err = rlp.Uint64FromBytes(f.src); err != nil {
+			return Withdrawal{}, ErrInvalidField
+		}
+	}
+	return w, nil
+}
+
+// DeriveRoot computes the withdrawals root of a block: the root of the
+// trie mapping rlp(index) to each withdrawal's RLP encoding, where index
+// is the position in the block (not Withdrawal.Index).
+func DeriveRoot(withdrawals []Withdrawal) hash.Hash {
+	values := make([][]byte, len(withdrawals))
+	for i, w := range withdrawals {
+		values[i] = w.EncodeRLP()
+	}
+	return trie.OrderedRoot(values)
+}
@@ -0,0 +1,96 @@
+package withdrawal
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/primitives/address"
+	"github.com/voltaire-labs/voltaire-go/primitives/trie"
+)
+
+func sample() Withdrawal {
+	return Withdrawal{
+		Index:          0x1c4,
+		ValidatorIndex: 0x8e21,
+		Address:        address.MustFromHex("0xb9d7934878b5fb9610b3fe8a5e441e8fad7e293f"),
+		Amount:         0xf4240,
+	}
+}
+
+func TestEncodeRLP(t *testing.T) {
+	// [0x01c4, 0x8e21, address, 0x0f4240]
+	want := "df" + "8201c4" + "828e21" + "94b9d7934878b5fb9610b3fe8a5e441e8fad7e293f" + "830f4240"
+	if got := hex.EncodeToString(sample().EncodeRLP()); got != want {
+		t.Errorf("EncodeRLP() = %s, want %s", got, want)
+	}
+
+	// Zero fields encode as the empty string
+	if got := hex.EncodeToString(Withdrawal{}.EncodeRLP()); got != "d8808094000000000000000000000000000000000000000080" {
+		t.Errorf("zero withdrawal = %s", got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, w := range []Withdrawal{sample(), {}, {Index: 1<<64 - 1, Amount: 32_000_000_000}} {
+		got, err := DecodeRLP(w.EncodeRLP())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != w {
+			t.Errorf("got %+v, want %+v", got, w)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{"not a list", "80", ErrInvalidRLP},
+		{"three fields", "c3010203", ErrInvalidRLP},
+		{"short address", "c701028301020303", ErrInvalidField},
+		{"nested list", "c4c0010203", ErrInvalidField},
+		{"leading zero", "da8200010294b9d7934878b5fb9610b3fe8a5e441e8fad7e293f03", ErrInvalidField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tt.data)
+			if _, err := DecodeRLP(data); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeriveRoot(t *testing.T) {
+	if got := DeriveRoot(nil); got != trie.EmptyRoot {
+		t.Errorf("DeriveRoot(nil) = %s, want empty root", got.Hex())
+	}
+
+	// A full payload of 16 consecutive withdrawals, as in a mainnet
+	// sweep, and its first withdrawal alone. Expected roots come from an
+	// independent RLP and trie implementation.
+	ws := make([]Withdrawal, 16)
+	for i := range ws {
+		ws[i] = sample()
+		ws[i].Index += uint64(i)
+		ws[i].ValidatorIndex += uint64(i)
+		ws[i].Amount += uint64(i)
+	}
+	tests := []struct {
+		ws   []Withdrawal
+		want string
+	}{
+		{ws[:1], "0x54a8bd2d0ebf6ad2c587e557cbcbb79eef89c974ab3378c9dc421eceb3ea6ec3"},
+		{ws, "0x41839b23f292691eaa787e8ec300bb1defa69781dc4a9246fe55db0c783a64df"},
+	}
+	for _, tt := range tests {
+		if got := DeriveRoot(tt.ws); got.Hex() != tt.want {
+			t.Errorf("DeriveRoot(%d withdrawals) = %s, want %s", len(tt.ws), got.Hex(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package voltaire
+
+import "github.com/voltaire-labs/voltaire-go/internal/ffi"
+
+// CallStats summarizes the calls made into the native library through one
+// wrapper: how often it ran, the bytes passed in and returned, and the
+// wall time spent, conversions included.
+//
+// Wrappers pass Go memory directly or copy it into C buffers; none of them
+// pin Go allocations across calls, so there is no pinning to report.
+type CallStats = ffi.CallStats
+
+// EnableCGOStats turns per-call accounting of native library calls on or
+// off. It is off by default; when off, each wrapper pays one atomic load.
+// The counters are process-wide.
+func EnableCGOStats(on bool) {
+	ffi.EnableStats(on)
+}
+
+// CGOStats returns the counters of every wrapper called since the last
+// reset, sorted by function name, and the number of cgo transitions the
+// process made over the same period. The transition count comes from
+// runtime.NumCgoCall, so it includes the helper calls each wrapper makes
+// (allocation, copying, freeing) and any cgo use outside this module.
+func CGOStats() ([]CallStats, int64) {
+	return ffi.ReadStats()
+}
+
+// ResetCGOStats clears the counters.
+func ResetCGOStats() {
+	ffi.ResetStats()
+}
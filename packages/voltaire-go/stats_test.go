@@ -0,0 +1,40 @@
+package voltaire
+
+import (
+	"testing"
+
+	"github.com/voltaire-labs/voltaire-go/crypto/keccak256"
+)
+
+func TestCGOStats(t *testing.T) {
+	ResetCGOStats()
+	keccak256.Hash([]byte("untracked"))
+	if stats, _ := CGOStats(); len(stats) != 0 {
+		t.Errorf("CGOStats() with accounting off = %+v", stats)
+	}
+
+	EnableCGOStats(true)
+	defer EnableCGOStats(false)
+	defer ResetCGOStats()
+
+	keccak256.Hash([]byte("abc"))
+	keccak256.Hash(make([]byte, 100))
+
+	stats, transitions := CGOStats()
+	var found bool
+	for _, s := range stats {
+		if s.Function != "Keccak256" {
+			continue
+		}
+		found = true
+		if s.Calls != 2 || s.BytesIn != 103 || s.BytesOut != 64 {
+			t.Errorf("Keccak256 stats = %+v", s)
+		}
+	}
+	if !found {
+		t.Errorf("CGOStats() = %+v, missing Keccak256", stats)
+	}
+	if transitions < 2 {
+		t.Errorf("transitions = %d, want at least 2", transitions)
+	}
+}
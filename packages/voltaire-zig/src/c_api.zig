@@ -97,14 +97,12 @@ export fn primitives_address_is_zero(
     return addr.isZero();
 }
 
-/// Compare two addresses for equality
+/// Compare two addresses for equality (constant-time)
 export fn primitives_address_equals(
     a: *const PrimitivesAddress,
     b: *const PrimitivesAddress,
 ) bool {
-    const addr_a = primitives.Address{ .bytes = a.bytes };
-    const addr_b = primitives.Address{ .bytes = b.bytes };
-    return addr_a.equals(addr_b);
+    return crypto.constant_time.constantTimeEqBytes(&a.bytes, &b.bytes);
 }
 
 /// Validate EIP-55 checksum
@@ -115,6 +113,37 @@ export fn primitives_address_validate_checksum(
     return primitives.Address.isValidChecksum(hex_slice);
 }
 
+/// Convert `count` addresses to checksummed hex in one call
+/// buf must be at least 42 * count bytes; results are not null-terminated
+export fn primitives_address_to_checksum_hex_batch(
+    addresses: [*]const PrimitivesAddress,
+    count: usize,
+    buf: [*]u8,
+) c_int {
+    for (addresses[0..count], 0..) |address, i| {
+        const addr = primitives.Address{ .bytes = address.bytes };
+        const hex = primitives.Address.toChecksummed(addr);
+        @memcpy(buf[i * 42 .. (i + 1) * 42], &hex);
+    }
+    return PRIMITIVES_SUCCESS;
+}
+
+/// Validate the EIP-55 checksums of `count` hex strings in one call
+/// The strings are concatenated in `hex`, string i being `lens[i]` bytes long
+export fn primitives_address_validate_checksum_batch(
+    hex: [*]const u8,
+    lens: [*]const usize,
+    count: usize,
+    out_valid: [*]bool,
+) c_int {
+    var offset: usize = 0;
+    for (lens[0..count], 0..) |len, i| {
+        out_valid[i] = primitives.Address.isValidChecksum(hex[offset .. offset + len]);
+        offset += len;
+    }
+    return PRIMITIVES_SUCCESS;
+}
+
 // ============================================================================
 // Keccak-256 API
 // ============================================================================
@@ -160,7 +189,16 @@ export fn primitives_hash_equals(
     a: *const PrimitivesHash,
     b: *const PrimitivesHash,
 ) bool {
-    return crypto.HashUtils.equal(a.bytes, b.bytes);
+    return crypto.constant_time.constantTimeEqBytes(&a.bytes, &b.bytes);
+}
+
+/// Compare two byte buffers of the same length in constant time
+export fn primitives_constant_time_equal(
+    a: [*]const u8,
+    b: [*]const u8,
+    len: usize,
+) bool {
+    return crypto.constant_time.constantTimeEqBytes(a[0..len], b[0..len]);
 }
 
 // ============================================================================
@@ -322,6 +360,33 @@ export fn primitives_calculate_create_address(
     return PRIMITIVES_SUCCESS;
 }
 
+/// Calculate the CREATE addresses for `count` consecutive nonces starting
+/// at start_nonce in one call
+/// out_addresses must hold `count` addresses
+export fn primitives_calculate_create_addresses(
+    sender: *const PrimitivesAddress,
+    start_nonce: u64,
+    count: usize,
+    out_addresses: [*]PrimitivesAddress,
+) c_int {
+    if (count > 0 and @as(u64, @intCast(count - 1)) > std.math.maxInt(u64) - start_nonce) {
+        return PRIMITIVES_ERROR_INVALID_INPUT;
+    }
+    const sender_addr = primitives.Address{ .bytes = sender.bytes };
+
+    var stack_buf: [1024]u8 = undefined;
+    var fba = std.heap.FixedBufferAllocator.init(&stack_buf);
+
+    for (0..count) |i| {
+        fba.reset();
+        const addr = primitives.Address.calculateCreateAddress(fba.allocator(), sender_addr, start_nonce + @as(u64, @intCast(i))) catch {
+            return PRIMITIVES_ERROR_OUT_OF_MEMORY;
+        };
+        @memcpy(&out_addresses[i].bytes, &addr.bytes);
+    }
+    return PRIMITIVES_SUCCESS;
+}
+
 // ============================================================================
 // Cryptographic Signatures (secp256k1)
 // ============================================================================
@@ -421,6 +486,160 @@ export fn primitives_secp256k1_validate_signature(
     return crypto.secp256k1.unauditedValidateSignature(r_u256, s_u256);
 }
 
+// ============================================================================
+// BLS12-381 Signatures (Ethereum consensus: public keys in G1, signatures in G2)
+// ============================================================================
+
+// blst is not linked into WASM builds, so these return
+// PRIMITIVES_ERROR_UNSUPPORTED_TYPE there.
+const bls = crypto.bls12_381;
+
+/// Derive the compressed public key of a BLS secret key
+export fn primitives_bls_secret_to_public(
+    secret_key: *const [32]u8,
+    out_public_key: *[48]u8,
+) c_int {
+    if (is_wasm_target) return PRIMITIVES_ERROR_UNSUPPORTED_TYPE;
+    var sk = bls.SecretKey.fromBytes(secret_key) catch {
+        return PRIMITIVES_ERROR_INVALID_INPUT;
+    };
+    defer sk.clear();
+
+    const pk = sk.toPublicKey();
+    out_public_key.* = pk.toCompressed();
+    return PRIMITIVES_SUCCESS;
+}
+
+/// Sign a message with the Ethereum proof-of-possession ciphersuite
+export fn primitives_bls_sign(
+    secret_key: *const [32]u8,
+    message: [*]const u8,
+    message_len: usize,
+    out_signature: *[96]u8,
+) c_int {
+    if (is_wasm_target) return PRIMITIVES_ERROR_UNSUPPORTED_TYPE;
+    var sk = bls.SecretKey.fromBytes(secret_key) catch {
+        return PRIMITIVES_ERROR_INVALID_INPUT;
+    };
+    defer sk.clear();
+
+    const sig = bls.sign(&sk, message[0..message_len], bls.DST.ETH2_SIGNATURE) catch {
+        return PRIMITIVES_ERROR_INVALID_INPUT;
+    };
+    out_signature.* = sig.toCompressed();
+    return PRIMITIVES_SUCCESS;
+}
+
+/// Verify a signature over a message
+/// Returns 1 if valid, 0 if invalid (including malformed keys or signatures)
+export fn primitives_bls_verify(
+    public_key: *const [48]u8,
+    message: [*]const u8,
+    message_len: usize,
+    signature: *const [96]u8,
+) c_int {
+    if (is_wasm_target) return PRIMITIVES_ERROR_UNSUPPORTED_TYPE;
+    const pk = bls.PublicKey.fromCompressed(public_key) catch return 0;
+    const sig = bls.Signature.fromCompressed(signature) catch return 0;
+    const valid = bls.verify(&sig, &pk, message[0..message_len], bls.DST.ETH2_SIGNATURE) catch return 0;
+    return @intFromBool(valid);
+}
+
+/// Aggregate `count` compressed signatures into one
+export fn primitives_bls_aggregate_signatures(
+    signatures: [*]const [96]u8,
+    count: usize,
+    out_signature: *[96]u8,
+) c_int {
+    if (is_wasm_target) return PRIMITIVES_ERROR_UNSUPPORTED_TYPE;
+    if (count == 0) return PRIMITIVES_ERROR_INVALID_INPUT;
+
+    var agg = bls.Signature.fromCompressed(&signatures[0]) catch {
+        return PRIMITIVES_ERROR_INVALID_SIGNATURE;
+    };
+    for (1..count) |i| {
+        const sig = bls.Signature.fromCompressed(&signatures[i]) catch {
+            return PRIMITIVES_ERROR_INVALID_SIGNATURE;
+        };
+        agg = bls.aggregateSignatures(&[_]*const bls.Signature{ &agg, &sig }) catch {
+            return PRIMITIVES_ERROR_INVALID_SIGNATURE;
+        };
+    }
+    out_signature.* = agg.toCompressed();
+    return PRIMITIVES_SUCCESS;
+}
+
+/// Decode, validate and sum `count` compressed public keys
+fn blsAggregatePublicKeys(public_keys: [*]const [48]u8, count: usize) ?bls.PublicKey {
+    if (count == 0) return null;
+
+    var agg: bls.PublicKey = undefined;
+    for (0..count) |i| {
+        const pk = bls.PublicKey.fromCompressed(&public_keys[i]) catch return null;
+        pk.validate() catch return null;
+        agg = if (i == 0) pk else bls.aggregatePublicKeys(&[_]*const bls.PublicKey{ &agg, &pk }) catch return null;
+    }
+    return agg;
+}
+
+/// Aggregate `count` compressed public keys into one
+export fn primitives_bls_aggregate_public_keys(
+    public_keys: [*]const [48]u8,
+    count: usize,
+    out_public_key: *[48]u8,
+) c_int {
+    if (is_wasm_target) return PRIMITIVES_ERROR_UNSUPPORTED_TYPE;
+    const agg = blsAggregatePublicKeys(public_keys, count) orelse {
+        return PRIMITIVES_ERROR_INVALID_INPUT;
+    };
+    out_public_key.* = agg.toCompressed();
+    return PRIMITIVES_SUCCESS;
+}
+
+/// Verify an aggregate signature by `count` signers over the same message
+/// Returns 1 if valid, 0 if invalid (including malformed keys or signatures)
+export fn primitives_bls_fast_aggregate_verify(
+    public_keys: [*]const [48]u8,
+    count: usize,
+    message: [*]const u8,
+    message_len: usize,
+    signature: *const [96]u8,
+) c_int {
+    if (is_wasm_target) return PRIMITIVES_ERROR_UNSUPPORTED_TYPE;
+    const agg = blsAggregatePublicKeys(public_keys, count) orelse return 0;
+    const sig = bls.Signature.fromCompressed(signature) catch return 0;
+    const valid = bls.verify(&sig, &agg, message[0..message_len], bls.DST.ETH2_SIGNATURE) catch return 0;
+    return @intFromBool(valid);
+}
+
+// ============================================================================
+// BN254 (alt_bn128) precompile operations (EIP-196/197)
+// ============================================================================
+
+/// BN254 point addition (precompile 0x06)
+/// input: 128 bytes, two G1 points as x || y
+/// out_point: 64-byte G1 point output
+export fn primitives_bn254_add(input: *const [128]u8, out_point: *[64]u8) c_int {
+    crypto.bn254.bn254Add(input, out_point) catch return PRIMITIVES_ERROR_INVALID_INPUT;
+    return PRIMITIVES_SUCCESS;
+}
+
+/// BN254 scalar multiplication (precompile 0x07)
+/// input: 96 bytes, G1 point || 32-byte scalar
+/// out_point: 64-byte G1 point output
+export fn primitives_bn254_mul(input: *const [96]u8, out_point: *[64]u8) c_int {
+    crypto.bn254.bn254Mul(input, out_point) catch return PRIMITIVES_ERROR_INVALID_INPUT;
+    return PRIMITIVES_SUCCESS;
+}
+
+/// BN254 pairing check (precompile 0x08)
+/// input: k * 192 bytes of G1 (64) || G2 (128) pairs
+/// Returns 1 if the product of pairings is one, 0 if not, negative on error
+export fn primitives_bn254_pairing(input: [*]const u8, input_len: usize) c_int {
+    const ok = crypto.bn254.bn254Pairing(input[0..input_len]) catch return PRIMITIVES_ERROR_INVALID_INPUT;
+    return @intFromBool(ok);
+}
+
 // ============================================================================
 // WASM-specific secp256k1 functions (inline to avoid module conflicts)
 // ============================================================================
@@ -1038,6 +1257,53 @@ export fn primitives_blake2b(
     return PRIMITIVES_SUCCESS;
 }
 
+// ============================================================================
+// ENS Normalization (ENSIP-15)
+// ============================================================================
+
+/// Normalize an ENS name per ENSIP-15
+/// Returns the number of bytes written, or negative error code
+export fn primitives_ens_normalize(
+    name: [*]const u8,
+    name_len: usize,
+    out_buf: [*]u8,
+    buf_len: usize,
+) c_int {
+    return ensTransform(primitives.Ens.normalize, name[0..name_len], out_buf, buf_len);
+}
+
+/// Beautify an ENS name per ENSIP-15 (normalize, restoring emoji presentation)
+/// Returns the number of bytes written, or negative error code
+export fn primitives_ens_beautify(
+    name: [*]const u8,
+    name_len: usize,
+    out_buf: [*]u8,
+    buf_len: usize,
+) c_int {
+    return ensTransform(primitives.Ens.beautify, name[0..name_len], out_buf, buf_len);
+}
+
+fn ensTransform(
+    comptime transform: anytype,
+    name: []const u8,
+    out_buf: [*]u8,
+    buf_len: usize,
+) c_int {
+    const allocator = std.heap.page_allocator;
+
+    const result = transform(allocator, name) catch |err| {
+        if (err == error.OutOfMemory) return PRIMITIVES_ERROR_OUT_OF_MEMORY;
+        return PRIMITIVES_ERROR_INVALID_INPUT;
+    };
+    defer allocator.free(result);
+
+    if (result.len > buf_len) {
+        return PRIMITIVES_ERROR_INVALID_LENGTH;
+    }
+    @memcpy(out_buf[0..result.len], result);
+    return @intCast(result.len);
+}
+
 // ============================================================================
 // CREATE2 Address Calculation
 // ============================================================================
@@ -1072,6 +1338,30 @@ export fn primitives_calculate_create2_address(
     return PRIMITIVES_SUCCESS;
 }
 
+/// Calculate CREATE2 addresses for `count` salts in one call, given the
+/// keccak256 hash of the init code
+/// salts holds `count` 32-byte salts back to back; out_addresses must hold `count` addresses
+export fn primitives_calculate_create2_addresses(
+    sender: *const PrimitivesAddress,
+    init_code_hash: *const [32]u8,
+    salts: [*]const [32]u8,
+    count: usize,
+    out_addresses: [*]PrimitivesAddress,
+) c_int {
+    // 0xff ++ sender ++ salt ++ init_code_hash, with only the salt changing
+    var buf: [85]u8 = undefined;
+    buf[0] = 0xff;
+    @memcpy(buf[1..21], &sender.bytes);
+    @memcpy(buf[53..85], init_code_hash);
+
+    for (salts[0..count], 0..) |salt, i| {
+        @memcpy(buf[21..53], &salt);
+        const hash = crypto.HashUtils.keccak256(&buf);
+        @memcpy(&out_addresses[i].bytes, hash[12..32]);
+    }
+    return PRIMITIVES_SUCCESS;
+}
+
 // ============================================================================
 // ABI Encoding/Decoding API
 // ============================================================================
@@ -3202,6 +3492,17 @@ export fn kzg_load_trusted_setup() c_int {
     return PRIMITIVES_SUCCESS;
 }
 
+/// Load trusted setup from the text format of the c-kzg-4844 setup file
+/// text: pointer to the file contents
+/// text_len: length of the contents in bytes
+/// Fails if a setup is already loaded
+export fn kzg_load_trusted_setup_from_text(text: [*]const u8, text_len: usize) c_int {
+    crypto.c_kzg.loadTrustedSetupFromText(text[0..text_len], 0) catch {
+        return PRIMITIVES_ERROR_KZG_NOT_LOADED;
+    };
+    return PRIMITIVES_SUCCESS;
+}
+
 /// Free trusted setup
 export fn kzg_free_trusted_setup() c_int {
     crypto.c_kzg.freeTrustedSetup() catch {